@@ -0,0 +1,141 @@
+package gym
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BenchmarkConfig holds configuration options for Benchmark.
+type BenchmarkConfig struct {
+	Seed        int64 // Seed used for the environment and its action space (defaults to 42)
+	WarmupSteps int   // Number of untimed steps taken before timing (defaults to 100)
+	Steps       int   // Number of timed steps (defaults to 10000)
+}
+
+// BenchmarkResult holds the measurements of a Benchmark run.
+type BenchmarkResult struct {
+	Steps     int           // Number of timed steps
+	Resets    int           // Number of timed resets
+	Episodes  int           // Number of episodes completed during timing
+	StepTime  time.Duration // Total time spent in Step
+	ResetTime time.Duration // Total time spent in Reset
+}
+
+// StepsPerSecond returns the step throughput of the environment.
+func (r *BenchmarkResult) StepsPerSecond() float64 {
+	if r.StepTime <= 0 {
+		return 0
+	}
+	return float64(r.Steps) / r.StepTime.Seconds()
+}
+
+// ResetsPerSecond returns the reset throughput of the environment.
+func (r *BenchmarkResult) ResetsPerSecond() float64 {
+	if r.ResetTime <= 0 {
+		return 0
+	}
+	return float64(r.Resets) / r.ResetTime.Seconds()
+}
+
+// NsPerStep returns the average time of a single Step in nanoseconds.
+func (r *BenchmarkResult) NsPerStep() float64 {
+	if r.Steps == 0 {
+		return 0
+	}
+	return float64(r.StepTime.Nanoseconds()) / float64(r.Steps)
+}
+
+// NsPerReset returns the average time of a single Reset in nanoseconds.
+func (r *BenchmarkResult) NsPerReset() float64 {
+	if r.Resets == 0 {
+		return 0
+	}
+	return float64(r.ResetTime.Nanoseconds()) / float64(r.Resets)
+}
+
+// String returns the standardized report of this result.
+func (r *BenchmarkResult) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "steps:    %d (%.0f ns/step, %.0f steps/s)\n", r.Steps, r.NsPerStep(), r.StepsPerSecond())
+	fmt.Fprintf(&sb, "resets:   %d (%.0f ns/reset, %.0f resets/s)\n", r.Resets, r.NsPerReset(), r.ResetsPerSecond())
+	fmt.Fprintf(&sb, "episodes: %d\n", r.Episodes)
+	return sb.String()
+}
+
+// Benchmark measures the reset and step throughput of an environment under a random policy.
+//
+// The environment and its action space are seeded with a fixed seed so that repeated runs execute
+// the same trajectories. A number of untimed warm-up steps are taken before timing starts.
+// Episodes that terminate or truncate are reset, and the reset time is reported separately.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - env: The environment to benchmark
+//   - config: Configuration options for the benchmark (nil uses the defaults)
+//
+// Returns:
+//   - The benchmark measurements
+//   - An error if the configuration is invalid or the environment fails
+func Benchmark[Obs any, Act any](ctx context.Context, env Env[Obs, Act], config *BenchmarkConfig) (*BenchmarkResult, error) {
+	cfg := BenchmarkConfig{Seed: 42, WarmupSteps: 100, Steps: 10000}
+	if config != nil {
+		if config.Seed != 0 {
+			cfg.Seed = config.Seed
+		}
+		if config.WarmupSteps != 0 {
+			cfg.WarmupSteps = config.WarmupSteps
+		}
+		if config.Steps != 0 {
+			cfg.Steps = config.Steps
+		}
+	}
+	if cfg.WarmupSteps < 0 || cfg.Steps < 0 {
+		return nil, fmt.Errorf("warmup steps and steps must be non-negative, got %d and %d", cfg.WarmupSteps, cfg.Steps)
+	}
+
+	if _, err := env.ActionSpace().Seed(cfg.Seed); err != nil {
+		return nil, fmt.Errorf("failed to seed action space: %w", err)
+	}
+	if _, _, err := env.Reset(ctx, &cfg.Seed, nil); err != nil {
+		return nil, fmt.Errorf("failed to reset environment: %w", err)
+	}
+
+	result := &BenchmarkResult{}
+	for i := range cfg.WarmupSteps + cfg.Steps {
+		timed := i >= cfg.WarmupSteps
+
+		action, err := env.ActionSpace().Sample(nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sample action: %w", err)
+		}
+
+		start := time.Now()
+		_, _, terminated, truncated, _, err := env.Step(ctx, action)
+		elapsed := time.Since(start)
+		if err != nil {
+			return nil, fmt.Errorf("failed to step environment: %w", err)
+		}
+		if timed {
+			result.Steps++
+			result.StepTime += elapsed
+		}
+
+		if terminated || truncated {
+			start := time.Now()
+			_, _, err := env.Reset(ctx, nil, nil)
+			elapsed := time.Since(start)
+			if err != nil {
+				return nil, fmt.Errorf("failed to reset environment: %w", err)
+			}
+			if timed {
+				result.Resets++
+				result.ResetTime += elapsed
+				result.Episodes++
+			}
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,239 @@
+package gym
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// checkSeed is the seed used by CheckEnv for its resets.
+const checkSeed = 42
+
+// Severity is the severity of a failed check of CheckEnvReport.
+type Severity int
+
+const (
+	SeverityWarning Severity = iota // The environment works but deviates from a convention
+	SeverityError                   // The environment violates the Env contract
+)
+
+// String returns the name of the severity, "warning" or "error".
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
+
+// MarshalText encodes the severity as its name, so reports encode to readable JSON.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// CheckStatus is the outcome of a check of CheckEnvReport.
+type CheckStatus int
+
+const (
+	CheckPassed  CheckStatus = iota // The check passed
+	CheckFailed                     // The check failed
+	CheckSkipped                    // The check could not run because an earlier step failed
+)
+
+// String returns the name of the status, "passed", "failed" or "skipped".
+func (s CheckStatus) String() string {
+	switch s {
+	case CheckPassed:
+		return "passed"
+	case CheckFailed:
+		return "failed"
+	case CheckSkipped:
+		return "skipped"
+	default:
+		return fmt.Sprintf("CheckStatus(%d)", int(s))
+	}
+}
+
+// MarshalText encodes the status as its name, so reports encode to readable JSON.
+func (s CheckStatus) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// CheckResult is the result of a single check of CheckEnvReport.
+type CheckResult struct {
+	Name     string      `json:"name"`              // Stable identifier of the check, e.g. "reset_determinism"
+	Status   CheckStatus `json:"status"`            // Whether the check passed, failed or was skipped
+	Severity Severity    `json:"severity"`          // Severity of the check if it fails
+	Details  string      `json:"details,omitempty"` // Why the check failed or was skipped, empty if it passed
+}
+
+// CheckReport is the machine-readable report of CheckEnvReport, with one result per check in the order they ran.
+type CheckReport struct {
+	Results []CheckResult `json:"results"`
+}
+
+// Errors returns the failed checks of error severity.
+func (r *CheckReport) Errors() []CheckResult {
+	return r.failed(SeverityError)
+}
+
+// Warnings returns the failed checks of warning severity.
+func (r *CheckReport) Warnings() []CheckResult {
+	return r.failed(SeverityWarning)
+}
+
+// OK reports whether no check of error severity failed, ignoring warnings.
+func (r *CheckReport) OK() bool {
+	return len(r.Errors()) == 0
+}
+
+// failed returns the failed checks of the given severity.
+func (r *CheckReport) failed(severity Severity) []CheckResult {
+	var results []CheckResult
+	for _, res := range r.Results {
+		if res.Status == CheckFailed && res.Severity == severity {
+			results = append(results, res)
+		}
+	}
+	return results
+}
+
+// add records the result of a check, which failed with the given details unless they are empty.
+func (r *CheckReport) add(name string, severity Severity, details string) {
+	status := CheckPassed
+	if details != "" {
+		status = CheckFailed
+	}
+	r.Results = append(r.Results, CheckResult{Name: name, Status: status, Severity: severity, Details: details})
+}
+
+// skip records checks that could not run, with the reason.
+func (r *CheckReport) skip(reason string, names ...string) {
+	for _, name := range names {
+		r.Results = append(r.Results, CheckResult{Name: name, Status: CheckSkipped, Severity: SeverityError, Details: reason})
+	}
+}
+
+// CheckEnv runs structural checks on an environment and returns the problems found.
+//
+// It runs the checks of CheckEnvReport and returns the failed checks of error severity; warnings are only
+// available from the report.
+//
+// Parameters:
+//   - env: The environment to check
+//
+// Returns:
+//   - The problems found, or nil if the environment passed every check of error severity
+func CheckEnv[Obs any, Act any](env Env[Obs, Act]) []error {
+	var problems []error
+	for _, res := range CheckEnvReport(env).Errors() {
+		problems = append(problems, errors.New(res.Details))
+	}
+	return problems
+}
+
+// CheckEnvReport runs structural checks on an environment and reports the outcome of each.
+//
+// The checks, in order, verify that:
+//   - "observation_space", "action_space": ObservationSpace and ActionSpace are non-nil
+//   - "render_modes": Metadata declares its render modes under "render_modes", a warning if there are none
+//   - "reset": Reset with a seed succeeds
+//   - "reset_observation": Reset returns an observation contained in the observation space
+//   - "reset_determinism": Two resets with the same seed return identical observations
+//   - "step": Step after Reset with a sampled action succeeds
+//   - "step_observation": Step returns an observation contained in the observation space
+//   - "step_reward": Step returns a finite reward
+//
+// Checks that depend on a failed step are reported as skipped. The environment is reset and stepped, so it
+// should be a fresh instance.
+//
+// Parameters:
+//   - env: The environment to check
+//
+// Returns:
+//   - The report, whose OK method tells whether no check of error severity failed
+func CheckEnvReport[Obs any, Act any](env Env[Obs, Act]) *CheckReport {
+	report := &CheckReport{}
+
+	obsSpace, actSpace := env.ObservationSpace(), env.ActionSpace()
+	report.add("observation_space", SeverityError, detailsIf(obsSpace == nil, "observation space is nil"))
+	report.add("action_space", SeverityError, detailsIf(actSpace == nil, "action space is nil"))
+
+	if modes, ok := env.Metadata()["render_modes"].([]string); !ok {
+		report.add("render_modes", SeverityError, "metadata does not declare render_modes as []string")
+	} else {
+		// An empty list is only a warning, as environments that render with Ebiten declare no render modes
+		// in headless builds
+		report.add("render_modes", SeverityWarning, detailsIf(len(modes) == 0, "metadata declares no render modes"))
+	}
+
+	ctx := context.Background()
+	seed := int64(checkSeed)
+	obs, _, err := env.Reset(ctx, &seed, nil)
+	if err != nil {
+		report.add("reset", SeverityError, fmt.Sprintf("reset failed: %v", err))
+		report.skip("reset failed", "reset_observation", "reset_determinism", "step", "step_observation", "step_reward")
+		return report
+	}
+	report.add("reset", SeverityError, "")
+	checkContains(report, "reset_observation", "reset", obsSpace, obs)
+
+	again, _, err := env.Reset(ctx, &seed, nil)
+	switch {
+	case err != nil:
+		report.add("reset_determinism", SeverityError, fmt.Sprintf("second reset failed: %v", err))
+		report.skip("second reset failed", "step", "step_observation", "step_reward")
+		return report
+	case !reflect.DeepEqual(obs, again):
+		report.add("reset_determinism", SeverityError, fmt.Sprintf("resets with seed %d are not deterministic: %v and %v", checkSeed, obs, again))
+	default:
+		report.add("reset_determinism", SeverityError, "")
+	}
+
+	if actSpace == nil {
+		report.skip("action space is nil", "step", "step_observation", "step_reward")
+		return report
+	}
+	action, err := actSpace.Sample(nil, nil)
+	if err != nil {
+		report.add("step", SeverityError, fmt.Sprintf("failed to sample action: %v", err))
+		report.skip("failed to sample action", "step_observation", "step_reward")
+		return report
+	}
+
+	obs, reward, _, _, _, err := env.Step(ctx, action)
+	if err != nil {
+		report.add("step", SeverityError, fmt.Sprintf("step failed: %v", err))
+		report.skip("step failed", "step_observation", "step_reward")
+		return report
+	}
+	report.add("step", SeverityError, "")
+	checkContains(report, "step_observation", "step", obsSpace, obs)
+	report.add("step_reward", SeverityError, detailsIf(math.IsNaN(reward) || math.IsInf(reward, 0),
+		fmt.Sprintf("step reward %f is not finite", reward)))
+
+	return report
+}
+
+// checkContains records whether obs of the given phase is contained in space, skipping the check if space is nil.
+func checkContains[Obs any](r *CheckReport, name, phase string, space Space[Obs], obs Obs) {
+	if space == nil {
+		r.skip("observation space is nil", name)
+		return
+	}
+	r.add(name, SeverityError, detailsIf(!space.Contains(obs),
+		fmt.Sprintf("%s observation %v is not contained in the observation space", phase, obs)))
+}
+
+// detailsIf returns details if failed is true and "" otherwise.
+func detailsIf(failed bool, details string) string {
+	if failed {
+		return details
+	}
+	return ""
+}
@@ -0,0 +1,236 @@
+package gym_test
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/envs/classic"
+	"github.com/gocnn/gym/rand"
+	"github.com/gocnn/gym/space"
+)
+
+// stubEnv is a configurable environment for testing, with a Discrete(2) action space and a Box observation
+// space of a single value in [0, 1]. Its episodes end after episodeLength steps if it is positive.
+type stubEnv struct {
+	obs           float64 // Observation returned by Reset and Step
+	reward        float64 // Reward returned by Step
+	randomReset   bool    // Whether Reset ignores its seed
+	episodeLength int     // Number of steps before the episode terminates, or 0 for unlimited
+	metadata      gym.Metadata
+
+	steps int
+	rng   *rand.RNG
+
+	actionSpace      *space.Discrete
+	observationSpace *space.Box
+}
+
+// newStubEnv creates a stubEnv that passes every check of CheckEnv.
+func newStubEnv(t *testing.T) *stubEnv {
+	t.Helper()
+
+	// The environment and each of its spaces have their own RNG
+	rngs := make([]*rand.RNG, 3)
+	for i := range rngs {
+		var err error
+		if rngs[i], _, err = rand.NewRNG(int64(i + 1)); err != nil {
+			t.Fatalf("NewRNG failed: %v", err)
+		}
+	}
+	rng := rngs[0]
+	actionSpace, err := space.NewDiscreteWithRNG(rngs[1], 2)
+	if err != nil {
+		t.Fatalf("NewDiscreteWithRNG failed: %v", err)
+	}
+	observationSpace, err := space.NewBoxWithRNG(rngs[2], []float64{0}, []float64{1})
+	if err != nil {
+		t.Fatalf("NewBoxWithRNG failed: %v", err)
+	}
+	return &stubEnv{
+		obs:              0.5,
+		reward:           1,
+		metadata:         gym.Metadata{"render_modes": []string{"ansi"}},
+		rng:              rng,
+		actionSpace:      actionSpace,
+		observationSpace: observationSpace,
+	}
+}
+
+func (e *stubEnv) Step(context.Context, int) ([]float64, float64, bool, bool, gym.Info, error) {
+	e.steps++
+	return []float64{e.obs}, e.reward, e.episodeLength > 0 && e.steps >= e.episodeLength, false, gym.Info{}, nil
+}
+
+func (e *stubEnv) Reset(_ context.Context, seed *int64, _ gym.Info) ([]float64, gym.Info, error) {
+	e.steps = 0
+	if seed != nil && !e.randomReset {
+		if err := e.rng.SeedExact(*seed); err != nil {
+			return nil, nil, err
+		}
+	}
+	if e.randomReset {
+		return []float64{e.rng.Float64()}, gym.Info{}, nil
+	}
+	return []float64{e.obs}, gym.Info{}, nil
+}
+
+func (e *stubEnv) Render() (gym.RenderFrame, error)       { return nil, nil }
+func (e *stubEnv) Close() error                           { return nil }
+func (e *stubEnv) ActionSpace() gym.Space[int]            { return e.actionSpace }
+func (e *stubEnv) ObservationSpace() gym.Space[[]float64] { return e.observationSpace }
+func (e *stubEnv) Metadata() gym.Metadata                 { return e.metadata }
+func (e *stubEnv) Unwrapped() gym.Env[[]float64, int]     { return e }
+func (e *stubEnv) GetRNG() *rand.RNG                      { return e.rng }
+
+// statuses returns the status of each check of a report by name.
+func statuses(report *gym.CheckReport) map[string]gym.CheckStatus {
+	byName := make(map[string]gym.CheckStatus, len(report.Results))
+	for _, res := range report.Results {
+		byName[res.Name] = res.Status
+	}
+	return byName
+}
+
+func TestCheckEnvReport(t *testing.T) {
+	report := gym.CheckEnvReport[[]float64, int](newBrokenStub(t))
+	if report.OK() {
+		t.Fatalf("OK() = true for a broken environment, report: %+v", report.Results)
+	}
+
+	want := map[string]gym.CheckStatus{
+		"observation_space": gym.CheckPassed,
+		"action_space":      gym.CheckPassed,
+		"render_modes":      gym.CheckPassed,
+		"reset":             gym.CheckPassed,
+		"reset_observation": gym.CheckPassed,
+		"reset_determinism": gym.CheckFailed,
+		"step":              gym.CheckPassed,
+		"step_observation":  gym.CheckFailed,
+		"step_reward":       gym.CheckFailed,
+	}
+	if got := statuses(report); !maps.Equal(got, want) {
+		t.Errorf("statuses = %v, want %v", got, want)
+	}
+
+	var names []string
+	for _, res := range report.Errors() {
+		names = append(names, res.Name)
+		if res.Details == "" {
+			t.Errorf("failed check %q has no details", res.Name)
+		}
+	}
+	if wantNames := []string{"reset_determinism", "step_observation", "step_reward"}; !slices.Equal(names, wantNames) {
+		t.Errorf("Errors() = %v, want %v", names, wantNames)
+	}
+	if problems := gym.CheckEnv[[]float64, int](newBrokenStub(t)); len(problems) != len(report.Errors()) {
+		t.Errorf("CheckEnv returned %d problems, want one per failed check of error severity (%d)", len(problems), len(report.Errors()))
+	}
+}
+
+func TestCheckEnvReportSkipsAfterFailedReset(t *testing.T) {
+	env := newStubEnv(t)
+	env.metadata = gym.Metadata{}
+
+	report := gym.CheckEnvReport[[]float64, int](&failingResetEnv{env})
+	got := statuses(report)
+	for _, name := range []string{"reset_observation", "reset_determinism", "step", "step_observation", "step_reward"} {
+		if got[name] != gym.CheckSkipped {
+			t.Errorf("check %q has status %v, want %v", name, got[name], gym.CheckSkipped)
+		}
+	}
+	if got["reset"] != gym.CheckFailed || got["render_modes"] != gym.CheckFailed {
+		t.Errorf("reset and render_modes have statuses %v and %v, want both %v", got["reset"], got["render_modes"], gym.CheckFailed)
+	}
+}
+
+func TestCheckEnvReportWarnings(t *testing.T) {
+	env := newStubEnv(t)
+	env.metadata = gym.Metadata{"render_modes": []string{}}
+
+	report := gym.CheckEnvReport[[]float64, int](env)
+	if !report.OK() {
+		t.Errorf("OK() = false for an environment with only warnings, errors: %+v", report.Errors())
+	}
+	if warnings := report.Warnings(); len(warnings) != 1 || warnings[0].Name != "render_modes" {
+		t.Errorf("Warnings() = %+v, want the render_modes check", warnings)
+	}
+	if problems := gym.CheckEnv[[]float64, int](env); problems != nil {
+		t.Errorf("CheckEnv returned %v, want no problems for warnings", problems)
+	}
+}
+
+func TestCheckReportJSON(t *testing.T) {
+	env := newStubEnv(t)
+	env.reward = math.Inf(1)
+
+	data, err := json.Marshal(gym.CheckEnvReport[[]float64, int](env))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	for _, want := range []string{`"name":"step_reward"`, `"status":"failed"`, `"severity":"error"`, `"status":"passed"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("JSON %s does not contain %s", data, want)
+		}
+	}
+}
+
+// failingResetEnv is a stubEnv whose Reset always fails.
+type failingResetEnv struct {
+	*stubEnv
+}
+
+func (e *failingResetEnv) Reset(context.Context, *int64, gym.Info) ([]float64, gym.Info, error) {
+	return nil, nil, context.DeadlineExceeded
+}
+
+// newBrokenStub creates a stubEnv whose resets ignore the seed and whose steps return an observation outside
+// the observation space and a NaN reward.
+func newBrokenStub(t *testing.T) *stubEnv {
+	t.Helper()
+
+	env := newStubEnv(t)
+	env.randomReset = true
+	env.reward = math.NaN()
+	env.obs = 2
+	return env
+}
+
+func TestCheckEnvCartPole(t *testing.T) {
+	env, err := classic.NewCartPoleEnv(nil)
+	if err != nil {
+		t.Fatalf("NewCartPoleEnv failed: %v", err)
+	}
+	defer env.Close()
+
+	// Headless builds declare no render modes, which is only a warning
+	if problems := gym.CheckEnv[[]float64, int](env); problems != nil {
+		t.Errorf("CheckEnv(CartPole) = %v, want no problems", problems)
+	}
+}
+
+func TestCheckEnvBrokenStub(t *testing.T) {
+	env := newBrokenStub(t)
+	env.metadata = gym.Metadata{"render_modes": "ansi"}
+
+	problems := gym.CheckEnv[[]float64, int](env)
+	want := []string{
+		"metadata does not declare render_modes as []string",
+		"resets with seed 42 are not deterministic",
+		"step observation [2] is not contained in the observation space",
+		"step reward NaN is not finite",
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("CheckEnv returned %d problems %v, want %d", len(problems), problems, len(want))
+	}
+	for i, problem := range problems {
+		if !strings.Contains(problem.Error(), want[i]) {
+			t.Errorf("problem %d = %q, want it to contain %q", i, problem, want[i])
+		}
+	}
+}
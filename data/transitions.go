@@ -0,0 +1,149 @@
+// Package data provides offline reinforcement learning datasets built from recorded trajectories.
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gocnn/gym"
+)
+
+// Transition is a single step of an episode.
+type Transition[Obs any, Act any] struct {
+	Obs        Obs     // Observation before the step
+	Action     Act     // Action taken
+	Reward     float64 // Reward received
+	NextObs    Obs     // Observation after the step
+	Terminated bool    // Whether the episode terminated with the step
+	Truncated  bool    // Whether the episode was truncated with the step
+}
+
+// Episode is a recorded episode together with the arguments of the Reset that started it.
+type Episode[Obs any, Act any] struct {
+	Seed        *int64                 // Seed passed to Reset, nil if none
+	Options     gym.Info               // Options passed to Reset, nil if none
+	Transitions []Transition[Obs, Act] // Transitions of the episode, in order
+}
+
+// Record is the JSON Lines record of a transition, as written by the RecordTrajectory wrapper.
+//
+// Observations and actions are stored in the JSONable form of their spaces. The seed and options of the
+// Reset that started the episode are stored in the first record of the episode only.
+type Record struct {
+	Episode    int      `json:"episode"`
+	Seed       *int64   `json:"seed,omitempty"`
+	Options    gym.Info `json:"options,omitempty"`
+	Obs        any      `json:"obs"`
+	Action     any      `json:"action"`
+	Reward     float64  `json:"reward"`
+	NextObs    any      `json:"next_obs"`
+	Terminated bool     `json:"terminated"`
+	Truncated  bool     `json:"truncated"`
+}
+
+// LoadTransitions reads the transitions of a JSON Lines file written by the RecordTrajectory wrapper.
+//
+// Observations and actions are reconstructed with the FromJSONable methods of the given spaces, which
+// must match the spaces of the recorded environment.
+//
+// Parameters:
+//   - path: The path of the JSON Lines file
+//   - obsSpace: The observation space of the recorded environment
+//   - actSpace: The action space of the recorded environment
+//
+// Returns:
+//   - The transitions of every episode, in file order
+//   - An error if the file cannot be read or a record does not match the spaces
+func LoadTransitions[Obs any, Act any](path string, obsSpace gym.Space[Obs], actSpace gym.Space[Act]) ([]Transition[Obs, Act], error) {
+	episodes, err := LoadEpisodes(path, obsSpace, actSpace)
+	if err != nil {
+		return nil, err
+	}
+
+	var transitions []Transition[Obs, Act]
+	for _, episode := range episodes {
+		transitions = append(transitions, episode.Transitions...)
+	}
+	return transitions, nil
+}
+
+// LoadEpisodes reads the episodes of a JSON Lines file written by the RecordTrajectory wrapper, with the seed
+// and options of the Reset that started each of them.
+//
+// Observations and actions are reconstructed as by LoadTransitions. Options are decoded by encoding/json, so
+// numbers are float64, except that arrays of numbers are converted to []float64, the type of array options
+// such as the reset bounds of CartPole.
+//
+// Parameters:
+//   - path: The path of the JSON Lines file
+//   - obsSpace: The observation space of the recorded environment
+//   - actSpace: The action space of the recorded environment
+//
+// Returns:
+//   - The episodes, in file order
+//   - An error if the file cannot be read or a record does not match the spaces
+func LoadEpisodes[Obs any, Act any](path string, obsSpace gym.Space[Obs], actSpace gym.Space[Act]) ([]Episode[Obs, Act], error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open transitions: %w", err)
+	}
+	defer f.Close()
+
+	var episodes []Episode[Obs, Act]
+	current := 0 // Episode number of the last record
+	dec := json.NewDecoder(f)
+	for i := 0; ; i++ {
+		var record Record
+		if err := dec.Decode(&record); errors.Is(err, io.EOF) {
+			return episodes, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to decode record %d: %w", i, err)
+		}
+
+		obs, err := obsSpace.FromJSONable([]any{record.Obs, record.NextObs})
+		if err != nil {
+			return nil, fmt.Errorf("invalid observation in record %d: %w", i, err)
+		}
+		action, err := actSpace.FromJSONable([]any{record.Action})
+		if err != nil {
+			return nil, fmt.Errorf("invalid action in record %d: %w", i, err)
+		}
+
+		if i == 0 || record.Episode != current {
+			episodes = append(episodes, Episode[Obs, Act]{Seed: record.Seed, Options: decodeOptions(record.Options)})
+			current = record.Episode
+		}
+		episode := &episodes[len(episodes)-1]
+		episode.Transitions = append(episode.Transitions, Transition[Obs, Act]{
+			Obs:        obs[0],
+			Action:     action[0],
+			Reward:     record.Reward,
+			NextObs:    obs[1],
+			Terminated: record.Terminated,
+			Truncated:  record.Truncated,
+		})
+	}
+}
+
+// decodeOptions converts the arrays of numbers of options decoded by encoding/json to []float64.
+func decodeOptions(options gym.Info) gym.Info {
+	for key, val := range options {
+		arr, ok := val.([]any)
+		if !ok {
+			continue
+		}
+		nums := make([]float64, len(arr))
+		for i, v := range arr {
+			if nums[i], ok = v.(float64); !ok {
+				break
+			}
+		}
+		if ok {
+			options[key] = nums
+		}
+	}
+	return options
+}
@@ -0,0 +1,88 @@
+package data_test
+
+import (
+	"context"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/gocnn/gym/data"
+	"github.com/gocnn/gym/envs/classic"
+	"github.com/gocnn/gym/envs/wrappers"
+)
+
+// closeTo reports whether a and b have the same length and differ by at most 1e-9 in every element.
+func closeTo(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > 1e-9 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestLoadTransitionsCartPole(t *testing.T) {
+	cartPole, err := classic.NewCartPoleEnv(nil)
+	if err != nil {
+		t.Fatalf("NewCartPoleEnv failed: %v", err)
+	}
+	defer cartPole.Close()
+
+	path := filepath.Join(t.TempDir(), "cartpole.jsonl")
+	env, err := wrappers.NewRecordTrajectory[[]float64, int](cartPole, path)
+	if err != nil {
+		t.Fatalf("NewRecordTrajectory failed: %v", err)
+	}
+
+	// Run two episodes, keeping the observations and actions to compare with the loaded transitions
+	ctx := context.Background()
+	var observations [][]float64
+	var actions []int
+	for seed := int64(1); seed <= 2; seed++ {
+		obs, _, err := env.Reset(ctx, &seed, nil)
+		if err != nil {
+			t.Fatalf("Reset failed: %v", err)
+		}
+		for terminated := false; !terminated; {
+			action, err := env.ActionSpace().Sample(nil, nil)
+			if err != nil {
+				t.Fatalf("Sample failed: %v", err)
+			}
+			observations = append(observations, obs)
+			actions = append(actions, action)
+			if obs, _, terminated, _, _, err = env.Step(ctx, action); err != nil {
+				t.Fatalf("Step failed: %v", err)
+			}
+		}
+		observations = append(observations, nil) // Marks the end of the episode
+	}
+
+	transitions, err := data.LoadTransitions(path, env.ObservationSpace(), env.ActionSpace())
+	if err != nil {
+		t.Fatalf("LoadTransitions failed: %v", err)
+	}
+	if len(transitions) != len(actions) {
+		t.Fatalf("loaded %d transitions, want %d", len(transitions), len(actions))
+	}
+
+	i := 0
+	for j, obs := range observations {
+		if obs == nil {
+			if !transitions[i-1].Terminated {
+				t.Errorf("last transition %d of an episode is not terminated", i-1)
+			}
+			continue
+		}
+		tr := transitions[i]
+		if !closeTo(tr.Obs, obs) || tr.Action != actions[i] || tr.Reward != 1 {
+			t.Errorf("transition %d = %+v, want observation %v, action %d and reward 1", i, tr, obs, actions[i])
+		}
+		if next := observations[j+1]; next != nil && !closeTo(tr.NextObs, next) {
+			t.Errorf("transition %d has next observation %v, want %v", i, tr.NextObs, next)
+		}
+		i++
+	}
+}
@@ -94,14 +94,15 @@ type Env[Obs any, Act any] interface {
 	// Parameters:
 	//   - ctx: Context for cancellation and timeouts
 	//   - seed: The seed that is used to initialize the environment's RNG. If nil, existing RNG state is preserved.
-	//     If provided, the RNG will be reset even if it already exists.
+	//     If provided, the RNG will be reset even if it already exists, and every value including 0 gives
+	//     a reproducible reset.
 	//   - options: Additional information to specify how the environment is reset (optional, depending on the specific environment)
 	//
 	// Returns:
 	//   - observation: Observation of the initial state. This will be an element of ObservationSpace
 	//   - info: Dictionary containing auxiliary information complementing the observation
 	//   - error: Any error that occurred during reset
-	Reset(ctx context.Context, seed int64, options Info) (Obs, Info, error)
+	Reset(ctx context.Context, seed *int64, options Info) (Obs, Info, error)
 
 	// Render computes the render frames as specified by the environment's render mode.
 	//
@@ -110,6 +111,7 @@ type Env[Obs any, Act any] interface {
 	//
 	//   - "human": The environment is continuously rendered for human consumption
 	//   - "rgb_array": Return a frame representing the current state as RGB array data
+	//   - "rgb_array_list": Return a []image.Image of the frames of every step since the last Reset
 	//   - "ansi": Return a string containing a terminal-style text representation
 	//
 	// Returns:
@@ -167,3 +169,16 @@ type Env[Obs any, Act any] interface {
 	//   - The environment's random number generator
 	GetRNG() *rand.RNG
 }
+
+// Cloneable is implemented by environments that can copy their full state.
+//
+// A clone continues from the same state, including its RNG, so stepping the original and the clone with
+// the same actions yields identical trajectories while neither affects the other. This allows deterministic
+// branching, e.g. for model-based planning with tree search.
+type Cloneable[Obs any, Act any] interface {
+	// Clone returns an independent copy of the environment.
+	//
+	// Returns:
+	//   - A new environment in the same state
+	Clone() Env[Obs, Act]
+}
@@ -1,20 +1,18 @@
 package classic
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
 	"fmt"
 	"image"
-	"image/color"
+	"maps"
 	"math"
-	"sync"
-	"time"
+	"slices"
 
 	"github.com/gocnn/gym"
 	"github.com/gocnn/gym/rand"
 	"github.com/gocnn/gym/space"
-	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
-	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
 // CartPoleEnv implements the classic cart-pole system described by Rich Sutton et al.
@@ -61,6 +59,17 @@ type CartPoleEnv struct {
 	forceMag             float64
 	tau                  float64 // seconds between state updates
 	kinematicsIntegrator string
+	frameSkip            int     // physics updates per Step
+	randomStartSteps     int     // maximum number of random actions taken on Reset
+	frictionCart         float64 // coefficient of friction of cart on track
+	frictionPole         float64 // coefficient of friction of pole on cart
+
+	// Domain randomization, [low, high] ranges sampled on every Reset
+	randomizePhysics bool
+	gravityRange     [2]float64
+	massCartRange    [2]float64
+	massPoleRange    [2]float64
+	lengthRange      [2]float64
 
 	// Thresholds
 	thetaThresholdRadians float64
@@ -75,27 +84,64 @@ type CartPoleEnv struct {
 	renderMode        string
 
 	// Spaces
-	actionSpace      gym.Space[int]
-	observationSpace gym.Space[[]float64]
+	actionSpace      *space.Discrete
+	observationSpace *space.Box
 
 	// Episode tracking
 	stepsBeyondTerminated *int
+	episodeReturn         float64 // sum of the rewards since the last Reset
+	episodeLength         int     // number of steps since the last Reset
 
 	// Metadata
 	metadata gym.Metadata
 
 	// Rendering
-	screen *ebiten.Image
-
-	// Auto-rendering support
-	autoRenderGame *AutoRenderGame
-	renderMutex    sync.Mutex
+	screenWidth  int
+	screenHeight int
+	renderer     cartPoleRenderer
+	frames       []image.Image // frames of the episode for "rgb_array_list" mode
 }
 
 // CartPoleConfig holds configuration options for CartPole environment
 type CartPoleConfig struct {
 	SuttonBartoReward bool
 	RenderMode        string
+
+	// Friction coefficients from Barto, Sutton, and Anderson (muc and mup), defaulting to a frictionless system
+	CartFriction float64 // Coefficient of friction of cart on track
+	PoleFriction float64 // Coefficient of friction of pole on cart
+
+	// Physics parameters, each defaulting to the value of the reference implementation when zero
+	Gravity    float64 // Gravitational acceleration (defaults to 9.8)
+	MassCart   float64 // Mass of the cart (defaults to 1.0)
+	MassPole   float64 // Mass of the pole (defaults to 0.1)
+	PoleLength float64 // Half the pole's length, as in the reference implementation (defaults to 0.5)
+	ForceMag   float64 // Magnitude of the force applied by an action (defaults to 10.0)
+	Tau        float64 // Seconds between state updates (defaults to 0.02)
+
+	// Domain randomization: when RandomizePhysics is true, every Reset samples each parameter with a
+	// non-zero [low, high] range uniformly from it, keeping the configured value for zero ranges
+	RandomizePhysics bool
+	GravityRange     [2]float64
+	MassCartRange    [2]float64
+	MassPoleRange    [2]float64
+	PoleLengthRange  [2]float64
+
+	// Integration scheme of the dynamics, "euler" (default) or "semi-implicit-euler"
+	KinematicsIntegrator string
+
+	// Number of physics updates of tau seconds applied with the same action per Step (defaults to 1),
+	// with the rewards summed and the updates stopping early on termination
+	FrameSkip int
+
+	// Maximum number of random actions taken by Reset after initializing the state, following the Atari
+	// random-start convention; Reset takes between 1 and RandomStartSteps actions and starts over if they
+	// terminate the episode (defaults to 0, no random actions)
+	RandomStartSteps int
+
+	// Size of the rendered frames in pixels, defaulting to 600x400
+	ScreenWidth  int
+	ScreenHeight int
 }
 
 // NewCartPoleEnv creates a new CartPole environment instance.
@@ -111,15 +157,81 @@ func NewCartPoleEnv(config *CartPoleConfig) (*CartPoleEnv, error) {
 		config = &CartPoleConfig{}
 	}
 
+	if config.RenderMode != "" && !slices.Contains(cartPoleRenderModes, config.RenderMode) {
+		return nil, fmt.Errorf("unsupported render mode %q, expected one of %v", config.RenderMode, cartPoleRenderModes)
+	}
+
+	if config.CartFriction < 0 || config.PoleFriction < 0 {
+		return nil, fmt.Errorf("friction coefficients must be non-negative, got %f and %f", config.CartFriction, config.PoleFriction)
+	}
+
+	physics := []struct {
+		name string
+		val  float64
+	}{
+		{"gravity", config.Gravity},
+		{"cart mass", config.MassCart},
+		{"pole mass", config.MassPole},
+		{"pole length", config.PoleLength},
+		{"force magnitude", config.ForceMag},
+		{"tau", config.Tau},
+	}
+	for _, p := range physics {
+		if p.val < 0 {
+			return nil, fmt.Errorf("%s must be non-negative, got %f", p.name, p.val)
+		}
+	}
+
+	ranges := []struct {
+		name string
+		val  [2]float64
+	}{
+		{"gravity", config.GravityRange},
+		{"cart mass", config.MassCartRange},
+		{"pole mass", config.MassPoleRange},
+		{"pole length", config.PoleLengthRange},
+	}
+	for _, r := range ranges {
+		if r.val[0] < 0 || r.val[0] > r.val[1] {
+			return nil, fmt.Errorf("%s range must satisfy 0 <= low <= high, got %v", r.name, r.val)
+		}
+	}
+
+	kinematicsIntegrator := cmp.Or(config.KinematicsIntegrator, "euler")
+	if kinematicsIntegrator != "euler" && kinematicsIntegrator != "semi-implicit-euler" {
+		return nil, fmt.Errorf("unsupported kinematics integrator %q, expected \"euler\" or \"semi-implicit-euler\"", config.KinematicsIntegrator)
+	}
+
+	if config.FrameSkip < 0 {
+		return nil, fmt.Errorf("frame skip must be non-negative, got %d", config.FrameSkip)
+	}
+
+	if config.RandomStartSteps < 0 {
+		return nil, fmt.Errorf("random start steps must be non-negative, got %d", config.RandomStartSteps)
+	}
+
+	if config.ScreenWidth < 0 || config.ScreenHeight < 0 {
+		return nil, fmt.Errorf("screen size must be non-negative, got %dx%d", config.ScreenWidth, config.ScreenHeight)
+	}
+
 	env := &CartPoleEnv{
-		// Physics parameters matching Python implementation
-		gravity:              9.8,
-		masscart:             1.0,
-		masspole:             0.1,
-		length:               0.5, // actually half the pole's length
-		forceMag:             10.0,
-		tau:                  0.02, // seconds between state updates
-		kinematicsIntegrator: "euler",
+		// Physics parameters matching Python implementation unless configured
+		gravity:              cmp.Or(config.Gravity, 9.8),
+		masscart:             cmp.Or(config.MassCart, 1.0),
+		masspole:             cmp.Or(config.MassPole, 0.1),
+		length:               cmp.Or(config.PoleLength, 0.5), // actually half the pole's length
+		forceMag:             cmp.Or(config.ForceMag, 10.0),
+		tau:                  cmp.Or(config.Tau, 0.02), // seconds between state updates
+		kinematicsIntegrator: kinematicsIntegrator,
+		frameSkip:            cmp.Or(config.FrameSkip, 1),
+		randomStartSteps:     config.RandomStartSteps,
+		frictionCart:         config.CartFriction,
+		frictionPole:         config.PoleFriction,
+		randomizePhysics:     config.RandomizePhysics,
+		gravityRange:         config.GravityRange,
+		massCartRange:        config.MassCartRange,
+		massPoleRange:        config.MassPoleRange,
+		lengthRange:          config.PoleLengthRange,
 
 		// Thresholds
 		thetaThresholdRadians: 12 * 2 * math.Pi / 360, // ±12°
@@ -128,19 +240,19 @@ func NewCartPoleEnv(config *CartPoleConfig) (*CartPoleEnv, error) {
 		// Configuration
 		suttonBartoReward: config.SuttonBartoReward,
 		renderMode:        config.RenderMode,
+		screenWidth:       cmp.Or(config.ScreenWidth, 600),
+		screenHeight:      cmp.Or(config.ScreenHeight, 400),
 
 		// Metadata
 		metadata: gym.Metadata{
-			"render_modes":      []string{"human", "rgb_array"},
+			"render_modes":      slices.Clone(cartPoleRenderModes),
 			"render_fps":        50,
 			"reward_threshold":  475.0,
 			"max_episode_steps": 500,
 		},
 	}
 
-	// Calculate derived parameters
-	env.totalMass = env.masspole + env.masscart
-	env.polemasslength = env.masspole * env.length
+	env.updateDerivedParameters()
 
 	// Initialize RNG
 	rng, _, err := rand.NewRNG(0)
@@ -149,8 +261,8 @@ func NewCartPoleEnv(config *CartPoleConfig) (*CartPoleEnv, error) {
 	}
 	env.rng = rng
 
-	// Create action space: Discrete(2) for left/right actions
-	actionSpace, err := space.NewDiscrete(2)
+	// Create action space: Discrete(2) for left/right actions, with its own RNG seeded on Reset
+	actionSpace, err := space.NewDiscreteWithRNG(env.rng.Derive(), 2)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create action space: %w", err)
 	}
@@ -169,7 +281,7 @@ func NewCartPoleEnv(config *CartPoleConfig) (*CartPoleEnv, error) {
 		-env.thetaThresholdRadians * 2,
 		math.Inf(-1),
 	}
-	observationSpace, err := space.NewBox(low, high)
+	observationSpace, err := space.NewBoxWithRNG(env.rng.Derive(), low, high)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create observation space: %w", err)
 	}
@@ -180,15 +292,18 @@ func NewCartPoleEnv(config *CartPoleConfig) (*CartPoleEnv, error) {
 
 // Close performs cleanup when the user has finished using the environment.
 func (env *CartPoleEnv) Close() error {
-	if env.screen != nil {
-		env.screen.Dispose()
-		env.screen = nil
-	}
+	env.renderer.close()
 	return nil
 }
 
 // Step runs one timestep of the environment's dynamics using the agent action.
 func (env *CartPoleEnv) Step(ctx context.Context, action int) ([]float64, float64, bool, bool, gym.Info, error) {
+	select {
+	case <-ctx.Done():
+		return nil, 0, false, false, nil, ctx.Err()
+	default:
+	}
+
 	if !env.actionSpace.Contains(action) {
 		return nil, 0, false, false, nil, fmt.Errorf("invalid action %d", action)
 	}
@@ -197,33 +312,79 @@ func (env *CartPoleEnv) Step(ctx context.Context, action int) ([]float64, float6
 		return nil, 0, false, false, nil, fmt.Errorf("call Reset before using Step method")
 	}
 
-	x, xDot, theta, thetaDot := env.state[0], env.state[1], env.state[2], env.state[3]
-
 	// Convert action to force
 	force := env.forceMag
 	if action == 0 {
 		force = -env.forceMag
 	}
 
+	observation, reward, terminated := env.step(force)
+	if err := env.recordFrame(); err != nil {
+		return nil, 0, false, false, nil, err
+	}
+	env.episodeReturn += reward
+	env.episodeLength++
+
+	// truncation=false as the time limit is handled by the TimeLimit wrapper
+	return observation, reward, terminated, false, gym.Info{}, nil
+}
+
+// EpisodeReturn returns the sum of the rewards of the steps since the last Reset.
+func (env *CartPoleEnv) EpisodeReturn() float64 {
+	return env.episodeReturn
+}
+
+// EpisodeLength returns the number of steps since the last Reset.
+//
+// Random start steps taken by Reset are not counted.
+func (env *CartPoleEnv) EpisodeLength() int {
+	return env.episodeLength
+}
+
+// step advances the dynamics by frameSkip timesteps with the given force applied to the cart.
+func (env *CartPoleEnv) step(force float64) ([]float64, float64, bool) {
+	var (
+		observation []float64
+		reward      float64
+		terminated  bool
+	)
+	for range env.frameSkip {
+		var r float64
+		observation, r, terminated = env.substep(force)
+		reward += r
+		if terminated {
+			break
+		}
+	}
+	return observation, reward, terminated
+}
+
+// substep advances the dynamics by one timestep with the given force applied to the cart.
+func (env *CartPoleEnv) substep(force float64) ([]float64, float64, bool) {
+	x, xDot, theta, thetaDot := env.state[0], env.state[1], env.state[2], env.state[3]
+
 	costheta := math.Cos(theta)
 	sintheta := math.Sin(theta)
 
-	// Physics simulation (from the referenced paper)
-	temp := (force + env.polemasslength*thetaDot*thetaDot*sintheta) / env.totalMass
-	thetaacc := (env.gravity*sintheta - costheta*temp) / (env.length * (4.0/3.0 - env.masspole*costheta*costheta/env.totalMass))
+	// Physics simulation (from the referenced paper), friction terms vanish for a frictionless system.
+	// Products added to another term are wrapped in float64() so the compiler cannot fuse them into FMA
+	// instructions on some architectures, which keeps seeded trajectories identical on every platform.
+	temp := (force + float64(env.polemasslength*thetaDot*thetaDot*sintheta) - float64(env.frictionCart*sign(xDot))) / env.totalMass
+	thetaacc := (float64(env.gravity*sintheta) - float64(costheta*temp) - env.frictionPole*thetaDot/env.polemasslength) /
+		(env.length * (4.0/3.0 - env.masspole*costheta*costheta/env.totalMass))
 	xacc := temp - env.polemasslength*thetaacc*costheta/env.totalMass
 
 	// Update state using Euler integration
 	if env.kinematicsIntegrator == "euler" {
-		x = x + env.tau*xDot
-		xDot = xDot + env.tau*xacc
-		theta = theta + env.tau*thetaDot
-		thetaDot = thetaDot + env.tau*thetaacc
+		x = x + float64(env.tau*xDot)
+		xDot = xDot + float64(env.tau*xacc)
+		theta = theta + float64(env.tau*thetaDot)
+		thetaDot = thetaDot + float64(env.tau*thetaacc)
 	} else { // semi-implicit euler
-		xDot = xDot + env.tau*xacc
-		x = x + env.tau*xDot
-		thetaDot = thetaDot + env.tau*thetaacc
-		theta = theta + env.tau*thetaDot
+		xDot = xDot + float64(env.tau*xacc)
+		x = x + float64(env.tau*xDot)
+		thetaDot = thetaDot + float64(env.tau*thetaacc)
+		theta = theta + float64(env.tau*thetaDot)
 	}
 
 	env.state = []float64{x, xDot, theta, thetaDot}
@@ -267,186 +428,343 @@ func (env *CartPoleEnv) Step(ctx context.Context, action int) ([]float64, float6
 	observation := make([]float64, len(env.state))
 	copy(observation, env.state)
 
-	// truncation=false as the time limit is handled by the TimeLimit wrapper
-	return observation, reward, terminated, false, gym.Info{}, nil
+	return observation, reward, terminated
+}
+
+// recordFrame appends a frame of the current state to the episode frames in "rgb_array_list" mode.
+func (env *CartPoleEnv) recordFrame() error {
+	if env.renderMode != "rgb_array_list" {
+		return nil
+	}
+
+	frame, err := env.render()
+	if err != nil {
+		return fmt.Errorf("failed to record frame: %w", err)
+	}
+	env.frames = append(env.frames, frame.(image.Image))
+	return nil
+}
+
+// updateDerivedParameters recomputes the parameters derived from the masses and the pole length.
+func (env *CartPoleEnv) updateDerivedParameters() {
+	env.totalMass = env.masspole + env.masscart
+	env.polemasslength = env.masspole * env.length
+}
+
+// randomizeParameters samples the physics parameters with a non-zero range from the environment's RNG.
+func (env *CartPoleEnv) randomizeParameters() {
+	for _, p := range []struct {
+		val *float64
+		rng [2]float64
+	}{
+		{&env.gravity, env.gravityRange},
+		{&env.masscart, env.massCartRange},
+		{&env.masspole, env.massPoleRange},
+		{&env.length, env.lengthRange},
+	} {
+		if p.rng != [2]float64{} {
+			*p.val = env.rng.Float64Range(p.rng[0], p.rng[1])
+		}
+	}
+	env.updateDerivedParameters()
+}
+
+// sign returns -1, 0 or 1 according to the sign of x.
+func sign(x float64) float64 {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// seedActionSpace seeds an action space with the first seed spawned from the seed given to Reset, so that
+// sampled actions are reproducible without drawing from the environment RNG.
+func seedActionSpace(actionSpace interface{ Seed(int64) (int64, error) }, seed int64) error {
+	seq, err := rand.NewSeedSequenceExact(seed)
+	if err != nil {
+		return fmt.Errorf("failed to derive action space seed: %w", err)
+	}
+	if _, err := actionSpace.Seed(seq.Spawn(1)[0]); err != nil {
+		return fmt.Errorf("failed to seed action space: %w", err)
+	}
+	return nil
 }
 
 // Reset resets the environment to an initial internal state, returning an initial observation and info.
-func (env *CartPoleEnv) Reset(ctx context.Context, seed int64, options gym.Info) ([]float64, gym.Info, error) {
-	// Seed the RNG if provided
-	if seed != 0 {
-		_, err := env.rng.Seed(seed)
-		if err != nil {
+//
+// The state components are drawn uniformly from [-0.05, 0.05], or from the bounds given by options["low"] and
+// options["high"], each either a float64 applied to every component or a []float64 with one bound per component.
+func (env *CartPoleEnv) Reset(ctx context.Context, seed *int64, options gym.Info) ([]float64, gym.Info, error) {
+	select {
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	default:
+	}
+
+	// Seed the RNG if provided, and the action space with a seed derived from it for reproducible sampling
+	if seed != nil {
+		if err := env.rng.SeedExact(*seed); err != nil {
 			return nil, nil, fmt.Errorf("failed to seed RNG: %w", err)
 		}
+		if err := seedActionSpace(env.actionSpace, *seed); err != nil {
+			return nil, nil, err
+		}
 	}
 
 	// Parse reset bounds from options
-	low, high := -0.05, 0.05 // default bounds
-	if options != nil {
-		if lowVal, ok := options["low"].(float64); ok {
-			low = lowVal
-		}
-		if highVal, ok := options["high"].(float64); ok {
-			high = highVal
+	low, err := resetBound(options, "low", -0.05)
+	if err != nil {
+		return nil, nil, err
+	}
+	high, err := resetBound(options, "high", 0.05)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range low {
+		if low[i] > high[i] {
+			return nil, nil, fmt.Errorf("reset bounds must satisfy low <= high, got [%f, %f] at index %d", low[i], high[i], i)
 		}
 	}
 
 	// Initialize state with uniform random values
-	env.state = make([]float64, 4)
-	for i := range env.state {
-		env.state[i] = low + env.rng.Float64()*(high-low)
+	env.initState(low, high)
+
+	info := gym.Info{}
+	if env.randomizePhysics {
+		env.randomizeParameters()
+		info["gravity"] = env.gravity
+		info["masscart"] = env.masscart
+		info["masspole"] = env.masspole
+		info["length"] = env.length
 	}
 
 	env.stepsBeyondTerminated = nil
+	if err := env.randomStart(low, high); err != nil {
+		return nil, nil, err
+	}
+	env.episodeReturn = 0
+	env.episodeLength = 0
+	env.frames = nil
 
 	// Create observation (copy of state)
 	observation := make([]float64, len(env.state))
 	copy(observation, env.state)
 
-	return observation, gym.Info{}, nil
+	return observation, info, nil
 }
 
-// Render computes the render frames as specified by the environment's render mode.
-func (env *CartPoleEnv) Render() (gym.RenderFrame, error) {
-	if env.renderMode == "" {
-		return nil, fmt.Errorf("no render mode specified")
-	}
-
-	if env.state == nil {
-		return nil, fmt.Errorf("environment state is nil, call Reset first")
+// resetBound returns the per-component reset bound stored under key in options, or def for every component
+// if there is none.
+func resetBound(options gym.Info, key string, def float64) ([]float64, error) {
+	switch v := options[key].(type) {
+	case nil:
+		return []float64{def, def, def, def}, nil
+	case float64:
+		return []float64{v, v, v, v}, nil
+	case []float64:
+		if len(v) != 4 {
+			return nil, fmt.Errorf("%s must have length 4, got %d", key, len(v))
+		}
+		return v, nil
+	default:
+		return nil, fmt.Errorf("%s must be float64 or []float64, got %T", key, v)
 	}
+}
 
-	env.renderMutex.Lock()
-	defer env.renderMutex.Unlock()
-
-	// Initialize screen if not already done
-	if env.screen == nil {
-		screenWidth, screenHeight := 600, 400
-		env.screen = ebiten.NewImage(screenWidth, screenHeight)
+// initState initializes each state component with a value drawn uniformly from [low[i], high[i]].
+func (env *CartPoleEnv) initState(low, high []float64) {
+	env.state = make([]float64, 4)
+	for i := range env.state {
+		env.state[i] = env.rng.Float64Range(low[i], high[i])
 	}
+}
 
-	// Clear screen with white background
-	env.screen.Fill(color.RGBA{255, 255, 255, 255})
-
-	// Get screen dimensions
-	bounds := env.screen.Bounds()
-	screenWidth := float64(bounds.Dx())
-	screenHeight := float64(bounds.Dy())
-
-	// Calculate scaling and positions
-	worldWidth := env.xThreshold * 2 // 4.8
-	scale := screenWidth / worldWidth
-	cartx := env.state[0]*scale + screenWidth/2
-	carty := screenHeight - 100.0 // Position from bottom
-
-	// CartPole parameters
-	polelen := scale * (2 * env.length) // use actual length from env
-	polewidth := 10.0
-	cartwidth, cartheight := 50.0, 30.0
-	axleoffset := cartheight / 4.0
-
-	// Draw track (horizontal line)
-	vector.StrokeLine(env.screen, 0, float32(carty), float32(screenWidth), float32(carty), 2, color.RGBA{0, 0, 0, 255}, false)
-
-	// Draw cart (rectangle)
-	cartLeft := cartx - cartwidth/2
-	cartTop := carty - cartheight/2
-
-	// Draw cart as filled rectangle
-	vector.DrawFilledRect(env.screen, float32(cartLeft), float32(cartTop), float32(cartwidth), float32(cartheight), color.RGBA{0, 0, 0, 255}, false)
-
-	// Calculate pole position
-	theta := env.state[2]
-	poleEndX := cartx + math.Sin(theta)*polelen
-	poleEndY := carty - math.Cos(theta)*polelen
-
-	// Draw pole (line with thickness)
-	vector.StrokeLine(env.screen, float32(cartx), float32(carty-axleoffset), float32(poleEndX), float32(poleEndY), float32(polewidth), color.RGBA{202, 152, 101, 255}, false)
-
-	// Draw axle (circle)
-	vector.DrawFilledCircle(env.screen, float32(cartx), float32(carty-axleoffset), float32(polewidth/2), color.RGBA{129, 132, 203, 255}, false)
-
-	// Display debug information
-	debugText := "CartPole Environment\n"
-	debugText += fmt.Sprintf("Position: %.2f\n", env.state[0])
-	debugText += fmt.Sprintf("Velocity: %.2f\n", env.state[1])
-	debugText += fmt.Sprintf("Angle: %.2f rad (%.1f°)\n", env.state[2], env.state[2]*180/math.Pi)
-	debugText += fmt.Sprintf("Angular Vel: %.2f\n", env.state[3])
-
-	ebitenutil.DebugPrint(env.screen, debugText)
+// maxRandomStartAttempts bounds the number of times Reset starts over when random actions terminate the episode.
+const maxRandomStartAttempts = 100
 
-	// Auto-start rendering window for "human" mode
-	if env.renderMode == "human" && env.autoRenderGame == nil {
-		env.startAutoRender()
+// randomStart takes between 1 and randomStartSteps random actions, re-initializing the state within
+// [low, high] and starting over whenever they terminate the episode.
+func (env *CartPoleEnv) randomStart(low, high []float64) error {
+	if env.randomStartSteps == 0 {
+		return nil
 	}
 
-	if env.renderMode == "rgb_array" {
-		// Convert screen to RGB array
-		bounds := env.screen.Bounds()
-		rgbaImg := image.NewRGBA(bounds)
-
-		// Read pixels from Ebiten image
-		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
-			for x := bounds.Min.X; x < bounds.Max.X; x++ {
-				c := env.screen.At(x, y)
-				rgbaImg.Set(x, y, c)
+	for range maxRandomStartAttempts {
+		terminated := false
+		for range 1 + env.rng.IntN(env.randomStartSteps) {
+			force := env.forceMag
+			if env.rng.IntN(2) == 0 {
+				force = -env.forceMag
+			}
+			if _, _, terminated = env.step(force); terminated {
+				break
 			}
 		}
+		if !terminated {
+			return nil
+		}
 
-		return rgbaImg, nil
+		env.initState(low, high)
+		env.stepsBeyondTerminated = nil
 	}
-
-	// For "human" mode, return the Ebiten image directly
-	return env.screen, nil
+	return fmt.Errorf("random start steps terminated the episode %d times in a row", maxRandomStartAttempts)
 }
 
-// startAutoRender starts the automatic rendering window in a separate goroutine
-func (env *CartPoleEnv) startAutoRender() {
-	env.autoRenderGame = &AutoRenderGame{
-		env:   env,
-		mutex: &env.renderMutex,
+// Render computes the render frames as specified by the environment's render mode.
+//
+// In "rgb_array_list" mode it returns the frames of every step since the last Reset as a []image.Image.
+// Rendering requires Ebiten and is unavailable when built with the "headless" build tag.
+func (env *CartPoleEnv) Render() (gym.RenderFrame, error) {
+	if env.renderMode == "" {
+		return nil, fmt.Errorf("no render mode specified")
 	}
 
-	go func() {
-		ebiten.SetWindowSize(600, 400)
-		ebiten.SetWindowTitle("CartPole Environment")
-		ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	if env.state == nil {
+		return nil, fmt.Errorf("environment state is nil, call Reset first")
+	}
 
-		// Run the game loop
-		if err := ebiten.RunGame(env.autoRenderGame); err != nil {
-			// Window was closed, clean up
-			env.renderMutex.Lock()
-			env.autoRenderGame = nil
-			env.renderMutex.Unlock()
-		}
-	}()
+	if env.renderMode == "rgb_array_list" {
+		return slices.Clone(env.frames), nil
+	}
 
-	// Give the window a moment to initialize
-	time.Sleep(100 * time.Millisecond)
+	return env.render()
 }
 
-// AutoRenderGame manages automatic rendering window
-type AutoRenderGame struct {
-	env   *CartPoleEnv
-	mutex *sync.Mutex
+// Clone returns an independent copy of the environment, including its state and RNG.
+//
+// The clone has its own action and observation spaces, whose RNGs continue from the state of the original's,
+// so sampling or seeding them does not affect the original. The rendering state is not copied.
+func (env *CartPoleEnv) Clone() gym.Env[[]float64, int] {
+	clone := &CartPoleEnv{
+		gravity:               env.gravity,
+		masscart:              env.masscart,
+		masspole:              env.masspole,
+		totalMass:             env.totalMass,
+		length:                env.length,
+		polemasslength:        env.polemasslength,
+		forceMag:              env.forceMag,
+		tau:                   env.tau,
+		kinematicsIntegrator:  env.kinematicsIntegrator,
+		frameSkip:             env.frameSkip,
+		randomStartSteps:      env.randomStartSteps,
+		frictionCart:          env.frictionCart,
+		frictionPole:          env.frictionPole,
+		randomizePhysics:      env.randomizePhysics,
+		gravityRange:          env.gravityRange,
+		massCartRange:         env.massCartRange,
+		massPoleRange:         env.massPoleRange,
+		lengthRange:           env.lengthRange,
+		thetaThresholdRadians: env.thetaThresholdRadians,
+		xThreshold:            env.xThreshold,
+		state:                 slices.Clone(env.state),
+		rng:                   env.rng.Clone(),
+		suttonBartoReward:     env.suttonBartoReward,
+		renderMode:            env.renderMode,
+		actionSpace:           env.actionSpace.Clone(),
+		observationSpace:      env.observationSpace.Clone(),
+		episodeReturn:         env.episodeReturn,
+		episodeLength:         env.episodeLength,
+		metadata:              maps.Clone(env.metadata),
+		screenWidth:           env.screenWidth,
+		screenHeight:          env.screenHeight,
+		frames:                slices.Clone(env.frames),
+	}
+	if env.stepsBeyondTerminated != nil {
+		steps := *env.stepsBeyondTerminated
+		clone.stepsBeyondTerminated = &steps
+	}
+	return clone
 }
 
-func (g *AutoRenderGame) Update() error {
-	return nil // No game logic needed, just display
+// cartPoleStateVersion is the version of the encoding produced by CartPoleEnv.GetState.
+//
+// Version 2 added the return and length of the current episode.
+const cartPoleStateVersion = 2
+
+// cartPoleState is the serialized dynamics state of a CartPole environment.
+type cartPoleState struct {
+	Version               int       `json:"version"`
+	State                 []float64 `json:"state"`
+	StepsBeyondTerminated *int      `json:"steps_beyond_terminated,omitempty"`
+	RNG                   []byte    `json:"rng"`
+	Physics               []float64 `json:"physics,omitempty"` // gravity, masscart, masspole and length when randomized
+	EpisodeReturn         float64   `json:"episode_return"`
+	EpisodeLength         int       `json:"episode_length"`
 }
 
-func (g *AutoRenderGame) Draw(screen *ebiten.Image) {
-	g.mutex.Lock()
-	defer g.mutex.Unlock()
+// GetState serializes the dynamics state of the environment, including its RNG and the return and length of
+// the current episode.
+//
+// Unlike Clone, the encoding can be stored and restored in another process with SetState.
+// The configuration is not included, so the state must be restored into an identically configured environment.
+//
+// Returns:
+//   - The serialized state
+//   - An error if the state cannot be serialized
+func (env *CartPoleEnv) GetState() ([]byte, error) {
+	rngState, err := env.rng.State()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get RNG state: %w", err)
+	}
 
-	if g.env.screen != nil {
-		screen.DrawImage(g.env.screen, nil)
+	state := cartPoleState{
+		Version:               cartPoleStateVersion,
+		State:                 env.state,
+		StepsBeyondTerminated: env.stepsBeyondTerminated,
+		RNG:                   rngState,
+		EpisodeReturn:         env.episodeReturn,
+		EpisodeLength:         env.episodeLength,
+	}
+	if env.randomizePhysics {
+		state.Physics = []float64{env.gravity, env.masscart, env.masspole, env.length}
 	}
+	return json.Marshal(state)
 }
 
-func (g *AutoRenderGame) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight int) {
-	return 600, 400
+// SetState restores a dynamics state serialized by GetState.
+//
+// Parameters:
+//   - data: The serialized state
+//
+// Returns:
+//   - An error if the state is malformed or has an unsupported version
+func (env *CartPoleEnv) SetState(data []byte) error {
+	var decoded cartPoleState
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return fmt.Errorf("failed to decode state: %w", err)
+	}
+
+	if decoded.Version != cartPoleStateVersion {
+		return fmt.Errorf("unsupported state version %d, expected %d", decoded.Version, cartPoleStateVersion)
+	}
+	if decoded.State != nil && len(decoded.State) != 4 {
+		return fmt.Errorf("state must have 4 elements, got %d", len(decoded.State))
+	}
+
+	if decoded.Physics != nil && len(decoded.Physics) != 4 {
+		return fmt.Errorf("physics must have 4 elements, got %d", len(decoded.Physics))
+	}
+	if decoded.EpisodeLength < 0 {
+		return fmt.Errorf("episode length must be non-negative, got %d", decoded.EpisodeLength)
+	}
+
+	if err := env.rng.SetState(decoded.RNG); err != nil {
+		return fmt.Errorf("failed to set RNG state: %w", err)
+	}
+	if decoded.Physics != nil {
+		env.gravity, env.masscart, env.masspole, env.length = decoded.Physics[0], decoded.Physics[1], decoded.Physics[2], decoded.Physics[3]
+		env.updateDerivedParameters()
+	}
+	env.state = decoded.State
+	env.stepsBeyondTerminated = decoded.StepsBeyondTerminated
+	env.episodeReturn = decoded.EpisodeReturn
+	env.episodeLength = decoded.EpisodeLength
+	env.frames = nil
+	return nil
 }
 
 // ActionSpace returns the Space object corresponding to valid actions.
@@ -0,0 +1,23 @@
+//go:build headless
+
+package classic
+
+import (
+	"fmt"
+
+	"github.com/gocnn/gym"
+)
+
+// cartPoleRenderModes is empty because rendering is unavailable in headless builds.
+var cartPoleRenderModes = []string{}
+
+// cartPoleRenderer is the rendering state of a CartPole environment, empty in headless builds.
+type cartPoleRenderer struct{}
+
+// close releases the resources held by the renderer.
+func (r *cartPoleRenderer) close() {}
+
+// render always fails because rendering is unavailable in headless builds.
+func (env *CartPoleEnv) render() (gym.RenderFrame, error) {
+	return nil, fmt.Errorf("render mode %q is unavailable in headless builds", env.renderMode)
+}
@@ -0,0 +1,59 @@
+//go:build headless
+
+package classic_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gocnn/gym/envs/classic"
+)
+
+// TestCartPoleHeadless checks that CartPole can be constructed and stepped in headless builds, which do not
+// link Ebiten, and that rendering fails there instead of opening a window.
+func TestCartPoleHeadless(t *testing.T) {
+	if _, err := classic.NewCartPoleEnv(&classic.CartPoleConfig{RenderMode: "rgb_array"}); err == nil {
+		t.Error("NewCartPoleEnv with render mode rgb_array succeeded in a headless build, expected an error")
+	}
+
+	env, err := classic.NewCartPoleEnv(nil)
+	if err != nil {
+		t.Fatalf("NewCartPoleEnv failed: %v", err)
+	}
+	defer env.Close()
+	if modes := env.Metadata()["render_modes"].([]string); len(modes) != 0 {
+		t.Errorf("render_modes = %v in a headless build, want none", modes)
+	}
+
+	ctx := context.Background()
+	seed := int64(1)
+	if _, _, err := env.Reset(ctx, &seed, nil); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+
+	// Pushing right every step tips the pole over within a few dozen steps
+	terminated := false
+	for i := 0; i < 200 && !terminated; i++ {
+		var (
+			obs    []float64
+			reward float64
+		)
+		obs, reward, terminated, _, _, err = env.Step(ctx, 1)
+		if err != nil {
+			t.Fatalf("Step failed: %v", err)
+		}
+		if reward != 1 {
+			t.Fatalf("step %d reward = %f, want 1", i, reward)
+		}
+		if !terminated && !env.ObservationSpace().Contains(obs) {
+			t.Fatalf("step %d observation %v is not contained in the observation space", i, obs)
+		}
+	}
+	if !terminated {
+		t.Error("pushing right for 200 steps did not terminate the episode")
+	}
+
+	if _, err := env.Render(); err == nil {
+		t.Error("Render without a render mode succeeded, expected an error")
+	}
+}
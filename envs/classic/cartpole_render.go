@@ -0,0 +1,214 @@
+//go:build !headless
+
+package classic
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/gocnn/gym"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// cartPoleRenderModes lists the render modes supported by CartPole when built with Ebiten.
+var cartPoleRenderModes = []string{"human", "rgb_array", "rgb_array_list", "rgb_array_layers"}
+
+// cartPoleRenderer holds the Ebiten rendering state of a CartPole environment.
+type cartPoleRenderer struct {
+	screen *ebiten.Image
+	layers map[string]*ebiten.Image // object masks for "rgb_array_layers" mode
+
+	// Auto-rendering support
+	autoRenderGame *AutoRenderGame
+	mutex          sync.Mutex
+}
+
+// close releases the images held by the renderer.
+func (r *cartPoleRenderer) close() {
+	if r.screen != nil {
+		r.screen.Dispose()
+		r.screen = nil
+	}
+	for name, layer := range r.layers {
+		layer.Dispose()
+		delete(r.layers, name)
+	}
+}
+
+// render draws the current state with Ebiten in the environment's render mode.
+func (env *CartPoleEnv) render() (gym.RenderFrame, error) {
+	r := &env.renderer
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Initialize screen if not already done
+	if r.screen == nil {
+		r.screen = ebiten.NewImage(env.screenWidth, env.screenHeight)
+	}
+
+	// Clear screen with white background
+	r.screen.Fill(color.RGBA{255, 255, 255, 255})
+
+	// Get screen dimensions
+	bounds := r.screen.Bounds()
+	screenWidth := float64(bounds.Dx())
+	screenHeight := float64(bounds.Dy())
+
+	// Calculate scaling and positions
+	worldWidth := env.xThreshold * 2 // 4.8
+	scale := screenWidth / worldWidth
+	cartx := env.state[0]*scale + screenWidth/2
+	carty := screenHeight * 3 / 4 // Position from bottom, 100 px on the default 400 px screen
+
+	// CartPole parameters
+	polelen := scale * (2 * env.length) // use actual length from env
+	polewidth := 10.0
+	cartwidth, cartheight := 50.0, 30.0
+	axleoffset := cartheight / 4.0
+
+	// Draw track (horizontal line)
+	vector.StrokeLine(r.screen, 0, float32(carty), float32(screenWidth), float32(carty), 2, color.RGBA{0, 0, 0, 255}, false)
+
+	// Draw cart (rectangle)
+	cartLeft := cartx - cartwidth/2
+	cartTop := carty - cartheight/2
+
+	// Draw cart as filled rectangle
+	vector.DrawFilledRect(r.screen, float32(cartLeft), float32(cartTop), float32(cartwidth), float32(cartheight), color.RGBA{0, 0, 0, 255}, false)
+
+	// Calculate pole position
+	theta := env.state[2]
+	poleEndX := cartx + math.Sin(theta)*polelen
+	poleEndY := carty - math.Cos(theta)*polelen
+
+	// Draw pole (line with thickness)
+	vector.StrokeLine(r.screen, float32(cartx), float32(carty-axleoffset), float32(poleEndX), float32(poleEndY), float32(polewidth), color.RGBA{202, 152, 101, 255}, false)
+
+	// Draw axle (circle)
+	vector.DrawFilledCircle(r.screen, float32(cartx), float32(carty-axleoffset), float32(polewidth/2), color.RGBA{129, 132, 203, 255}, false)
+
+	// Display debug information
+	debugText := "CartPole Environment\n"
+	debugText += fmt.Sprintf("Position: %.2f\n", env.state[0])
+	debugText += fmt.Sprintf("Velocity: %.2f\n", env.state[1])
+	debugText += fmt.Sprintf("Angle: %.2f rad (%.1f°)\n", env.state[2], env.state[2]*180/math.Pi)
+	debugText += fmt.Sprintf("Angular Vel: %.2f\n", env.state[3])
+
+	ebitenutil.DebugPrint(r.screen, debugText)
+
+	// Auto-start rendering window for "human" mode
+	if env.renderMode == "human" && r.autoRenderGame == nil {
+		env.startAutoRender()
+	}
+
+	if env.renderMode == "rgb_array" || env.renderMode == "rgb_array_list" {
+		return toRGBA(r.screen), nil
+	}
+
+	if env.renderMode == "rgb_array_layers" {
+		// Draw each object as a white mask on a black background
+		cartMask := r.layer("cart")
+		vector.DrawFilledRect(cartMask, float32(cartLeft), float32(cartTop), float32(cartwidth), float32(cartheight), color.White, false)
+
+		poleMask := r.layer("pole")
+		vector.StrokeLine(poleMask, float32(cartx), float32(carty-axleoffset), float32(poleEndX), float32(poleEndY), float32(polewidth), color.White, false)
+
+		return map[string]*image.RGBA{
+			"rgb":  toRGBA(r.screen),
+			"cart": toRGBA(cartMask),
+			"pole": toRGBA(poleMask),
+		}, nil
+	}
+
+	// For "human" mode, return the Ebiten image directly
+	return r.screen, nil
+}
+
+// layer returns the cleared mask image with the given name, creating it if necessary.
+func (r *cartPoleRenderer) layer(name string) *ebiten.Image {
+	if r.layers == nil {
+		r.layers = make(map[string]*ebiten.Image)
+	}
+
+	img, ok := r.layers[name]
+	if !ok {
+		bounds := r.screen.Bounds()
+		img = ebiten.NewImage(bounds.Dx(), bounds.Dy())
+		r.layers[name] = img
+	}
+
+	img.Fill(color.Black)
+	return img
+}
+
+// toRGBA converts an Ebiten image to an RGB array.
+func toRGBA(img *ebiten.Image) *image.RGBA {
+	bounds := img.Bounds()
+	rgbaImg := image.NewRGBA(bounds)
+
+	// Read pixels from Ebiten image
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := img.At(x, y)
+			rgbaImg.Set(x, y, c)
+		}
+	}
+
+	return rgbaImg
+}
+
+// startAutoRender starts the automatic rendering window in a separate goroutine
+func (env *CartPoleEnv) startAutoRender() {
+	r := &env.renderer
+	r.autoRenderGame = &AutoRenderGame{
+		env:   env,
+		mutex: &r.mutex,
+	}
+
+	go func() {
+		ebiten.SetWindowSize(env.screenWidth, env.screenHeight)
+		ebiten.SetWindowTitle("CartPole Environment")
+		ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+
+		// Run the game loop
+		if err := ebiten.RunGame(r.autoRenderGame); err != nil {
+			// Window was closed, clean up
+			r.mutex.Lock()
+			r.autoRenderGame = nil
+			r.mutex.Unlock()
+		}
+	}()
+
+	// Give the window a moment to initialize
+	time.Sleep(100 * time.Millisecond)
+}
+
+// AutoRenderGame manages automatic rendering window
+type AutoRenderGame struct {
+	env   *CartPoleEnv
+	mutex *sync.Mutex
+}
+
+func (g *AutoRenderGame) Update() error {
+	return nil // No game logic needed, just display
+}
+
+func (g *AutoRenderGame) Draw(screen *ebiten.Image) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.env.renderer.screen != nil {
+		screen.DrawImage(g.env.renderer.screen, nil)
+	}
+}
+
+func (g *AutoRenderGame) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight int) {
+	return g.env.screenWidth, g.env.screenHeight
+}
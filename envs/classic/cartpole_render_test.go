@@ -0,0 +1,104 @@
+//go:build !headless
+
+package classic_test
+
+import (
+	"context"
+	"image"
+	"os"
+	"testing"
+
+	"github.com/gocnn/gym/envs/classic"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// renderingAvailable reports whether the tests run inside the Ebiten game loop, outside of which rendered
+// frames cannot be read back.
+var renderingAvailable bool
+
+// testGame runs the tests from its first Update, inside the Ebiten game loop.
+type testGame struct {
+	m    *testing.M
+	code int
+}
+
+func (g *testGame) Update() error {
+	renderingAvailable = true
+	g.code = g.m.Run()
+	return ebiten.Termination
+}
+
+func (*testGame) Draw(*ebiten.Image) {}
+
+func (*testGame) Layout(int, int) (int, int) {
+	return 320, 240
+}
+
+func TestMain(m *testing.M) {
+	g := &testGame{m: m, code: 1}
+	if err := ebiten.RunGame(g); err != nil && !renderingAvailable {
+		// The game loop could not start, run the tests without rendering
+		os.Exit(m.Run())
+	}
+	os.Exit(g.code)
+}
+
+// requireRendering skips the test if rendered frames cannot be read back.
+func requireRendering(t *testing.T) {
+	t.Helper()
+
+	if !renderingAvailable {
+		t.Skip("rendering requires the Ebiten game loop, which could not start")
+	}
+}
+
+func TestCartPoleRGBArrayList(t *testing.T) {
+	requireRendering(t)
+
+	env := newCartPole(t, &classic.CartPoleConfig{RenderMode: "rgb_array_list"}, 3)
+	for i := range 10 {
+		step(t, env, i%2)
+	}
+
+	frame, err := env.Render()
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	frames, ok := frame.([]image.Image)
+	if !ok {
+		t.Fatalf("Render returned %T, want []image.Image", frame)
+	}
+	if len(frames) != 10 {
+		t.Errorf("Render returned %d frames after 10 steps, want 10", len(frames))
+	}
+	for i, f := range frames {
+		if size := f.Bounds().Size(); size != image.Pt(600, 400) {
+			t.Errorf("frame %d has size %v, want 600x400", i, size)
+		}
+	}
+
+	seed := int64(3)
+	if _, _, err := env.Reset(context.Background(), &seed, nil); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	if frame, err := env.Render(); err != nil || len(frame.([]image.Image)) != 0 {
+		t.Errorf("Render after Reset = %v, %v, want no frames", frame, err)
+	}
+}
+
+func TestCartPoleScreenSize(t *testing.T) {
+	requireRendering(t)
+
+	env := newCartPole(t, &classic.CartPoleConfig{RenderMode: "rgb_array", ScreenWidth: 1200, ScreenHeight: 800}, 3)
+	frame, err := env.Render()
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	img, ok := frame.(image.Image)
+	if !ok {
+		t.Fatalf("Render returned %T, want an image.Image", frame)
+	}
+	if size := img.Bounds().Size(); size != image.Pt(1200, 800) {
+		t.Errorf("frame has size %v, want 1200x800", size)
+	}
+}
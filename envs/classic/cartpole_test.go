@@ -0,0 +1,693 @@
+package classic_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"maps"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/envs/classic"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// cartPoleTransition is a step of a recorded CartPole trajectory.
+type cartPoleTransition struct {
+	Action      int       `json:"action"`
+	Observation []float64 `json:"observation"`
+	Reward      float64   `json:"reward"`
+	Terminated  bool      `json:"terminated"`
+}
+
+// cartPoleTrajectory is a CartPole episode recorded from a seeded Reset with actions sampled from the action space.
+type cartPoleTrajectory struct {
+	Seed        int64                `json:"seed"`
+	Initial     []float64            `json:"initial"`
+	Transitions []cartPoleTransition `json:"transitions"`
+}
+
+// recordCartPole runs an episode of at most maxSteps steps from Reset with seed, sampling the actions from the
+// action space, which Reset seeds as well.
+func recordCartPole(t *testing.T, seed int64, maxSteps int) cartPoleTrajectory {
+	t.Helper()
+
+	env, err := classic.NewCartPoleEnv(nil)
+	if err != nil {
+		t.Fatalf("NewCartPoleEnv failed: %v", err)
+	}
+	defer env.Close()
+
+	ctx := context.Background()
+	obs, _, err := env.Reset(ctx, &seed, nil)
+	if err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+
+	trajectory := cartPoleTrajectory{Seed: seed, Initial: obs}
+	for range maxSteps {
+		action, err := env.ActionSpace().Sample(nil, nil)
+		if err != nil {
+			t.Fatalf("Sample failed: %v", err)
+		}
+		obs, reward, terminated, _, _, err := env.Step(ctx, action)
+		if err != nil {
+			t.Fatalf("Step failed: %v", err)
+		}
+		trajectory.Transitions = append(trajectory.Transitions, cartPoleTransition{
+			Action:      action,
+			Observation: obs,
+			Reward:      reward,
+			Terminated:  terminated,
+		})
+		if terminated {
+			break
+		}
+	}
+	return trajectory
+}
+
+// TestCartPoleGoldenTrajectory checks that seed 42 reproduces the committed reference trajectory bit for bit,
+// catching platform- or version-dependent nondeterminism. Run with -update to regenerate the reference.
+func TestCartPoleGoldenTrajectory(t *testing.T) {
+	golden := filepath.Join("testdata", "cartpole_seed42.json")
+	got := recordCartPole(t, 42, 200)
+
+	if *update {
+		data, err := json.MarshalIndent(got, "", "  ")
+		if err != nil {
+			t.Fatalf("failed to encode trajectory: %v", err)
+		}
+		if err := os.WriteFile(golden, append(data, '\n'), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", golden, err)
+		}
+	}
+
+	data, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", golden, err)
+	}
+	var want cartPoleTrajectory
+	if err := json.Unmarshal(data, &want); err != nil {
+		t.Fatalf("failed to decode %s: %v", golden, err)
+	}
+
+	if !slices.Equal(got.Initial, want.Initial) {
+		t.Fatalf("initial observation = %v, want %v", got.Initial, want.Initial)
+	}
+	if len(got.Transitions) != len(want.Transitions) {
+		t.Fatalf("trajectory has %d steps, want %d", len(got.Transitions), len(want.Transitions))
+	}
+	for i, g := range got.Transitions {
+		w := want.Transitions[i]
+		if g.Action != w.Action || g.Reward != w.Reward || g.Terminated != w.Terminated || !slices.Equal(g.Observation, w.Observation) {
+			t.Fatalf("step %d = %+v, want %+v", i, g, w)
+		}
+	}
+}
+
+// newCartPole creates a CartPole environment reset with seed, failing the test on error.
+func newCartPole(t *testing.T, config *classic.CartPoleConfig, seed int64) *classic.CartPoleEnv {
+	t.Helper()
+
+	env, err := classic.NewCartPoleEnv(config)
+	if err != nil {
+		t.Fatalf("NewCartPoleEnv failed: %v", err)
+	}
+	t.Cleanup(func() { env.Close() })
+
+	if _, _, err := env.Reset(context.Background(), &seed, nil); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	return env
+}
+
+// step steps env with action, failing the test on error.
+func step(t *testing.T, env gym.Env[[]float64, int], action int) ([]float64, float64, bool) {
+	t.Helper()
+
+	obs, reward, terminated, _, _, err := env.Step(context.Background(), action)
+	if err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+	return obs, reward, terminated
+}
+
+func TestCartPoleClone(t *testing.T) {
+	env := newCartPole(t, nil, 7)
+	for _, action := range []int{1, 0, 1, 1, 0} {
+		step(t, env, action)
+	}
+
+	clone := env.Clone()
+	actions := []int{0, 1, 1, 0, 1, 0, 0, 1}
+	for i, action := range actions {
+		want, wantReward, wantTerminated := step(t, env, action)
+		got, gotReward, gotTerminated := step(t, clone, action)
+		if !slices.Equal(got, want) || gotReward != wantReward || gotTerminated != wantTerminated {
+			t.Fatalf("step %d of the clone = (%v, %f, %v), want (%v, %f, %v)", i, got, gotReward, gotTerminated, want, wantReward, wantTerminated)
+		}
+	}
+
+	original, _, _ := step(t, env, 0)
+	diverged, _, _ := step(t, clone, 1)
+	if slices.Equal(original, diverged) {
+		t.Errorf("different actions gave the same observation %v", original)
+	}
+}
+
+func TestCartPoleCloneSpacesAreIndependent(t *testing.T) {
+	env := newCartPole(t, nil, 7)
+	clone := env.Clone()
+	if clone.ActionSpace() == env.ActionSpace() {
+		t.Fatal("the clone shares the action space of the original")
+	}
+
+	// The clone samples the same actions as the original would, without advancing the original's space
+	sample := func(s gym.Space[int]) []int {
+		actions := make([]int, 20)
+		for i := range actions {
+			action, err := s.Sample(nil, nil)
+			if err != nil {
+				t.Fatalf("Sample failed: %v", err)
+			}
+			actions[i] = action
+		}
+		return actions
+	}
+	cloned := sample(clone.ActionSpace())
+	if original := sample(env.ActionSpace()); !slices.Equal(cloned, original) {
+		t.Errorf("clone sampled %v, the original %v", cloned, original)
+	}
+
+	// Reseeding the clone does not reseed the original
+	want := sample(env.Clone().ActionSpace())
+	seed := int64(99)
+	if _, _, err := clone.Reset(context.Background(), &seed, nil); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	if got := sample(env.ActionSpace()); !slices.Equal(got, want) {
+		t.Errorf("original sampled %v after the clone was reset, want %v", got, want)
+	}
+}
+
+func TestCartPoleStateRoundTrip(t *testing.T) {
+	env := newCartPole(t, nil, 11)
+	for _, action := range []int{1, 0, 1} {
+		step(t, env, action)
+	}
+
+	saved, err := env.GetState()
+	if err != nil {
+		t.Fatalf("GetState failed: %v", err)
+	}
+	wantReturn, wantLength := env.EpisodeReturn(), env.EpisodeLength()
+
+	actions := []int{0, 0, 1, 0, 1}
+	var want [][]float64
+	for _, action := range actions {
+		obs, _, _ := step(t, env, action)
+		want = append(want, obs)
+	}
+
+	restored := newCartPole(t, nil, 0)
+	if err := restored.SetState(saved); err != nil {
+		t.Fatalf("SetState failed: %v", err)
+	}
+	if restored.EpisodeReturn() != wantReturn || restored.EpisodeLength() != wantLength {
+		t.Errorf("restored episode return and length = %f, %d, want %f, %d",
+			restored.EpisodeReturn(), restored.EpisodeLength(), wantReturn, wantLength)
+	}
+	for i, action := range actions {
+		if got, _, _ := step(t, restored, action); !slices.Equal(got, want[i]) {
+			t.Fatalf("step %d after SetState = %v, want %v", i, got, want[i])
+		}
+	}
+	if restored.EpisodeReturn() != env.EpisodeReturn() || restored.EpisodeLength() != env.EpisodeLength() {
+		t.Errorf("episode return and length after stepping = %f, %d, want %f, %d",
+			restored.EpisodeReturn(), restored.EpisodeLength(), env.EpisodeReturn(), env.EpisodeLength())
+	}
+
+	if err := restored.SetState([]byte(`{"version":1}`)); err == nil {
+		t.Error("SetState with version 1 succeeded, expected an error")
+	}
+}
+
+func TestCartPoleEpisodeStatistics(t *testing.T) {
+	env := newCartPole(t, nil, 5)
+
+	var total float64
+	steps := 0
+	for terminated := false; !terminated; {
+		action, err := env.ActionSpace().Sample(nil, nil)
+		if err != nil {
+			t.Fatalf("Sample failed: %v", err)
+		}
+		var reward float64
+		_, reward, terminated = step(t, env, action)
+		total += reward
+		steps++
+
+		if env.EpisodeReturn() != total || env.EpisodeLength() != steps {
+			t.Fatalf("after step %d EpisodeReturn, EpisodeLength = %f, %d, want %f, %d",
+				steps, env.EpisodeReturn(), env.EpisodeLength(), total, steps)
+		}
+		if steps == 3 {
+			// The clone and a restored state report the statistics of the original
+			clone, ok := env.Clone().(*classic.CartPoleEnv)
+			if !ok {
+				t.Fatalf("Clone returned %T, want *classic.CartPoleEnv", env.Clone())
+			}
+			saved, err := env.GetState()
+			if err != nil {
+				t.Fatalf("GetState failed: %v", err)
+			}
+			restored := newCartPole(t, nil, 0)
+			if err := restored.SetState(saved); err != nil {
+				t.Fatalf("SetState failed: %v", err)
+			}
+			for name, other := range map[string]*classic.CartPoleEnv{"clone": clone, "restored state": restored} {
+				if other.EpisodeReturn() != total || other.EpisodeLength() != steps {
+					t.Errorf("%s EpisodeReturn, EpisodeLength = %f, %d, want %f, %d",
+						name, other.EpisodeReturn(), other.EpisodeLength(), total, steps)
+				}
+			}
+		}
+	}
+
+	if _, _, err := env.Reset(context.Background(), nil, nil); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	if env.EpisodeReturn() != 0 || env.EpisodeLength() != 0 {
+		t.Errorf("after Reset EpisodeReturn, EpisodeLength = %f, %d, want 0, 0", env.EpisodeReturn(), env.EpisodeLength())
+	}
+}
+
+func TestContinuousCartPoleEpisodeStatistics(t *testing.T) {
+	env, err := classic.NewContinuousCartPoleEnv(nil)
+	if err != nil {
+		t.Fatalf("NewContinuousCartPoleEnv failed: %v", err)
+	}
+	defer env.Close()
+
+	ctx := context.Background()
+	seed := int64(5)
+	if _, _, err := env.Reset(ctx, &seed, nil); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+
+	var total float64
+	steps := 0
+	for terminated := false; !terminated && steps < 500; steps++ {
+		var reward float64
+		_, reward, terminated, _, _, err = env.Step(ctx, []float64{1})
+		if err != nil {
+			t.Fatalf("Step failed: %v", err)
+		}
+		total += reward
+	}
+	if env.EpisodeReturn() != total || env.EpisodeLength() != steps {
+		t.Errorf("EpisodeReturn, EpisodeLength = %f, %d, want %f, %d", env.EpisodeReturn(), env.EpisodeLength(), total, steps)
+	}
+}
+
+func TestCartPoleRejectsNegativeScreenSize(t *testing.T) {
+	if _, err := classic.NewCartPoleEnv(&classic.CartPoleConfig{ScreenWidth: -1, ScreenHeight: 400}); err == nil {
+		t.Error("NewCartPoleEnv with a negative screen width succeeded, expected an error")
+	}
+}
+
+func TestCartPoleCancelledContext(t *testing.T) {
+	env := newCartPole(t, nil, 9)
+	step(t, env, 1)
+
+	before, err := env.GetState()
+	if err != nil {
+		t.Fatalf("GetState failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, _, _, _, err := env.Step(ctx, 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("Step with a cancelled context returned %v, want %v", err, context.Canceled)
+	}
+	seed := int64(1)
+	if _, _, err := env.Reset(ctx, &seed, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Reset with a cancelled context returned %v, want %v", err, context.Canceled)
+	}
+
+	after, err := env.GetState()
+	if err != nil {
+		t.Fatalf("GetState failed: %v", err)
+	}
+	if !bytes.Equal(before, after) {
+		t.Errorf("cancelled calls changed the state from %s to %s", before, after)
+	}
+}
+
+// resetUpright resets env to the state with every component 0, the cart at rest with the pole upright.
+func resetUpright(t *testing.T, env *classic.CartPoleEnv) {
+	t.Helper()
+
+	if _, _, err := env.Reset(context.Background(), nil, gym.Info{"low": 0.0, "high": 0.0}); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+}
+
+func TestCartPoleKinematicsIntegrators(t *testing.T) {
+	euler := newCartPole(t, nil, 4)
+	semiImplicit := newCartPole(t, &classic.CartPoleConfig{KinematicsIntegrator: "semi-implicit-euler"}, 4)
+	resetUpright(t, euler)
+	resetUpright(t, semiImplicit)
+
+	// From rest, Euler moves the cart with the velocity before the step, which is 0, and the semi-implicit
+	// scheme with the velocity after it
+	eulerObs, _, _ := step(t, euler, 1)
+	semiObs, _, _ := step(t, semiImplicit, 1)
+	if eulerObs[0] != 0 || eulerObs[2] != 0 {
+		t.Errorf("Euler moved the cart and pole from rest in one step: %v", eulerObs)
+	}
+	if semiObs[0] <= 0 || semiObs[2] >= 0 {
+		t.Errorf("semi-implicit Euler did not push the cart right and tip the pole left in one step: %v", semiObs)
+	}
+
+	for i, action := range []int{1, 0, 0, 1, 1} {
+		eulerObs, _, _ = step(t, euler, action)
+		semiObs, _, _ = step(t, semiImplicit, action)
+		if slices.Equal(eulerObs, semiObs) {
+			t.Errorf("step %d of both integrators gave %v", i+2, eulerObs)
+		}
+	}
+
+	if _, err := classic.NewCartPoleEnv(&classic.CartPoleConfig{KinematicsIntegrator: "rk4"}); err == nil {
+		t.Error("NewCartPoleEnv with integrator rk4 succeeded, expected an error")
+	}
+}
+
+func TestCartPoleForceMag(t *testing.T) {
+	velocity := func(forceMag float64) float64 {
+		t.Helper()
+
+		env := newCartPole(t, &classic.CartPoleConfig{ForceMag: forceMag}, 1)
+		resetUpright(t, env)
+		obs, _, _ := step(t, env, 1)
+		return obs[1]
+	}
+
+	// From rest the acceleration is proportional to the force
+	base, doubled := velocity(10), velocity(20)
+	if base <= 0 {
+		t.Fatalf("cart velocity after pushing right = %f, want positive", base)
+	}
+	if math.Abs(doubled-2*base) > 1e-12 {
+		t.Errorf("cart velocity with ForceMag 20 = %f, want twice %f", doubled, base)
+	}
+}
+
+func TestCartPoleDomainRandomization(t *testing.T) {
+	config := &classic.CartPoleConfig{
+		RandomizePhysics: true,
+		GravityRange:     [2]float64{8, 12},
+		MassPoleRange:    [2]float64{0.05, 0.2},
+	}
+	env, err := classic.NewCartPoleEnv(config)
+	if err != nil {
+		t.Fatalf("NewCartPoleEnv failed: %v", err)
+	}
+	defer env.Close()
+
+	reset := func(seed int64) gym.Info {
+		t.Helper()
+
+		_, info, err := env.Reset(context.Background(), &seed, nil)
+		if err != nil {
+			t.Fatalf("Reset failed: %v", err)
+		}
+		return info
+	}
+
+	first, second := reset(1), reset(2)
+	if first["gravity"] == second["gravity"] || first["masspole"] == second["masspole"] {
+		t.Errorf("seeds 1 and 2 gave the same parameters %v and %v", first, second)
+	}
+	for _, info := range []gym.Info{first, second} {
+		if g := info["gravity"].(float64); g < 8 || g > 12 {
+			t.Errorf("gravity %f is outside [8, 12]", g)
+		}
+		if m := info["masspole"].(float64); m < 0.05 || m > 0.2 {
+			t.Errorf("masspole %f is outside [0.05, 0.2]", m)
+		}
+		if info["masscart"] != 1.0 || info["length"] != 0.5 {
+			t.Errorf("parameters without a range = %v and %v, want the defaults 1 and 0.5", info["masscart"], info["length"])
+		}
+	}
+
+	if again := reset(1); !maps.Equal(again, first) {
+		t.Errorf("seed 1 gave %v, then %v", first, again)
+	}
+}
+
+func TestCartPoleResetSeedsActionSpace(t *testing.T) {
+	sample := func(env *classic.CartPoleEnv) []int {
+		t.Helper()
+
+		actions := make([]int, 50)
+		for i := range actions {
+			action, err := env.ActionSpace().Sample(nil, nil)
+			if err != nil {
+				t.Fatalf("Sample failed: %v", err)
+			}
+			actions[i] = action
+		}
+		return actions
+	}
+
+	first := newCartPole(t, nil, 5)
+	want := sample(first)
+
+	// Samples drawn before the seeded Reset do not change the sequence
+	second, err := classic.NewCartPoleEnv(nil)
+	if err != nil {
+		t.Fatalf("NewCartPoleEnv failed: %v", err)
+	}
+	defer second.Close()
+	sample(second)
+	seed := int64(5)
+	if _, _, err := second.Reset(context.Background(), &seed, nil); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	if got := sample(second); !slices.Equal(got, want) {
+		t.Errorf("seed 5 sampled %v, then %v", want, got)
+	}
+
+	if other := sample(newCartPole(t, nil, 6)); slices.Equal(other, want) {
+		t.Errorf("seeds 5 and 6 sampled the same actions %v", want)
+	}
+}
+
+func TestCartPoleFrameSkip(t *testing.T) {
+	skipping := newCartPole(t, &classic.CartPoleConfig{FrameSkip: 2}, 3)
+	plain := newCartPole(t, nil, 3)
+
+	// Pushing right until the pole falls, each skipping step matches two plain steps, stopping early if the
+	// first of them terminates
+	terminated := false
+	for i := 0; !terminated; i++ {
+		if i == 100 {
+			t.Fatal("pushing right did not terminate the episode in 100 steps")
+		}
+
+		skipObs, skipReward, skipTerminated := step(t, skipping, 1)
+		var (
+			obs    []float64
+			reward float64
+		)
+		obs, reward, terminated = step(t, plain, 1)
+		if !terminated {
+			var r float64
+			obs, r, terminated = step(t, plain, 1)
+			reward += r
+		}
+		if !slices.Equal(skipObs, obs) || skipReward != reward || skipTerminated != terminated {
+			t.Fatalf("step %d with FrameSkip 2 = (%v, %f, %v), two steps gave (%v, %f, %v)",
+				i, skipObs, skipReward, skipTerminated, obs, reward, terminated)
+		}
+	}
+
+	if _, err := classic.NewCartPoleEnv(&classic.CartPoleConfig{FrameSkip: -1}); err == nil {
+		t.Error("NewCartPoleEnv with FrameSkip -1 succeeded, expected an error")
+	}
+}
+
+func TestCartPoleSpecJSONRoundTrip(t *testing.T) {
+	spec, err := gym.Spec[[]float64, int]("CartPole-v1")
+	if err != nil {
+		t.Fatalf("Spec failed: %v", err)
+	}
+	spec.Kwargs = map[string]any{"frame_skip": 2, "random_start_steps": 0, "screen_width": 300, "gravity": 9.8}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var decoded gym.EnvSpec[[]float64, int]
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	// The int kwargs are decoded as float64
+	env, err := decoded.EntryPoint(decoded.Kwargs)
+	if err != nil {
+		t.Fatalf("EntryPoint with the decoded kwargs %v failed: %v", decoded.Kwargs, err)
+	}
+	defer env.Close()
+	seed := int64(3)
+	if _, _, err := env.Reset(context.Background(), &seed, nil); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+
+	skipping := newCartPole(t, &classic.CartPoleConfig{FrameSkip: 2, ScreenWidth: 300}, 3)
+	for i := range 5 {
+		obs, reward, _ := step(t, env, 1)
+		wantObs, wantReward, _ := step(t, skipping, 1)
+		if !slices.Equal(obs, wantObs) || reward != wantReward {
+			t.Fatalf("step %d of the decoded spec = (%v, %f), want (%v, %f) with FrameSkip 2", i, obs, reward, wantObs, wantReward)
+		}
+	}
+
+	decoded.Kwargs["frame_skip"] = 2.5
+	if _, err := decoded.EntryPoint(decoded.Kwargs); err == nil {
+		t.Error("EntryPoint with frame_skip 2.5 succeeded, expected an error")
+	}
+}
+
+func TestCartPoleRandomStartSteps(t *testing.T) {
+	env, err := classic.NewCartPoleEnv(&classic.CartPoleConfig{RandomStartSteps: 30})
+	if err != nil {
+		t.Fatalf("NewCartPoleEnv failed: %v", err)
+	}
+	defer env.Close()
+
+	// Every reset starts upright at rest, so only the random actions move the state
+	upright := gym.Info{"low": 0.0, "high": 0.0}
+	reset := func(seed int64) []float64 {
+		t.Helper()
+
+		obs, _, err := env.Reset(context.Background(), &seed, upright)
+		if err != nil {
+			t.Fatalf("Reset failed: %v", err)
+		}
+		if env.EpisodeLength() != 0 {
+			t.Errorf("EpisodeLength() after Reset = %d, want 0", env.EpisodeLength())
+		}
+		return obs
+	}
+
+	seen := make([][]float64, 0, 5)
+	for seed := int64(1); seed <= 5; seed++ {
+		obs := reset(seed)
+		if slices.Equal(obs, []float64{0, 0, 0, 0}) {
+			t.Errorf("seed %d started at rest despite the random actions", seed)
+		}
+		if math.Abs(obs[0]) > 2.4 || math.Abs(obs[2]) > 12*2*math.Pi/360 {
+			t.Errorf("seed %d started in the terminal state %v", seed, obs)
+		}
+		for _, other := range seen {
+			if slices.Equal(obs, other) {
+				t.Errorf("seed %d started at %v, like an earlier seed", seed, obs)
+			}
+		}
+		seen = append(seen, obs)
+	}
+
+	if again := reset(1); !slices.Equal(again, seen[0]) {
+		t.Errorf("seed 1 started at %v, then %v", seen[0], again)
+	}
+
+	if _, err := classic.NewCartPoleEnv(&classic.CartPoleConfig{RandomStartSteps: -1}); err == nil {
+		t.Error("NewCartPoleEnv with RandomStartSteps -1 succeeded, expected an error")
+	}
+}
+
+func TestCartPolePerComponentResetBounds(t *testing.T) {
+	env := newCartPole(t, nil, 1)
+	reset := func(seed int64, options gym.Info) ([]float64, error) {
+		obs, _, err := env.Reset(context.Background(), &seed, options)
+		return obs, err
+	}
+
+	// Equal bounds pin each component to its own value
+	obs, err := reset(2, gym.Info{"low": []float64{0.1, -0.2, 0.03, -0.04}, "high": []float64{0.1, -0.2, 0.03, -0.04}})
+	if err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	if want := []float64{0.1, -0.2, 0.03, -0.04}; !slices.Equal(obs, want) {
+		t.Errorf("Reset with equal bounds = %v, want %v", obs, want)
+	}
+
+	// A per-component bound can be mixed with a scalar one
+	low, high := []float64{-1, 0.5, -0.1, 0}, 1.0
+	for seed := int64(1); seed <= 20; seed++ {
+		obs, err := reset(seed, gym.Info{"low": low, "high": high})
+		if err != nil {
+			t.Fatalf("Reset failed: %v", err)
+		}
+		for i, v := range obs {
+			if v < low[i] || v > high {
+				t.Errorf("component %d = %f, outside [%v, %v]", i, v, low[i], high)
+			}
+		}
+	}
+
+	for name, options := range map[string]gym.Info{
+		"three bounds":      {"low": []float64{0, 0, 0}},
+		"low above high":    {"low": []float64{0, 0, 0.2, 0}, "high": []float64{0, 0, 0.1, 0}},
+		"float32 bounds":    {"high": []float32{1, 1, 1, 1}},
+		"integer low bound": {"low": 0},
+	} {
+		if _, err := reset(2, options); err == nil {
+			t.Errorf("Reset with %s succeeded, expected an error", name)
+		}
+	}
+}
+
+func TestCartPoleResetNilSeedKeepsRNG(t *testing.T) {
+	reset := func(env *classic.CartPoleEnv, seed *int64) []float64 {
+		t.Helper()
+
+		obs, _, err := env.Reset(context.Background(), seed, nil)
+		if err != nil {
+			t.Fatalf("Reset failed: %v", err)
+		}
+		return obs
+	}
+
+	// Seed 0 reseeds deterministically rather than from the time
+	zero := int64(0)
+	first, second := newCartPole(t, nil, 0), newCartPole(t, nil, 0)
+	start := reset(first, &zero)
+	if again := reset(second, &zero); !slices.Equal(again, start) {
+		t.Fatalf("seed 0 started at %v, then %v", start, again)
+	}
+
+	// A nil seed continues the sequence of the RNG, identically for identically seeded environments
+	next := reset(first, nil)
+	if slices.Equal(next, start) {
+		t.Errorf("Reset with a nil seed reseeded the RNG, starting again at %v", start)
+	}
+	if again := reset(second, nil); !slices.Equal(again, next) {
+		t.Errorf("Reset with a nil seed after seed 0 started at %v, then %v", next, again)
+	}
+
+	// Reseeding with 0 restarts the sequence
+	if again := reset(first, &zero); !slices.Equal(again, start) {
+		t.Errorf("reseeding with 0 started at %v, want %v", again, start)
+	}
+}
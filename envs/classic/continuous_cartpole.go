@@ -0,0 +1,139 @@
+package classic
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/rand"
+	"github.com/gocnn/gym/space"
+)
+
+// ContinuousCartPoleEnv implements the cart-pole system with a continuous action.
+//
+// The physics, observations, rewards, termination and rendering are identical to CartPoleEnv.
+//
+// ## Action Space
+// The action is a 1-element array in [-1, 1] scaling the force the cart is pushed with.
+// Negative values push the cart to the left and positive values to the right.
+// Actions outside of the bounds are clipped.
+type ContinuousCartPoleEnv struct {
+	cartPole *CartPoleEnv
+
+	// Spaces
+	actionSpace gym.Space[[]float64]
+}
+
+// NewContinuousCartPoleEnv creates a new continuous CartPole environment instance.
+//
+// Parameters:
+//   - config: Configuration options for the environment
+//
+// Returns:
+//   - A new continuous CartPole environment
+//   - An error if initialization fails
+func NewContinuousCartPoleEnv(config *CartPoleConfig) (*ContinuousCartPoleEnv, error) {
+	cartPole, err := NewCartPoleEnv(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create action space: Box(1) for the scaled force
+	actionSpace, err := space.NewBoxWithRNG(cartPole.rng.Derive(), []float64{-1.0}, []float64{1.0})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create action space: %w", err)
+	}
+
+	return &ContinuousCartPoleEnv{
+		cartPole:    cartPole,
+		actionSpace: actionSpace,
+	}, nil
+}
+
+// Close performs cleanup when the user has finished using the environment.
+func (env *ContinuousCartPoleEnv) Close() error {
+	return env.cartPole.Close()
+}
+
+// Step runs one timestep of the environment's dynamics using the agent action.
+func (env *ContinuousCartPoleEnv) Step(ctx context.Context, action []float64) ([]float64, float64, bool, bool, gym.Info, error) {
+	select {
+	case <-ctx.Done():
+		return nil, 0, false, false, nil, ctx.Err()
+	default:
+	}
+
+	if len(action) != 1 || math.IsNaN(action[0]) {
+		return nil, 0, false, false, nil, fmt.Errorf("invalid action %v", action)
+	}
+
+	if env.cartPole.state == nil {
+		return nil, 0, false, false, nil, fmt.Errorf("call Reset before using Step method")
+	}
+
+	force := math.Max(-1.0, math.Min(action[0], 1.0)) * env.cartPole.forceMag
+	observation, reward, terminated := env.cartPole.step(force)
+	if err := env.cartPole.recordFrame(); err != nil {
+		return nil, 0, false, false, nil, err
+	}
+	env.cartPole.episodeReturn += reward
+	env.cartPole.episodeLength++
+
+	// truncation=false as the time limit is handled by the TimeLimit wrapper
+	return observation, reward, terminated, false, gym.Info{}, nil
+}
+
+// EpisodeReturn returns the sum of the rewards of the steps since the last Reset.
+func (env *ContinuousCartPoleEnv) EpisodeReturn() float64 {
+	return env.cartPole.episodeReturn
+}
+
+// EpisodeLength returns the number of steps since the last Reset.
+func (env *ContinuousCartPoleEnv) EpisodeLength() int {
+	return env.cartPole.episodeLength
+}
+
+// Reset resets the environment to an initial internal state, returning an initial observation and info.
+//
+// As for CartPoleEnv, a seed also seeds the action space.
+func (env *ContinuousCartPoleEnv) Reset(ctx context.Context, seed *int64, options gym.Info) ([]float64, gym.Info, error) {
+	obs, info, err := env.cartPole.Reset(ctx, seed, options)
+	if err != nil || seed == nil {
+		return obs, info, err
+	}
+	if err := seedActionSpace(env.actionSpace, *seed); err != nil {
+		return nil, nil, err
+	}
+	return obs, info, nil
+}
+
+// Render computes the render frames as specified by the environment's render mode.
+func (env *ContinuousCartPoleEnv) Render() (gym.RenderFrame, error) {
+	return env.cartPole.Render()
+}
+
+// ActionSpace returns the Space object corresponding to valid actions.
+func (env *ContinuousCartPoleEnv) ActionSpace() gym.Space[[]float64] {
+	return env.actionSpace
+}
+
+// ObservationSpace returns the Space object corresponding to valid observations.
+func (env *ContinuousCartPoleEnv) ObservationSpace() gym.Space[[]float64] {
+	return env.cartPole.ObservationSpace()
+}
+
+// Metadata returns the metadata of the environment.
+func (env *ContinuousCartPoleEnv) Metadata() gym.Metadata {
+	return env.cartPole.Metadata()
+}
+
+// Unwrapped returns the base non-wrapped environment.
+func (env *ContinuousCartPoleEnv) Unwrapped() gym.Env[[]float64, []float64] {
+	return env
+}
+
+// GetRNG returns the environment's random number generator.
+func (env *ContinuousCartPoleEnv) GetRNG() *rand.RNG {
+	return env.cartPole.GetRNG()
+}
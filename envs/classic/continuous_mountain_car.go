@@ -1 +1,167 @@
 package classic
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/rand"
+	"github.com/gocnn/gym/space"
+)
+
+// ContinuousMountainCarEnv implements the mountain car problem with a continuous action.
+//
+// The observations, starting state and rendering are identical to MountainCarEnv, but the goal is slightly
+// lower, at position 0.45.
+//
+// ## Action Space
+// The action is a 1-element array in [-1, 1] scaling the power applied to the car.
+// Negative values accelerate the car to the left and positive values to the right.
+// Actions outside of the bounds are clipped.
+//
+// ## Transition Dynamics
+// velocity += action * 0.0015 - 0.0025 * cos(3 * position), clipped to [-0.07, 0.07]
+// position += velocity, clipped to [-1.2, 0.6], with the velocity set to 0 when hitting the left wall
+//
+// ## Rewards
+// A reward of -0.1 * action^2 is given for every step, penalizing large actions,
+// and +100 is added when the car reaches the goal.
+//
+// ## Episode End
+// The episode ends if any one of the following occurs:
+// 1. Termination: The position of the car is greater than or equal to 0.45 (the goal position on top of the right hill)
+// 2. Truncation: Episode length is greater than 999 (handled by TimeLimit wrapper)
+type ContinuousMountainCarEnv struct {
+	mountainCar *MountainCarEnv
+
+	// Environment parameters
+	power float64
+
+	// Spaces
+	actionSpace gym.Space[[]float64]
+}
+
+// NewContinuousMountainCarEnv creates a new continuous MountainCar environment instance.
+//
+// Parameters:
+//   - config: Configuration options for the environment
+//
+// Returns:
+//   - A new continuous MountainCar environment
+//   - An error if initialization fails
+func NewContinuousMountainCarEnv(config *MountainCarConfig) (*ContinuousMountainCarEnv, error) {
+	mountainCar, err := NewMountainCarEnv(config)
+	if err != nil {
+		return nil, err
+	}
+	mountainCar.goalPosition = 0.45
+	mountainCar.metadata["reward_threshold"] = 90.0
+	mountainCar.metadata["max_episode_steps"] = 999
+
+	// Create action space: Box(1) for the scaled power
+	actionSpace, err := space.NewBoxWithRNG(mountainCar.rng.Derive(), []float64{-1.0}, []float64{1.0})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create action space: %w", err)
+	}
+
+	return &ContinuousMountainCarEnv{
+		mountainCar: mountainCar,
+		power:       0.0015,
+		actionSpace: actionSpace,
+	}, nil
+}
+
+// Close performs cleanup when the user has finished using the environment.
+func (env *ContinuousMountainCarEnv) Close() error {
+	return env.mountainCar.Close()
+}
+
+// Step runs one timestep of the environment's dynamics using the agent action.
+func (env *ContinuousMountainCarEnv) Step(ctx context.Context, action []float64) ([]float64, float64, bool, bool, gym.Info, error) {
+	select {
+	case <-ctx.Done():
+		return nil, 0, false, false, nil, ctx.Err()
+	default:
+	}
+
+	if len(action) != 1 || math.IsNaN(action[0]) {
+		return nil, 0, false, false, nil, fmt.Errorf("invalid action %v", action)
+	}
+
+	mc := env.mountainCar
+	if mc.state == nil {
+		return nil, 0, false, false, nil, fmt.Errorf("call Reset before using Step method")
+	}
+
+	position, velocity := mc.state[0], mc.state[1]
+	force := math.Max(-1.0, math.Min(action[0], 1.0))
+
+	velocity += force*env.power - math.Cos(3*position)*mc.gravity
+	velocity = math.Max(-mc.maxSpeed, math.Min(velocity, mc.maxSpeed))
+	position += velocity
+	position = math.Max(mc.minPosition, math.Min(position, mc.maxPosition))
+	if position == mc.minPosition && velocity < 0 {
+		velocity = 0
+	}
+
+	mc.state = []float64{position, velocity}
+
+	terminated := position >= mc.goalPosition && velocity >= mc.goalVelocity
+
+	reward := -0.1 * force * force
+	if terminated {
+		reward += 100.0
+	}
+
+	// Create observation (copy of state)
+	observation := make([]float64, len(mc.state))
+	copy(observation, mc.state)
+
+	// truncation=false as the time limit is handled by the TimeLimit wrapper
+	return observation, reward, terminated, false, gym.Info{}, nil
+}
+
+// Reset resets the environment to an initial internal state, returning an initial observation and info.
+//
+// A seed also seeds the action space, with a seed spawned from it so that sampled actions are reproducible.
+func (env *ContinuousMountainCarEnv) Reset(ctx context.Context, seed *int64, options gym.Info) ([]float64, gym.Info, error) {
+	obs, info, err := env.mountainCar.Reset(ctx, seed, options)
+	if err != nil || seed == nil {
+		return obs, info, err
+	}
+	if err := seedActionSpace(env.actionSpace, *seed); err != nil {
+		return nil, nil, err
+	}
+	return obs, info, nil
+}
+
+// Render computes the render frames as specified by the environment's render mode.
+func (env *ContinuousMountainCarEnv) Render() (gym.RenderFrame, error) {
+	return env.mountainCar.Render()
+}
+
+// ActionSpace returns the Space object corresponding to valid actions.
+func (env *ContinuousMountainCarEnv) ActionSpace() gym.Space[[]float64] {
+	return env.actionSpace
+}
+
+// ObservationSpace returns the Space object corresponding to valid observations.
+func (env *ContinuousMountainCarEnv) ObservationSpace() gym.Space[[]float64] {
+	return env.mountainCar.ObservationSpace()
+}
+
+// Metadata returns the metadata of the environment.
+func (env *ContinuousMountainCarEnv) Metadata() gym.Metadata {
+	return env.mountainCar.Metadata()
+}
+
+// Unwrapped returns the base non-wrapped environment.
+func (env *ContinuousMountainCarEnv) Unwrapped() gym.Env[[]float64, []float64] {
+	return env
+}
+
+// GetRNG returns the environment's random number generator.
+func (env *ContinuousMountainCarEnv) GetRNG() *rand.RNG {
+	return env.mountainCar.GetRNG()
+}
@@ -0,0 +1,117 @@
+package classic_test
+
+import (
+	"context"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/envs/classic"
+)
+
+func TestContinuousMountainCarMaxThrottle(t *testing.T) {
+	env, err := classic.NewContinuousMountainCarEnv(nil)
+	if err != nil {
+		t.Fatalf("NewContinuousMountainCarEnv failed: %v", err)
+	}
+	defer env.Close()
+
+	// run drives the car from a seeded standard start for at most 999 steps, the episode limit of
+	// MountainCarContinuous-v0, returning the number of steps, the return and whether the goal was reached
+	run := func(seed int64, policy func(obs []float64) float64) (int, float64, bool) {
+		t.Helper()
+
+		ctx := context.Background()
+		obs, _, err := env.Reset(ctx, &seed, nil)
+		if err != nil {
+			t.Fatalf("Reset failed: %v", err)
+		}
+		total := 0.0
+		for steps := 1; steps <= 999; steps++ {
+			var (
+				reward     float64
+				terminated bool
+			)
+			obs, reward, terminated, _, _, err = env.Step(ctx, []float64{policy(obs)})
+			if err != nil {
+				t.Fatalf("Step failed: %v", err)
+			}
+			total += reward
+			if terminated {
+				if obs[0] < 0.45 {
+					t.Errorf("episode terminated at position %f, before the goal", obs[0])
+				}
+				return steps, total, true
+			}
+		}
+		return 999, total, false
+	}
+
+	for seed := int64(1); seed <= 5; seed++ {
+		// The engine is weaker than gravity on the right hill, so constant full throttle stalls
+		if steps, _, reached := run(seed, func([]float64) float64 { return 1 }); reached {
+			t.Errorf("seed %d: constant full throttle reached the goal in %d steps, want it to stall", seed, steps)
+		}
+
+		// Full throttle in the direction of motion swings the car up to the goal
+		steps, total, reached := run(seed, func(obs []float64) float64 {
+			if obs[1] < 0 {
+				return -1
+			}
+			return 1
+		})
+		if !reached {
+			t.Errorf("seed %d: full throttle along the velocity did not reach the goal in 999 steps", seed)
+			continue
+		}
+		// Every full-throttle step costs 0.1
+		if want := 100 - 0.1*float64(steps); math.Abs(total-want) > 1e-9 {
+			t.Errorf("seed %d: return after %d steps = %f, want %f", seed, steps, total, want)
+		}
+	}
+}
+
+func TestContinuousResetSeedsActionSpace(t *testing.T) {
+	constructors := map[string]func() (gym.Env[[]float64, []float64], error){
+		"ContinuousCartPole": func() (gym.Env[[]float64, []float64], error) {
+			return classic.NewContinuousCartPoleEnv(nil)
+		},
+		"ContinuousMountainCar": func() (gym.Env[[]float64, []float64], error) {
+			return classic.NewContinuousMountainCarEnv(nil)
+		},
+	}
+	for name, newEnv := range constructors {
+		// sample resets a new environment with seed, after sampling skip actions, and samples 20 actions
+		sample := func(seed int64, skip int) [][]float64 {
+			t.Helper()
+
+			env, err := newEnv()
+			if err != nil {
+				t.Fatalf("creating %s failed: %v", name, err)
+			}
+			defer env.Close()
+
+			actions := make([][]float64, skip+20)
+			for i := range actions {
+				if i == skip {
+					if _, _, err := env.Reset(context.Background(), &seed, nil); err != nil {
+						t.Fatalf("Reset failed: %v", err)
+					}
+				}
+				if actions[i], err = env.ActionSpace().Sample(nil, nil); err != nil {
+					t.Fatalf("Sample failed: %v", err)
+				}
+			}
+			return actions[skip:]
+		}
+
+		want := sample(5, 0)
+		if got := sample(5, 7); !slices.EqualFunc(got, want, slices.Equal) {
+			t.Errorf("%s with seed 5 sampled %v, then %v", name, want, got)
+		}
+		if other := sample(6, 0); slices.EqualFunc(other, want, slices.Equal) {
+			t.Errorf("%s with seeds 5 and 6 sampled the same actions %v", name, want)
+		}
+	}
+}
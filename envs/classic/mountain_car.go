@@ -1 +1,264 @@
 package classic
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/rand"
+	"github.com/gocnn/gym/space"
+)
+
+// MountainCarEnv implements the mountain car problem described by Andrew Moore.
+//
+// This environment corresponds to the version of the mountain car problem described by Andrew Moore in his PhD thesis
+// "Efficient Memory-based Learning for Robot Control".
+// A car is placed stochastically at the bottom of a sinusoidal valley, with the only possible actions being
+// the accelerations that can be applied to the car in either direction. The goal is to strategically accelerate
+// the car to reach the goal state on top of the right hill.
+//
+// ## Action Space
+// The action is an integer which can take values {0, 1, 2} indicating the direction of the acceleration.
+// - 0: Accelerate to the left
+// - 1: Don't accelerate
+// - 2: Accelerate to the right
+//
+// ## Observation Space
+// The observation is a 2-element array with the values corresponding to the following position and velocity:
+// | Index | Observation                          | Min   | Max  |
+// |-------|--------------------------------------|-------|------|
+// | 0     | Position of the car along the x-axis | -1.2  | 0.6  |
+// | 1     | Velocity of the car                  | -0.07 | 0.07 |
+//
+// ## Rewards
+// A reward of -1 is given for every step taken, including the termination step.
+//
+// ## Episode End
+// The episode ends if any one of the following occurs:
+// 1. Termination: The position of the car is greater than or equal to 0.5 (the goal position on top of the right hill)
+// 2. Truncation: Episode length is greater than 200 (handled by TimeLimit wrapper)
+type MountainCarEnv struct {
+	// Environment parameters
+	minPosition  float64
+	maxPosition  float64
+	maxSpeed     float64
+	goalPosition float64
+	goalVelocity float64
+	force        float64
+	gravity      float64
+
+	// State
+	state []float64 // [position, velocity]
+	rng   *rand.RNG
+
+	// Configuration
+	renderMode string
+
+	// Spaces
+	actionSpace      gym.Space[int]
+	observationSpace gym.Space[[]float64]
+
+	// Metadata
+	metadata gym.Metadata
+
+	// Rendering
+	renderer mountainCarRenderer
+}
+
+// MountainCarConfig holds configuration options for MountainCar environment
+type MountainCarConfig struct {
+	RenderMode   string
+	GoalVelocity float64 // Minimum velocity required at the goal position
+}
+
+// NewMountainCarEnv creates a new MountainCar environment instance.
+//
+// Parameters:
+//   - config: Configuration options for the environment
+//
+// Returns:
+//   - A new MountainCar environment
+//   - An error if initialization fails
+func NewMountainCarEnv(config *MountainCarConfig) (*MountainCarEnv, error) {
+	if config == nil {
+		config = &MountainCarConfig{}
+	}
+
+	if config.RenderMode != "" && !slices.Contains(mountainCarRenderModes, config.RenderMode) {
+		return nil, fmt.Errorf("unsupported render mode %q, expected one of %v", config.RenderMode, mountainCarRenderModes)
+	}
+
+	env := &MountainCarEnv{
+		// Physics parameters matching Python implementation
+		minPosition:  -1.2,
+		maxPosition:  0.6,
+		maxSpeed:     0.07,
+		goalPosition: 0.5,
+		goalVelocity: config.GoalVelocity,
+		force:        0.001,
+		gravity:      0.0025,
+
+		// Configuration
+		renderMode: config.RenderMode,
+
+		// Metadata
+		metadata: gym.Metadata{
+			"render_modes":      slices.Clone(mountainCarRenderModes),
+			"render_fps":        30,
+			"reward_threshold":  -110.0,
+			"max_episode_steps": 200,
+		},
+	}
+
+	// Initialize RNG
+	rng, _, err := rand.NewRNG(0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RNG: %w", err)
+	}
+	env.rng = rng
+
+	// Create action space: Discrete(3) for left/none/right accelerations
+	actionSpace, err := space.NewDiscreteWithRNG(env.rng.Derive(), 3)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create action space: %w", err)
+	}
+	env.actionSpace = actionSpace
+
+	// Create observation space: Box(2) with bounds
+	low := []float64{env.minPosition, -env.maxSpeed}
+	high := []float64{env.maxPosition, env.maxSpeed}
+	observationSpace, err := space.NewBoxWithRNG(env.rng.Derive(), low, high)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create observation space: %w", err)
+	}
+	env.observationSpace = observationSpace
+
+	return env, nil
+}
+
+// Close performs cleanup when the user has finished using the environment.
+func (env *MountainCarEnv) Close() error {
+	env.renderer.close()
+	return nil
+}
+
+// Step runs one timestep of the environment's dynamics using the agent action.
+func (env *MountainCarEnv) Step(ctx context.Context, action int) ([]float64, float64, bool, bool, gym.Info, error) {
+	select {
+	case <-ctx.Done():
+		return nil, 0, false, false, nil, ctx.Err()
+	default:
+	}
+
+	if !env.actionSpace.Contains(action) {
+		return nil, 0, false, false, nil, fmt.Errorf("invalid action %d", action)
+	}
+
+	if env.state == nil {
+		return nil, 0, false, false, nil, fmt.Errorf("call Reset before using Step method")
+	}
+
+	position, velocity := env.state[0], env.state[1]
+
+	velocity += float64(action-1)*env.force - math.Cos(3*position)*env.gravity
+	velocity = math.Max(-env.maxSpeed, math.Min(velocity, env.maxSpeed))
+	position += velocity
+	position = math.Max(env.minPosition, math.Min(position, env.maxPosition))
+	if position == env.minPosition && velocity < 0 {
+		velocity = 0
+	}
+
+	env.state = []float64{position, velocity}
+
+	terminated := position >= env.goalPosition && velocity >= env.goalVelocity
+
+	// Create observation (copy of state)
+	observation := make([]float64, len(env.state))
+	copy(observation, env.state)
+
+	// truncation=false as the time limit is handled by the TimeLimit wrapper
+	return observation, -1.0, terminated, false, gym.Info{}, nil
+}
+
+// Reset resets the environment to an initial internal state, returning an initial observation and info.
+func (env *MountainCarEnv) Reset(ctx context.Context, seed *int64, options gym.Info) ([]float64, gym.Info, error) {
+	select {
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	default:
+	}
+
+	// Seed the RNG if provided
+	if seed != nil {
+		if err := env.rng.SeedExact(*seed); err != nil {
+			return nil, nil, fmt.Errorf("failed to seed RNG: %w", err)
+		}
+	}
+
+	// Parse reset bounds from options
+	low, high := -0.6, -0.4 // default bounds
+	if options != nil {
+		if lowVal, ok := options["low"].(float64); ok {
+			low = lowVal
+		}
+		if highVal, ok := options["high"].(float64); ok {
+			high = highVal
+		}
+	}
+
+	// Initialize position uniformly at random with zero velocity
+	env.state = []float64{low + env.rng.Float64()*(high-low), 0}
+
+	// Create observation (copy of state)
+	observation := make([]float64, len(env.state))
+	copy(observation, env.state)
+
+	return observation, gym.Info{}, nil
+}
+
+// height returns the height of the hill at the given position.
+func (env *MountainCarEnv) height(position float64) float64 {
+	return math.Sin(3*position)*0.45 + 0.55
+}
+
+// Render computes the render frames as specified by the environment's render mode.
+//
+// Rendering requires Ebiten and is unavailable when built with the "headless" build tag.
+func (env *MountainCarEnv) Render() (gym.RenderFrame, error) {
+	if env.renderMode == "" {
+		return nil, fmt.Errorf("no render mode specified")
+	}
+
+	if env.state == nil {
+		return nil, fmt.Errorf("environment state is nil, call Reset first")
+	}
+
+	return env.render()
+}
+
+// ActionSpace returns the Space object corresponding to valid actions.
+func (env *MountainCarEnv) ActionSpace() gym.Space[int] {
+	return env.actionSpace
+}
+
+// ObservationSpace returns the Space object corresponding to valid observations.
+func (env *MountainCarEnv) ObservationSpace() gym.Space[[]float64] {
+	return env.observationSpace
+}
+
+// Metadata returns the metadata of the environment.
+func (env *MountainCarEnv) Metadata() gym.Metadata {
+	return env.metadata
+}
+
+// Unwrapped returns the base non-wrapped environment.
+func (env *MountainCarEnv) Unwrapped() gym.Env[[]float64, int] {
+	return env
+}
+
+// GetRNG returns the environment's random number generator.
+func (env *MountainCarEnv) GetRNG() *rand.RNG {
+	return env.rng
+}
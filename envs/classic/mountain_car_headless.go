@@ -0,0 +1,23 @@
+//go:build headless
+
+package classic
+
+import (
+	"fmt"
+
+	"github.com/gocnn/gym"
+)
+
+// mountainCarRenderModes is empty because rendering is unavailable in headless builds.
+var mountainCarRenderModes = []string{}
+
+// mountainCarRenderer is the rendering state of a MountainCar environment, empty in headless builds.
+type mountainCarRenderer struct{}
+
+// close releases the resources held by the renderer.
+func (r *mountainCarRenderer) close() {}
+
+// render always fails because rendering is unavailable in headless builds.
+func (env *MountainCarEnv) render() (gym.RenderFrame, error) {
+	return nil, fmt.Errorf("render mode %q is unavailable in headless builds", env.renderMode)
+}
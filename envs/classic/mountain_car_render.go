@@ -0,0 +1,186 @@
+//go:build !headless
+
+package classic
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/gocnn/gym"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// mountainCarRenderModes lists the render modes supported by MountainCar when built with Ebiten.
+var mountainCarRenderModes = []string{"human", "rgb_array"}
+
+// mountainCarRenderer holds the Ebiten rendering state of a MountainCar environment.
+type mountainCarRenderer struct {
+	screen *ebiten.Image
+
+	// Auto-rendering support
+	game  *mountainCarGame
+	mutex sync.Mutex
+}
+
+// close releases the images held by the renderer.
+func (r *mountainCarRenderer) close() {
+	if r.screen != nil {
+		r.screen.Dispose()
+		r.screen = nil
+	}
+}
+
+// render draws the current state with Ebiten in the environment's render mode.
+func (env *MountainCarEnv) render() (gym.RenderFrame, error) {
+	r := &env.renderer
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Initialize screen if not already done
+	if r.screen == nil {
+		screenWidth, screenHeight := 600, 400
+		r.screen = ebiten.NewImage(screenWidth, screenHeight)
+	}
+
+	// Clear screen with white background
+	r.screen.Fill(color.RGBA{255, 255, 255, 255})
+
+	// Get screen dimensions
+	bounds := r.screen.Bounds()
+	screenWidth := float64(bounds.Dx())
+	screenHeight := float64(bounds.Dy())
+
+	// Calculate scaling, world coordinates grow upwards from the bottom of the screen
+	worldWidth := env.maxPosition - env.minPosition
+	scale := screenWidth / worldWidth
+	toScreen := func(position, height float64) (float32, float32) {
+		return float32((position - env.minPosition) * scale), float32(screenHeight - height*scale)
+	}
+
+	// MountainCar parameters
+	carwidth, carheight := 40.0, 20.0
+	clearance := 10.0
+
+	// Draw hill (polyline along the height curve)
+	const segments = 100
+	for i := range segments {
+		x0 := env.minPosition + worldWidth*float64(i)/segments
+		x1 := env.minPosition + worldWidth*float64(i+1)/segments
+		sx0, sy0 := toScreen(x0, env.height(x0))
+		sx1, sy1 := toScreen(x1, env.height(x1))
+		vector.StrokeLine(r.screen, sx0, sy0, sx1, sy1, 2, color.RGBA{0, 0, 0, 255}, false)
+	}
+
+	// Calculate car position and rotation along the slope
+	position := env.state[0]
+	angle := math.Cos(3 * position)
+	cx := (position - env.minPosition) * scale
+	cy := screenHeight - (env.height(position)*scale + clearance)
+	rotate := func(x, y float64) (float32, float32) {
+		return float32(cx + x*math.Cos(angle) + y*math.Sin(angle)), float32(cy - x*math.Sin(angle) + y*math.Cos(angle))
+	}
+
+	// Draw car (rotated rectangle)
+	var car vector.Path
+	corners := [][2]float64{{-carwidth / 2, 0}, {-carwidth / 2, -carheight}, {carwidth / 2, -carheight}, {carwidth / 2, 0}}
+	for i, corner := range corners {
+		x, y := rotate(corner[0], corner[1])
+		if i == 0 {
+			car.MoveTo(x, y)
+		} else {
+			car.LineTo(x, y)
+		}
+	}
+	car.Close()
+	carOptions := &vector.DrawPathOptions{}
+	carOptions.ColorScale.ScaleWithColor(color.RGBA{0, 0, 0, 255})
+	vector.FillPath(r.screen, &car, nil, carOptions)
+
+	// Draw wheels (circles)
+	for _, offset := range []float64{carwidth / 4, -carwidth / 4} {
+		wx, wy := rotate(offset, clearance)
+		vector.DrawFilledCircle(r.screen, wx, wy, float32(carheight/2.5), color.RGBA{128, 128, 128, 255}, false)
+	}
+
+	// Draw flag at the goal position
+	flagx, flagy := toScreen(env.goalPosition, env.height(env.goalPosition))
+	vector.StrokeLine(r.screen, flagx, flagy, flagx, flagy-50, 2, color.RGBA{0, 0, 0, 255}, false)
+	var flag vector.Path
+	flag.MoveTo(flagx, flagy-50)
+	flag.LineTo(flagx, flagy-40)
+	flag.LineTo(flagx+25, flagy-45)
+	flag.Close()
+	flagOptions := &vector.DrawPathOptions{}
+	flagOptions.ColorScale.ScaleWithColor(color.RGBA{204, 204, 0, 255})
+	vector.FillPath(r.screen, &flag, nil, flagOptions)
+
+	// Display debug information
+	debugText := "MountainCar Environment\n"
+	debugText += fmt.Sprintf("Position: %.3f\n", env.state[0])
+	debugText += fmt.Sprintf("Velocity: %.4f\n", env.state[1])
+
+	ebitenutil.DebugPrint(r.screen, debugText)
+
+	// Auto-start rendering window for "human" mode
+	if env.renderMode == "human" && r.game == nil {
+		env.startAutoRender()
+	}
+
+	if env.renderMode == "rgb_array" {
+		return toRGBA(r.screen), nil
+	}
+
+	// For "human" mode, return the Ebiten image directly
+	return r.screen, nil
+}
+
+// startAutoRender starts the automatic rendering window in a separate goroutine
+func (env *MountainCarEnv) startAutoRender() {
+	r := &env.renderer
+	r.game = &mountainCarGame{renderer: r}
+
+	go func() {
+		ebiten.SetWindowSize(600, 400)
+		ebiten.SetWindowTitle("MountainCar Environment")
+		ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+
+		// Run the game loop
+		if err := ebiten.RunGame(r.game); err != nil {
+			// Window was closed, clean up
+			r.mutex.Lock()
+			r.game = nil
+			r.mutex.Unlock()
+		}
+	}()
+
+	// Give the window a moment to initialize
+	time.Sleep(100 * time.Millisecond)
+}
+
+// mountainCarGame displays the last rendered MountainCar frame in a window.
+type mountainCarGame struct {
+	renderer *mountainCarRenderer
+}
+
+func (g *mountainCarGame) Update() error {
+	return nil // No game logic needed, just display
+}
+
+func (g *mountainCarGame) Draw(screen *ebiten.Image) {
+	g.renderer.mutex.Lock()
+	defer g.renderer.mutex.Unlock()
+
+	if g.renderer.screen != nil {
+		screen.DrawImage(g.renderer.screen, nil)
+	}
+}
+
+func (g *mountainCarGame) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight int) {
+	return 600, 400
+}
@@ -1 +1,275 @@
 package classic
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/rand"
+	"github.com/gocnn/gym/space"
+)
+
+// PendulumEnv implements the inverted pendulum swingup problem.
+//
+// The system consists of a pendulum attached at one end to a fixed point, and the other end being free.
+// The pendulum starts in a random position and the goal is to apply torque on the free end to swing it
+// into an upright position, with its center of gravity right above the fixed point.
+//
+// ## Action Space
+// The action is a 1-element array representing the torque applied to the free end of the pendulum.
+// | Index | Action | Min  | Max |
+// |-------|--------|------|-----|
+// | 0     | Torque | -2.0 | 2.0 |
+//
+// ## Observation Space
+// The observation is a 3-element array representing the x-y coordinates of the pendulum's free end
+// and its angular velocity:
+// | Index | Observation      | Min  | Max |
+// |-------|------------------|------|-----|
+// | 0     | x = cos(theta)   | -1.0 | 1.0 |
+// | 1     | y = sin(theta)   | -1.0 | 1.0 |
+// | 2     | Angular Velocity | -8.0 | 8.0 |
+//
+// ## Rewards
+// The reward is -(theta² + 0.1 * theta_dot² + 0.001 * torque²), where theta is the pendulum's angle
+// normalized between [-pi, pi] with 0 being in the upright position.
+//
+// ## Episode End
+// The episode never terminates and is truncated at 200 time steps (handled by TimeLimit wrapper).
+type PendulumEnv struct {
+	// Environment parameters
+	maxSpeed  float64
+	maxTorque float64
+	dt        float64
+	g         float64
+	m         float64
+	l         float64
+
+	// State
+	state []float64 // [theta, theta_dot]
+	rng   *rand.RNG
+
+	// Configuration
+	renderMode string
+
+	// Rendering
+	lastTorque *float64 // last applied torque, used for rendering
+	renderer   pendulumRenderer
+
+	// Spaces
+	actionSpace      gym.Space[[]float64]
+	observationSpace gym.Space[[]float64]
+
+	// Metadata
+	metadata gym.Metadata
+}
+
+// PendulumConfig holds configuration options for Pendulum environment
+type PendulumConfig struct {
+	RenderMode string
+	Gravity    float64 // Acceleration of gravity, defaults to 10.0
+}
+
+// NewPendulumEnv creates a new Pendulum environment instance.
+//
+// Parameters:
+//   - config: Configuration options for the environment
+//
+// Returns:
+//   - A new Pendulum environment
+//   - An error if initialization fails
+func NewPendulumEnv(config *PendulumConfig) (*PendulumEnv, error) {
+	if config == nil {
+		config = &PendulumConfig{}
+	}
+
+	if config.RenderMode != "" && !slices.Contains(pendulumRenderModes, config.RenderMode) {
+		return nil, fmt.Errorf("unsupported render mode %q, expected one of %v", config.RenderMode, pendulumRenderModes)
+	}
+
+	if config.Gravity < 0 {
+		return nil, fmt.Errorf("gravity must be non-negative, got %f", config.Gravity)
+	}
+
+	gravity := config.Gravity
+	if gravity == 0 {
+		gravity = 10.0
+	}
+
+	env := &PendulumEnv{
+		// Physics parameters matching Python implementation
+		maxSpeed:  8,
+		maxTorque: 2.0,
+		dt:        0.05,
+		g:         gravity,
+		m:         1.0,
+		l:         1.0,
+
+		// Configuration
+		renderMode: config.RenderMode,
+
+		// Metadata
+		metadata: gym.Metadata{
+			"render_modes":      slices.Clone(pendulumRenderModes),
+			"render_fps":        30,
+			"max_episode_steps": 200,
+		},
+	}
+
+	// Initialize RNG
+	rng, _, err := rand.NewRNG(0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RNG: %w", err)
+	}
+	env.rng = rng
+
+	// Create action space: Box(1) for the torque
+	actionSpace, err := space.NewBoxWithRNG(env.rng.Derive(), []float64{-env.maxTorque}, []float64{env.maxTorque})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create action space: %w", err)
+	}
+	env.actionSpace = actionSpace
+
+	// Create observation space: Box(3) with bounds
+	high := []float64{1.0, 1.0, env.maxSpeed}
+	low := []float64{-1.0, -1.0, -env.maxSpeed}
+	observationSpace, err := space.NewBoxWithRNG(env.rng.Derive(), low, high)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create observation space: %w", err)
+	}
+	env.observationSpace = observationSpace
+
+	return env, nil
+}
+
+// Close performs cleanup when the user has finished using the environment.
+func (env *PendulumEnv) Close() error {
+	env.renderer.close()
+	return nil
+}
+
+// Step runs one timestep of the environment's dynamics using the agent action.
+//
+// The torque is clipped to the bounds of the action space.
+func (env *PendulumEnv) Step(ctx context.Context, action []float64) ([]float64, float64, bool, bool, gym.Info, error) {
+	select {
+	case <-ctx.Done():
+		return nil, 0, false, false, nil, ctx.Err()
+	default:
+	}
+
+	if len(action) != 1 || math.IsNaN(action[0]) {
+		return nil, 0, false, false, nil, fmt.Errorf("invalid action %v", action)
+	}
+
+	if env.state == nil {
+		return nil, 0, false, false, nil, fmt.Errorf("call Reset before using Step method")
+	}
+
+	th, thdot := env.state[0], env.state[1]
+
+	u := math.Max(-env.maxTorque, math.Min(action[0], env.maxTorque))
+	env.lastTorque = &u
+
+	costs := angleNormalize(th)*angleNormalize(th) + 0.1*thdot*thdot + 0.001*u*u
+
+	newthdot := thdot + (3*env.g/(2*env.l)*math.Sin(th)+3.0/(env.m*env.l*env.l)*u)*env.dt
+	newthdot = math.Max(-env.maxSpeed, math.Min(newthdot, env.maxSpeed))
+	newth := th + newthdot*env.dt
+
+	env.state = []float64{newth, newthdot}
+
+	// terminated=false as the pendulum never terminates, truncation is handled by the TimeLimit wrapper
+	return env.observation(), -costs, false, false, gym.Info{}, nil
+}
+
+// angleNormalize wraps an angle in radians to [-pi, pi).
+func angleNormalize(x float64) float64 {
+	return math.Mod(math.Mod(x+math.Pi, 2*math.Pi)+2*math.Pi, 2*math.Pi) - math.Pi
+}
+
+// observation returns the observation corresponding to the current state.
+func (env *PendulumEnv) observation() []float64 {
+	theta, thetaDot := env.state[0], env.state[1]
+	return []float64{math.Cos(theta), math.Sin(theta), thetaDot}
+}
+
+// Reset resets the environment to an initial internal state, returning an initial observation and info.
+func (env *PendulumEnv) Reset(ctx context.Context, seed *int64, options gym.Info) ([]float64, gym.Info, error) {
+	select {
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	default:
+	}
+
+	// Seed the RNG if provided
+	if seed != nil {
+		if err := env.rng.SeedExact(*seed); err != nil {
+			return nil, nil, fmt.Errorf("failed to seed RNG: %w", err)
+		}
+	}
+
+	// Parse reset bounds of the angle and angular velocity from options
+	xInit, yInit := math.Pi, 1.0 // default bounds
+	if options != nil {
+		if xVal, ok := options["x_init"].(float64); ok {
+			xInit = xVal
+		}
+		if yVal, ok := options["y_init"].(float64); ok {
+			yInit = yVal
+		}
+	}
+
+	// Initialize state with uniform random values in [-high, high]
+	high := []float64{xInit, yInit}
+	env.state = make([]float64, 2)
+	for i := range env.state {
+		env.state[i] = -high[i] + env.rng.Float64()*2*high[i]
+	}
+
+	env.lastTorque = nil
+
+	return env.observation(), gym.Info{}, nil
+}
+
+// Render computes the render frames as specified by the environment's render mode.
+//
+// Rendering requires Ebiten and is unavailable when built with the "headless" build tag.
+func (env *PendulumEnv) Render() (gym.RenderFrame, error) {
+	if env.renderMode == "" {
+		return nil, fmt.Errorf("no render mode specified")
+	}
+
+	if env.state == nil {
+		return nil, fmt.Errorf("environment state is nil, call Reset first")
+	}
+
+	return env.render()
+}
+
+// ActionSpace returns the Space object corresponding to valid actions.
+func (env *PendulumEnv) ActionSpace() gym.Space[[]float64] {
+	return env.actionSpace
+}
+
+// ObservationSpace returns the Space object corresponding to valid observations.
+func (env *PendulumEnv) ObservationSpace() gym.Space[[]float64] {
+	return env.observationSpace
+}
+
+// Metadata returns the metadata of the environment.
+func (env *PendulumEnv) Metadata() gym.Metadata {
+	return env.metadata
+}
+
+// Unwrapped returns the base non-wrapped environment.
+func (env *PendulumEnv) Unwrapped() gym.Env[[]float64, []float64] {
+	return env
+}
+
+// GetRNG returns the environment's random number generator.
+func (env *PendulumEnv) GetRNG() *rand.RNG {
+	return env.rng
+}
@@ -0,0 +1,23 @@
+//go:build headless
+
+package classic
+
+import (
+	"fmt"
+
+	"github.com/gocnn/gym"
+)
+
+// pendulumRenderModes is empty because rendering is unavailable in headless builds.
+var pendulumRenderModes = []string{}
+
+// pendulumRenderer is the rendering state of a Pendulum environment, empty in headless builds.
+type pendulumRenderer struct{}
+
+// close releases the resources held by the renderer.
+func (r *pendulumRenderer) close() {}
+
+// render always fails because rendering is unavailable in headless builds.
+func (env *PendulumEnv) render() (gym.RenderFrame, error) {
+	return nil, fmt.Errorf("render mode %q is unavailable in headless builds", env.renderMode)
+}
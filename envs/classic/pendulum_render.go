@@ -0,0 +1,147 @@
+//go:build !headless
+
+package classic
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/gocnn/gym"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// pendulumRenderModes lists the render modes supported by Pendulum when built with Ebiten.
+var pendulumRenderModes = []string{"human", "rgb_array"}
+
+// pendulumRenderer holds the Ebiten rendering state of a Pendulum environment.
+type pendulumRenderer struct {
+	screen *ebiten.Image
+
+	// Auto-rendering support
+	game  *pendulumGame
+	mutex sync.Mutex
+}
+
+// close releases the images held by the renderer.
+func (r *pendulumRenderer) close() {
+	if r.screen != nil {
+		r.screen.Dispose()
+		r.screen = nil
+	}
+}
+
+// render draws the current state with Ebiten in the environment's render mode.
+func (env *PendulumEnv) render() (gym.RenderFrame, error) {
+	r := &env.renderer
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Initialize screen if not already done
+	if r.screen == nil {
+		screenDim := 500
+		r.screen = ebiten.NewImage(screenDim, screenDim)
+	}
+
+	// Clear screen with white background
+	r.screen.Fill(color.RGBA{255, 255, 255, 255})
+
+	// Get screen dimensions
+	bounds := r.screen.Bounds()
+	screenDim := float64(bounds.Dx())
+
+	// Calculate scaling and positions, the pivot is at the center of the screen
+	bound := 2.2
+	scale := screenDim / (bound * 2)
+	offset := screenDim / 2
+
+	// Pendulum parameters
+	rodLength := env.l * scale
+	rodWidth := 0.2 * scale
+
+	// Calculate rod end position, theta = 0 points upwards
+	theta := env.state[0]
+	rodEndX := offset - math.Sin(theta)*rodLength
+	rodEndY := offset - math.Cos(theta)*rodLength
+
+	// Draw rod (line with thickness) with rounded ends
+	rodColor := color.RGBA{204, 77, 77, 255}
+	vector.StrokeLine(r.screen, float32(offset), float32(offset), float32(rodEndX), float32(rodEndY), float32(rodWidth), rodColor, true)
+	vector.DrawFilledCircle(r.screen, float32(offset), float32(offset), float32(rodWidth/2), rodColor, true)
+	vector.DrawFilledCircle(r.screen, float32(rodEndX), float32(rodEndY), float32(rodWidth/2), rodColor, true)
+
+	// Draw axle (circle)
+	vector.DrawFilledCircle(r.screen, float32(offset), float32(offset), float32(0.05*scale), color.RGBA{0, 0, 0, 255}, true)
+
+	// Display debug information
+	debugText := "Pendulum Environment\n"
+	debugText += fmt.Sprintf("Angle: %.2f rad (%.1f°)\n", angleNormalize(theta), angleNormalize(theta)*180/math.Pi)
+	debugText += fmt.Sprintf("Angular Vel: %.2f\n", env.state[1])
+	if env.lastTorque != nil {
+		debugText += fmt.Sprintf("Torque: %.2f\n", *env.lastTorque)
+	}
+
+	ebitenutil.DebugPrint(r.screen, debugText)
+
+	// Auto-start rendering window for "human" mode
+	if env.renderMode == "human" && r.game == nil {
+		env.startAutoRender()
+	}
+
+	if env.renderMode == "rgb_array" {
+		return toRGBA(r.screen), nil
+	}
+
+	// For "human" mode, return the Ebiten image directly
+	return r.screen, nil
+}
+
+// startAutoRender starts the automatic rendering window in a separate goroutine
+func (env *PendulumEnv) startAutoRender() {
+	r := &env.renderer
+	r.game = &pendulumGame{renderer: r}
+
+	go func() {
+		ebiten.SetWindowSize(500, 500)
+		ebiten.SetWindowTitle("Pendulum Environment")
+		ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+
+		// Run the game loop
+		if err := ebiten.RunGame(r.game); err != nil {
+			// Window was closed, clean up
+			r.mutex.Lock()
+			r.game = nil
+			r.mutex.Unlock()
+		}
+	}()
+
+	// Give the window a moment to initialize
+	time.Sleep(100 * time.Millisecond)
+}
+
+// pendulumGame displays the last rendered Pendulum frame in a window.
+type pendulumGame struct {
+	renderer *pendulumRenderer
+}
+
+func (g *pendulumGame) Update() error {
+	return nil // No game logic needed, just display
+}
+
+func (g *pendulumGame) Draw(screen *ebiten.Image) {
+	g.renderer.mutex.Lock()
+	defer g.renderer.mutex.Unlock()
+
+	if g.renderer.screen != nil {
+		screen.DrawImage(g.renderer.screen, nil)
+	}
+}
+
+func (g *pendulumGame) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight int) {
+	return 500, 500
+}
@@ -0,0 +1,220 @@
+package classic
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/gocnn/gym"
+)
+
+func init() {
+	gym.Register("CartPole-v0", makeCartPole,
+		gym.WithMaxEpisodeSteps[[]float64, int](200),
+		gym.WithRewardThreshold[[]float64, int](195.0),
+	)
+	gym.Register("CartPole-v1", makeCartPole,
+		gym.WithMaxEpisodeSteps[[]float64, int](500),
+		gym.WithRewardThreshold[[]float64, int](475.0),
+	)
+	gym.Register("CartPoleContinuous-v0", makeContinuousCartPole,
+		gym.WithMaxEpisodeSteps[[]float64, []float64](500),
+		gym.WithRewardThreshold[[]float64, []float64](475.0),
+	)
+	gym.Register("MountainCar-v0", makeMountainCar,
+		gym.WithMaxEpisodeSteps[[]float64, int](200),
+		gym.WithRewardThreshold[[]float64, int](-110.0),
+	)
+	gym.Register("MountainCarContinuous-v0", makeContinuousMountainCar,
+		gym.WithMaxEpisodeSteps[[]float64, []float64](999),
+		gym.WithRewardThreshold[[]float64, []float64](90.0),
+	)
+	gym.Register("Pendulum-v1", makePendulum,
+		gym.WithMaxEpisodeSteps[[]float64, []float64](200),
+	)
+}
+
+// makeCartPole creates a CartPole environment from keyword arguments.
+//
+// Supported keyword arguments are "sutton_barto_reward" (bool), "render_mode" (string),
+// "cart_friction" (float64), "pole_friction" (float64), "gravity" (float64), "masscart" (float64),
+// "masspole" (float64), "length" (float64), "force_mag" (float64), "tau" (float64), "kinematics_integrator" (string),
+// "frame_skip" (int), "random_start_steps" (int), "screen_width" (int) and "screen_height" (int). The int
+// arguments may also be integral float64 values, as decoded from the kwargs of a JSON spec.
+func makeCartPole(kwargs map[string]any) (gym.Env[[]float64, int], error) {
+	config, err := cartPoleConfig(kwargs)
+	if err != nil {
+		return nil, err
+	}
+	return NewCartPoleEnv(config)
+}
+
+// makeContinuousCartPole creates a continuous CartPole environment from keyword arguments.
+//
+// Supported keyword arguments are the same as for makeCartPole.
+func makeContinuousCartPole(kwargs map[string]any) (gym.Env[[]float64, []float64], error) {
+	config, err := cartPoleConfig(kwargs)
+	if err != nil {
+		return nil, err
+	}
+	return NewContinuousCartPoleEnv(config)
+}
+
+// cartPoleConfig parses the keyword arguments of the CartPole environments.
+func cartPoleConfig(kwargs map[string]any) (*CartPoleConfig, error) {
+	config := &CartPoleConfig{}
+	for key, val := range kwargs {
+		switch key {
+		case "sutton_barto_reward":
+			v, ok := val.(bool)
+			if !ok {
+				return nil, fmt.Errorf("sutton_barto_reward must be bool, got %T", val)
+			}
+			config.SuttonBartoReward = v
+		case "render_mode":
+			v, ok := val.(string)
+			if !ok {
+				return nil, fmt.Errorf("render_mode must be string, got %T", val)
+			}
+			config.RenderMode = v
+		case "cart_friction", "pole_friction", "gravity", "masscart", "masspole", "length", "force_mag", "tau":
+			v, ok := val.(float64)
+			if !ok {
+				return nil, fmt.Errorf("%s must be float64, got %T", key, val)
+			}
+			switch key {
+			case "cart_friction":
+				config.CartFriction = v
+			case "pole_friction":
+				config.PoleFriction = v
+			case "gravity":
+				config.Gravity = v
+			case "masscart":
+				config.MassCart = v
+			case "masspole":
+				config.MassPole = v
+			case "length":
+				config.PoleLength = v
+			case "force_mag":
+				config.ForceMag = v
+			case "tau":
+				config.Tau = v
+			}
+		case "kinematics_integrator":
+			v, ok := val.(string)
+			if !ok {
+				return nil, fmt.Errorf("kinematics_integrator must be string, got %T", val)
+			}
+			config.KinematicsIntegrator = v
+		case "frame_skip", "random_start_steps":
+			v, err := intArg(key, val)
+			if err != nil {
+				return nil, err
+			}
+			if key == "frame_skip" {
+				config.FrameSkip = v
+			} else {
+				config.RandomStartSteps = v
+			}
+		case "screen_width", "screen_height":
+			v, err := intArg(key, val)
+			if err != nil {
+				return nil, err
+			}
+			if key == "screen_width" {
+				config.ScreenWidth = v
+			} else {
+				config.ScreenHeight = v
+			}
+		default:
+			return nil, fmt.Errorf("unknown keyword argument: %s", key)
+		}
+	}
+	return config, nil
+}
+
+// intArg returns the value of an int keyword argument, which may be an int or an integral float64.
+func intArg(key string, val any) (int, error) {
+	switch v := val.(type) {
+	case int:
+		return v, nil
+	case float64:
+		if v != math.Trunc(v) || math.Abs(v) > 1<<53 {
+			return 0, fmt.Errorf("%s must be an integer, got %v", key, v)
+		}
+		return int(v), nil
+	default:
+		return 0, fmt.Errorf("%s must be int, got %T", key, val)
+	}
+}
+
+// makeMountainCar creates a MountainCar environment from keyword arguments.
+//
+// Supported keyword arguments are "render_mode" (string) and "goal_velocity" (float64).
+func makeMountainCar(kwargs map[string]any) (gym.Env[[]float64, int], error) {
+	config, err := mountainCarConfig(kwargs)
+	if err != nil {
+		return nil, err
+	}
+	return NewMountainCarEnv(config)
+}
+
+// makeContinuousMountainCar creates a continuous MountainCar environment from keyword arguments.
+//
+// Supported keyword arguments are the same as for makeMountainCar.
+func makeContinuousMountainCar(kwargs map[string]any) (gym.Env[[]float64, []float64], error) {
+	config, err := mountainCarConfig(kwargs)
+	if err != nil {
+		return nil, err
+	}
+	return NewContinuousMountainCarEnv(config)
+}
+
+// mountainCarConfig parses the keyword arguments of the MountainCar environments.
+func mountainCarConfig(kwargs map[string]any) (*MountainCarConfig, error) {
+	config := &MountainCarConfig{}
+	for key, val := range kwargs {
+		switch key {
+		case "render_mode":
+			v, ok := val.(string)
+			if !ok {
+				return nil, fmt.Errorf("render_mode must be string, got %T", val)
+			}
+			config.RenderMode = v
+		case "goal_velocity":
+			v, ok := val.(float64)
+			if !ok {
+				return nil, fmt.Errorf("goal_velocity must be float64, got %T", val)
+			}
+			config.GoalVelocity = v
+		default:
+			return nil, fmt.Errorf("unknown keyword argument: %s", key)
+		}
+	}
+	return config, nil
+}
+
+// makePendulum creates a Pendulum environment from keyword arguments.
+//
+// Supported keyword arguments are "render_mode" (string) and "g" (float64).
+func makePendulum(kwargs map[string]any) (gym.Env[[]float64, []float64], error) {
+	config := &PendulumConfig{}
+	for key, val := range kwargs {
+		switch key {
+		case "render_mode":
+			v, ok := val.(string)
+			if !ok {
+				return nil, fmt.Errorf("render_mode must be string, got %T", val)
+			}
+			config.RenderMode = v
+		case "g":
+			v, ok := val.(float64)
+			if !ok {
+				return nil, fmt.Errorf("g must be float64, got %T", val)
+			}
+			config.Gravity = v
+		default:
+			return nil, fmt.Errorf("unknown keyword argument: %s", key)
+		}
+	}
+	return NewPendulumEnv(config)
+}
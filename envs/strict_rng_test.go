@@ -0,0 +1,34 @@
+package envs_test
+
+import (
+	"testing"
+
+	"github.com/gocnn/gym"
+	_ "github.com/gocnn/gym/envs/classic"
+	_ "github.com/gocnn/gym/envs/toy"
+	"github.com/gocnn/gym/rand"
+)
+
+// TestMakeRegisteredWithoutDefaultRNG checks that every registered environment builds its spaces from its
+// own RNG, so it can be made when the default RNG is disabled, as with the strictrng build tag.
+func TestMakeRegisteredWithoutDefaultRNG(t *testing.T) {
+	if !rand.DefaultRNGDisabled() {
+		rand.DisableDefaultRNG()
+		t.Cleanup(rand.EnableDefaultRNG)
+	}
+
+	ids := gym.ListRegistered()
+	if len(ids) == 0 {
+		t.Fatal("no environments are registered")
+	}
+	for _, id := range ids {
+		env, err := gym.MakeAny(id, nil)
+		if err != nil {
+			t.Errorf("MakeAny(%q) failed: %v", id, err)
+			continue
+		}
+		if closer, ok := env.(interface{ Close() error }); ok {
+			closer.Close()
+		}
+	}
+}
@@ -0,0 +1,328 @@
+package toy
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/rand"
+	"github.com/gocnn/gym/space"
+)
+
+// gridWorldDefaultMap is the layout used when GridWorldConfig.Map is empty.
+const gridWorldDefaultMap = `
+S..#
+.#..
+...#
+#..G`
+
+// gridWorldActionNames are the names of the actions, indexed by action.
+var gridWorldActionNames = []string{"Left", "Down", "Right", "Up"}
+
+// gridWorldMoves are the row and column offsets of the actions, indexed by action.
+var gridWorldMoves = [][2]int{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}
+
+// GridWorldEnv implements a deterministic grid world with a layout given as an ASCII map.
+//
+// The map has one string per row, using "#" for walls, "S" for the start, "G" for the goal and "." for free
+// cells, e.g.
+//
+//	S..#
+//	.#..
+//	...#
+//	#..G
+//
+// The agent starts on "S" and has to reach "G". Moves into walls or off the grid leave the agent in place.
+//
+// ## Action Space
+// The action is an integer which can take values {0, 1, 2, 3}:
+// - 0: Move left
+// - 1: Move down
+// - 2: Move right
+// - 3: Move up
+//
+// ## Observation Space
+// The observation is an integer in [0, n) where n is the number of non-wall cells, numbering them in row-major
+// order. Use Position to convert an observation to the row and column of its cell.
+//
+// ## Rewards
+// - GoalReward (defaults to 1) for reaching the goal
+// - -StepPenalty (defaults to 0) for every other step
+//
+// ## Episode End
+// The episode ends if any one of the following occurs:
+// 1. Termination: The agent reaches the goal
+// 2. Truncation: Episode length is greater than 100 (handled by TimeLimit wrapper)
+type GridWorldEnv struct {
+	// Layout
+	grid  []string // Rows of the map
+	cells [][2]int // Row and column of each non-wall cell, indexed by state
+	start int      // State of the start cell
+	goal  int      // State of the goal cell
+
+	// Rewards
+	stepPenalty float64
+	goalReward  float64
+
+	// State
+	state      int
+	lastAction *int
+	reset      bool
+	rng        *rand.RNG
+
+	// Configuration
+	renderMode string
+
+	// Spaces
+	actionSpace      gym.Space[int]
+	observationSpace gym.Space[int]
+
+	// Metadata
+	metadata gym.Metadata
+}
+
+// GridWorldConfig holds configuration options for GridWorld environment
+type GridWorldConfig struct {
+	Map         string  // Layout with one row per line, surrounding whitespace ignored (defaults to a 4x4 map)
+	StepPenalty float64 // Penalty subtracted on every step not reaching the goal (defaults to 0)
+	GoalReward  float64 // Reward for reaching the goal (defaults to 1)
+	RenderMode  string
+}
+
+// gridWorldRenderModes lists the render modes supported by GridWorld.
+var gridWorldRenderModes = []string{"ansi"}
+
+// NewGridWorldEnv creates a new GridWorld environment instance.
+//
+// Parameters:
+//   - config: Configuration options for the environment
+//
+// Returns:
+//   - A new GridWorld environment
+//   - An error if the map is invalid or initialization fails
+func NewGridWorldEnv(config *GridWorldConfig) (*GridWorldEnv, error) {
+	if config == nil {
+		config = &GridWorldConfig{}
+	}
+
+	if config.RenderMode != "" && !slices.Contains(gridWorldRenderModes, config.RenderMode) {
+		return nil, fmt.Errorf("unsupported render mode %q, expected one of %v", config.RenderMode, gridWorldRenderModes)
+	}
+
+	if config.StepPenalty < 0 {
+		return nil, fmt.Errorf("step penalty must be non-negative, got %f", config.StepPenalty)
+	}
+
+	env := &GridWorldEnv{
+		stepPenalty: config.StepPenalty,
+		goalReward:  cmp.Or(config.GoalReward, 1.0),
+
+		// Configuration
+		renderMode: config.RenderMode,
+
+		// Metadata
+		metadata: gym.Metadata{
+			"render_modes":      slices.Clone(gridWorldRenderModes),
+			"render_fps":        4,
+			"max_episode_steps": 100,
+		},
+	}
+
+	if err := env.parseMap(cmp.Or(config.Map, gridWorldDefaultMap)); err != nil {
+		return nil, err
+	}
+
+	// Initialize RNG
+	rng, _, err := rand.NewRNG(0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RNG: %w", err)
+	}
+	env.rng = rng
+
+	// Create action space: Discrete(4) for the moves
+	actionSpace, err := space.NewDiscreteWithRNG(env.rng.Derive(), len(gridWorldActionNames))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create action space: %w", err)
+	}
+	env.actionSpace = actionSpace
+
+	// Create observation space: Discrete(n) for the non-wall cells
+	observationSpace, err := space.NewDiscreteWithRNG(env.rng.Derive(), len(env.cells))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create observation space: %w", err)
+	}
+	env.observationSpace = observationSpace
+
+	return env, nil
+}
+
+// parseMap validates the layout and numbers its non-wall cells.
+func (env *GridWorldEnv) parseMap(layout string) error {
+	var grid []string
+	for line := range strings.SplitSeq(strings.TrimSpace(layout), "\n") {
+		grid = append(grid, strings.TrimSpace(line))
+	}
+
+	width := len(grid[0])
+	if width == 0 {
+		return fmt.Errorf("map must not be empty")
+	}
+
+	starts, goals := 0, 0
+	for row, line := range grid {
+		if len(line) != width {
+			return fmt.Errorf("map must be rectangular, row %d has %d cells instead of %d", row, len(line), width)
+		}
+
+		for col, cell := range line {
+			switch cell {
+			case '#':
+				continue
+			case 'S':
+				env.start = len(env.cells)
+				starts++
+			case 'G':
+				env.goal = len(env.cells)
+				goals++
+			case '.':
+			default:
+				return fmt.Errorf("invalid map cell %q at row %d, column %d, expected one of \"#SG.\"", cell, row, col)
+			}
+			env.cells = append(env.cells, [2]int{row, col})
+		}
+	}
+
+	if starts != 1 || goals != 1 {
+		return fmt.Errorf("map must have exactly one start and one goal, got %d and %d", starts, goals)
+	}
+
+	env.grid = grid
+	return nil
+}
+
+// Position returns the row and column of the cell of a state.
+func (env *GridWorldEnv) Position(state int) (row, col int) {
+	return env.cells[state][0], env.cells[state][1]
+}
+
+// Close performs cleanup when the user has finished using the environment.
+func (env *GridWorldEnv) Close() error {
+	return nil
+}
+
+// Step runs one timestep of the environment's dynamics using the agent action.
+func (env *GridWorldEnv) Step(ctx context.Context, action int) (int, float64, bool, bool, gym.Info, error) {
+	select {
+	case <-ctx.Done():
+		return 0, 0, false, false, nil, ctx.Err()
+	default:
+	}
+
+	if !env.actionSpace.Contains(action) {
+		return 0, 0, false, false, nil, fmt.Errorf("invalid action %d", action)
+	}
+
+	if !env.reset {
+		return 0, 0, false, false, nil, fmt.Errorf("call Reset before using Step method")
+	}
+
+	row, col := env.Position(env.state)
+	row += gridWorldMoves[action][0]
+	col += gridWorldMoves[action][1]
+	if next := slices.Index(env.cells, [2]int{row, col}); next >= 0 {
+		env.state = next
+	}
+	env.lastAction = &action
+
+	terminated := env.state == env.goal
+	reward := -env.stepPenalty
+	if terminated {
+		reward = env.goalReward
+	}
+
+	// truncation=false as the time limit is handled by the TimeLimit wrapper
+	return env.state, reward, terminated, false, gym.Info{"prob": 1.0}, nil
+}
+
+// Reset resets the environment to an initial internal state, returning an initial observation and info.
+func (env *GridWorldEnv) Reset(ctx context.Context, seed *int64, options gym.Info) (int, gym.Info, error) {
+	select {
+	case <-ctx.Done():
+		return 0, nil, ctx.Err()
+	default:
+	}
+
+	// Seed the RNG if provided
+	if seed != nil {
+		if err := env.rng.SeedExact(*seed); err != nil {
+			return 0, nil, fmt.Errorf("failed to seed RNG: %w", err)
+		}
+	}
+
+	env.state = env.start
+	env.lastAction = nil
+	env.reset = true
+
+	return env.state, gym.Info{"prob": 1.0}, nil
+}
+
+// Render computes the render frames as specified by the environment's render mode.
+func (env *GridWorldEnv) Render() (gym.RenderFrame, error) {
+	if env.renderMode == "" {
+		return nil, fmt.Errorf("no render mode specified")
+	}
+
+	if !env.reset {
+		return nil, fmt.Errorf("environment state is nil, call Reset first")
+	}
+
+	return env.renderANSI(), nil
+}
+
+// renderANSI draws the map with the agent highlighted using ANSI escape codes.
+func (env *GridWorldEnv) renderANSI() string {
+	row, col := env.Position(env.state)
+
+	var sb strings.Builder
+	for i, line := range env.grid {
+		if i == row {
+			line = line[:col] + colorize(line[col:col+1], 31, false, true) + line[col+1:]
+		}
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	if env.lastAction != nil {
+		fmt.Fprintf(&sb, "  (%s)\n", gridWorldActionNames[*env.lastAction])
+	} else {
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// ActionSpace returns the Space object corresponding to valid actions.
+func (env *GridWorldEnv) ActionSpace() gym.Space[int] {
+	return env.actionSpace
+}
+
+// ObservationSpace returns the Space object corresponding to valid observations.
+func (env *GridWorldEnv) ObservationSpace() gym.Space[int] {
+	return env.observationSpace
+}
+
+// Metadata returns the metadata of the environment.
+func (env *GridWorldEnv) Metadata() gym.Metadata {
+	return env.metadata
+}
+
+// Unwrapped returns the base non-wrapped environment.
+func (env *GridWorldEnv) Unwrapped() gym.Env[int, int] {
+	return env
+}
+
+// GetRNG returns the environment's random number generator.
+func (env *GridWorldEnv) GetRNG() *rand.RNG {
+	return env.rng
+}
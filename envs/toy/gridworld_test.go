@@ -0,0 +1,117 @@
+package toy_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gocnn/gym/envs/toy"
+)
+
+func TestGridWorldInvalidMap(t *testing.T) {
+	invalid := map[string]string{
+		"two starts":    "S.G\n..S",
+		"no start":      "..G\n...",
+		"two goals":     "S.G\nG..",
+		"no goal":       "S..\n...",
+		"not rectangle": "S.G\n..",
+		"unknown cell":  "S.G\n.x.",
+		"empty":         " \n ",
+	}
+	for name, layout := range invalid {
+		if _, err := toy.NewGridWorldEnv(&toy.GridWorldConfig{Map: layout}); err == nil {
+			t.Errorf("NewGridWorldEnv with a map with %s succeeded, expected an error", name)
+		}
+	}
+
+	if _, err := toy.NewGridWorldEnv(&toy.GridWorldConfig{StepPenalty: -1}); err == nil {
+		t.Error("NewGridWorldEnv with a negative step penalty succeeded, expected an error")
+	}
+	if _, err := toy.NewGridWorldEnv(&toy.GridWorldConfig{RenderMode: "human"}); err == nil {
+		t.Error("NewGridWorldEnv with render mode human succeeded, expected an error")
+	}
+}
+
+func TestGridWorldSolvablePath(t *testing.T) {
+	env, err := toy.NewGridWorldEnv(&toy.GridWorldConfig{StepPenalty: 0.1, RenderMode: "ansi"})
+	if err != nil {
+		t.Fatalf("NewGridWorldEnv failed: %v", err)
+	}
+	defer env.Close()
+
+	ctx := context.Background()
+	seed := int64(1)
+	state, _, err := env.Reset(ctx, &seed, nil)
+	if err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	if row, col := env.Position(state); row != 0 || col != 0 {
+		t.Fatalf("agent started at (%d, %d), want the start (0, 0)", row, col)
+	}
+
+	// Moving left or up from the start is blocked by the edge of the grid
+	for _, action := range []int{0, 3} {
+		next, reward, terminated, _, _, err := env.Step(ctx, action)
+		if err != nil {
+			t.Fatalf("Step failed: %v", err)
+		}
+		if next != state || reward != -0.1 || terminated {
+			t.Errorf("blocked move %d = (%d, %v, %t), want (%d, -0.1, false)", action, next, reward, terminated, state)
+		}
+	}
+
+	// Down, down, right, right, down and right lead around the walls of the default map to the goal
+	path := []int{1, 1, 2, 2, 1, 2}
+	for i, action := range path {
+		state, reward, terminated, truncated, _, err := env.Step(ctx, action)
+		if err != nil {
+			t.Fatalf("Step failed: %v", err)
+		}
+		if truncated {
+			t.Fatalf("step %d was truncated", i)
+		}
+		if i < len(path)-1 {
+			if terminated || reward != -0.1 {
+				t.Errorf("step %d = (%v, %t), want (-0.1, false)", i, reward, terminated)
+			}
+			continue
+		}
+
+		if !terminated || reward != 1 {
+			t.Errorf("last step = (%v, %t), want the goal reward 1 and termination", reward, terminated)
+		}
+		if row, col := env.Position(state); row != 3 || col != 3 {
+			t.Errorf("agent ended at (%d, %d), want the goal (3, 3)", row, col)
+		}
+	}
+
+	frame, err := env.Render()
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if text, ok := frame.(string); !ok || !strings.Contains(text, "(Right)") {
+		t.Errorf("Render() = %q, want an ANSI frame naming the last action", frame)
+	}
+}
+
+func TestGridWorldObservationSpace(t *testing.T) {
+	env, err := toy.NewGridWorldEnv(&toy.GridWorldConfig{Map: "S#\n.G"})
+	if err != nil {
+		t.Fatalf("NewGridWorldEnv failed: %v", err)
+	}
+	defer env.Close()
+
+	// One state per non-wall cell
+	for state := range 3 {
+		if !env.ObservationSpace().Contains(state) {
+			t.Errorf("state %d is not in the observation space", state)
+		}
+	}
+	if env.ObservationSpace().Contains(3) {
+		t.Error("state 3 is in the observation space of a map with 3 free cells")
+	}
+
+	if _, _, _, _, _, err := env.Step(context.Background(), 1); err == nil {
+		t.Error("Step before Reset succeeded, expected an error")
+	}
+}
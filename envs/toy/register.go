@@ -0,0 +1,72 @@
+package toy
+
+import (
+	"fmt"
+
+	"github.com/gocnn/gym"
+)
+
+func init() {
+	gym.Register("Taxi-v3", makeTaxi,
+		gym.WithMaxEpisodeSteps[int, int](200),
+		gym.WithRewardThreshold[int, int](8.0),
+	)
+	gym.Register("GridWorld-v0", makeGridWorld,
+		gym.WithMaxEpisodeSteps[int, int](100),
+	)
+}
+
+// makeTaxi creates a Taxi environment from keyword arguments.
+//
+// Supported keyword arguments are "render_mode" (string).
+func makeTaxi(kwargs map[string]any) (gym.Env[int, int], error) {
+	config := &TaxiConfig{}
+	for key, val := range kwargs {
+		switch key {
+		case "render_mode":
+			v, ok := val.(string)
+			if !ok {
+				return nil, fmt.Errorf("render_mode must be string, got %T", val)
+			}
+			config.RenderMode = v
+		default:
+			return nil, fmt.Errorf("unknown keyword argument: %s", key)
+		}
+	}
+	return NewTaxiEnv(config)
+}
+
+// makeGridWorld creates a GridWorld environment from keyword arguments.
+//
+// Supported keyword arguments are "render_mode" (string), "map" (string), "step_penalty" (float64)
+// and "goal_reward" (float64).
+func makeGridWorld(kwargs map[string]any) (gym.Env[int, int], error) {
+	config := &GridWorldConfig{}
+	for key, val := range kwargs {
+		switch key {
+		case "render_mode", "map":
+			v, ok := val.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s must be string, got %T", key, val)
+			}
+			if key == "render_mode" {
+				config.RenderMode = v
+			} else {
+				config.Map = v
+			}
+		case "step_penalty", "goal_reward":
+			v, ok := val.(float64)
+			if !ok {
+				return nil, fmt.Errorf("%s must be float64, got %T", key, val)
+			}
+			if key == "step_penalty" {
+				config.StepPenalty = v
+			} else {
+				config.GoalReward = v
+			}
+		default:
+			return nil, fmt.Errorf("unknown keyword argument: %s", key)
+		}
+	}
+	return NewGridWorldEnv(config)
+}
@@ -1 +1,417 @@
 package toy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/rand"
+	"github.com/gocnn/gym/space"
+)
+
+// taxiMap is the layout of the Taxi grid, where "|" denotes a wall and ":" a passable border.
+var taxiMap = []string{
+	"+---------+",
+	"|R: | : :G|",
+	"| : | : : |",
+	"| : : : : |",
+	"| | : | : |",
+	"|Y| : |B: |",
+	"+---------+",
+}
+
+// taxiLocs are the grid coordinates of the designated locations R, G, Y and B.
+var taxiLocs = [][2]int{{0, 0}, {0, 4}, {4, 0}, {4, 3}}
+
+// taxiActionNames are the names of the actions, indexed by action.
+var taxiActionNames = []string{"South", "North", "East", "West", "Pickup", "Dropoff"}
+
+const (
+	taxiRows      = 5
+	taxiCols      = 5
+	taxiNumStates = 500
+)
+
+// taxiTransition is the deterministic outcome of taking an action in a state.
+type taxiTransition struct {
+	next       int
+	reward     float64
+	terminated bool
+}
+
+// TaxiEnv implements the Taxi problem from "Hierarchical Reinforcement Learning with the MAXQ Value Function
+// Decomposition" by Tom Dietterich.
+//
+// There are four designated pick-up and drop-off locations (Red, Green, Yellow and Blue) in the 5x5 grid world.
+// The taxi starts off at a random square and the passenger at one of the designated locations.
+// The goal is to move the taxi to the passenger's location, pick up the passenger, move to the passenger's
+// desired destination, and drop off the passenger.
+//
+// ## Action Space
+// The action is an integer which can take values {0, 1, 2, 3, 4, 5}:
+// - 0: Move south (down)
+// - 1: Move north (up)
+// - 2: Move east (right)
+// - 3: Move west (left)
+// - 4: Pickup passenger
+// - 5: Drop off passenger
+//
+// ## Observation Space
+// The observation is an integer in [0, 500) encoding the taxi row, taxi column, passenger location and destination
+// as ((taxiRow*5 + taxiCol)*5 + passLoc)*4 + dest. Use Decode to interpret observations.
+// Passenger locations are 0: Red, 1: Green, 2: Yellow, 3: Blue and 4: in taxi.
+// Destinations are 0: Red, 1: Green, 2: Yellow and 3: Blue.
+//
+// ## Rewards
+// - -1 per step unless other reward is triggered
+// - +20 delivering passenger
+// - -10 executing "pickup" and "drop-off" actions illegally
+//
+// ## Episode End
+// The episode ends if any one of the following occurs:
+// 1. Termination: The taxi drops off the passenger
+// 2. Truncation: Episode length is greater than 200 (handled by TimeLimit wrapper)
+type TaxiEnv struct {
+	// Transition table, indexed by state and action
+	transitions [][]taxiTransition
+
+	// States with the passenger waiting at a location different from the destination
+	initialStates []int
+
+	// State
+	state      int
+	lastAction *int
+	reset      bool
+	rng        *rand.RNG
+
+	// Configuration
+	renderMode string
+
+	// Spaces
+	actionSpace      gym.Space[int]
+	observationSpace gym.Space[int]
+
+	// Metadata
+	metadata gym.Metadata
+}
+
+// TaxiConfig holds configuration options for Taxi environment
+type TaxiConfig struct {
+	RenderMode string
+}
+
+// taxiRenderModes lists the render modes supported by Taxi.
+var taxiRenderModes = []string{"ansi"}
+
+// NewTaxiEnv creates a new Taxi environment instance.
+//
+// Parameters:
+//   - config: Configuration options for the environment
+//
+// Returns:
+//   - A new Taxi environment
+//   - An error if initialization fails
+func NewTaxiEnv(config *TaxiConfig) (*TaxiEnv, error) {
+	if config == nil {
+		config = &TaxiConfig{}
+	}
+
+	if config.RenderMode != "" && !slices.Contains(taxiRenderModes, config.RenderMode) {
+		return nil, fmt.Errorf("unsupported render mode %q, expected one of %v", config.RenderMode, taxiRenderModes)
+	}
+
+	env := &TaxiEnv{
+		// Configuration
+		renderMode: config.RenderMode,
+
+		// Metadata
+		metadata: gym.Metadata{
+			"render_modes":      slices.Clone(taxiRenderModes),
+			"render_fps":        4,
+			"reward_threshold":  8.0,
+			"max_episode_steps": 200,
+		},
+	}
+
+	env.buildTransitions()
+
+	// Initialize RNG
+	rng, _, err := rand.NewRNG(0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RNG: %w", err)
+	}
+	env.rng = rng
+
+	// Create action space: Discrete(6) for the moves, pickup and dropoff
+	actionSpace, err := space.NewDiscreteWithRNG(env.rng.Derive(), len(taxiActionNames))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create action space: %w", err)
+	}
+	env.actionSpace = actionSpace
+
+	// Create observation space: Discrete(500) for the encoded states
+	observationSpace, err := space.NewDiscreteWithRNG(env.rng.Derive(), taxiNumStates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create observation space: %w", err)
+	}
+	env.observationSpace = observationSpace
+
+	return env, nil
+}
+
+// buildTransitions computes the deterministic transition table and the initial states.
+func (env *TaxiEnv) buildTransitions() {
+	env.transitions = make([][]taxiTransition, taxiNumStates)
+	env.initialStates = nil
+
+	for row := range taxiRows {
+		for col := range taxiCols {
+			for passLoc := range len(taxiLocs) + 1 {
+				for dest := range len(taxiLocs) {
+					state := taxiEncode(row, col, passLoc, dest)
+					if passLoc < len(taxiLocs) && passLoc != dest {
+						env.initialStates = append(env.initialStates, state)
+					}
+
+					env.transitions[state] = make([]taxiTransition, len(taxiActionNames))
+					for action := range taxiActionNames {
+						env.transitions[state][action] = taxiStep(row, col, passLoc, dest, action)
+					}
+				}
+			}
+		}
+	}
+}
+
+// taxiStep computes the outcome of taking an action in the decoded state.
+func taxiStep(row, col, passLoc, dest, action int) taxiTransition {
+	newRow, newCol, newPassLoc := row, col, passLoc
+	reward := -1.0
+	terminated := false
+	taxiLoc := [2]int{row, col}
+
+	switch action {
+	case 0: // South
+		newRow = min(row+1, taxiRows-1)
+	case 1: // North
+		newRow = max(row-1, 0)
+	case 2: // East
+		if taxiMap[1+row][2*col+2] == ':' {
+			newCol = min(col+1, taxiCols-1)
+		}
+	case 3: // West
+		if taxiMap[1+row][2*col] == ':' {
+			newCol = max(col-1, 0)
+		}
+	case 4: // Pickup
+		if passLoc < len(taxiLocs) && taxiLoc == taxiLocs[passLoc] {
+			newPassLoc = len(taxiLocs)
+		} else {
+			reward = -10
+		}
+	case 5: // Dropoff
+		if loc := slices.Index(taxiLocs, taxiLoc); passLoc == len(taxiLocs) && loc == dest {
+			newPassLoc = dest
+			terminated = true
+			reward = 20
+		} else if passLoc == len(taxiLocs) && loc >= 0 {
+			newPassLoc = loc
+		} else {
+			reward = -10
+		}
+	}
+
+	return taxiTransition{
+		next:       taxiEncode(newRow, newCol, newPassLoc, dest),
+		reward:     reward,
+		terminated: terminated,
+	}
+}
+
+// Encode returns the observation corresponding to the given taxi row, taxi column, passenger location and destination.
+func (env *TaxiEnv) Encode(taxiRow, taxiCol, passLoc, dest int) int {
+	return taxiEncode(taxiRow, taxiCol, passLoc, dest)
+}
+
+// taxiEncode encodes the taxi row, taxi column, passenger location and destination into a state.
+func taxiEncode(taxiRow, taxiCol, passLoc, dest int) int {
+	return ((taxiRow*taxiCols+taxiCol)*(len(taxiLocs)+1)+passLoc)*len(taxiLocs) + dest
+}
+
+// Decode returns the taxi row, taxi column, passenger location and destination encoded in an observation.
+func (env *TaxiEnv) Decode(state int) (taxiRow, taxiCol, passLoc, dest int) {
+	dest = state % len(taxiLocs)
+	state /= len(taxiLocs)
+	passLoc = state % (len(taxiLocs) + 1)
+	state /= len(taxiLocs) + 1
+	taxiCol = state % taxiCols
+	taxiRow = state / taxiCols
+	return taxiRow, taxiCol, passLoc, dest
+}
+
+// ActionMask returns a mask of the actions that change the state, with 1 for such actions and 0 otherwise.
+func (env *TaxiEnv) ActionMask(state int) []int8 {
+	mask := make([]int8, len(taxiActionNames))
+	taxiRow, taxiCol, passLoc, _ := env.Decode(state)
+	if taxiRow < taxiRows-1 {
+		mask[0] = 1
+	}
+	if taxiRow > 0 {
+		mask[1] = 1
+	}
+	if taxiCol < taxiCols-1 && taxiMap[taxiRow+1][2*taxiCol+2] == ':' {
+		mask[2] = 1
+	}
+	if taxiCol > 0 && taxiMap[taxiRow+1][2*taxiCol] == ':' {
+		mask[3] = 1
+	}
+	if passLoc < len(taxiLocs) && [2]int{taxiRow, taxiCol} == taxiLocs[passLoc] {
+		mask[4] = 1
+	}
+	if passLoc == len(taxiLocs) && slices.Contains(taxiLocs, [2]int{taxiRow, taxiCol}) {
+		mask[5] = 1
+	}
+	return mask
+}
+
+// Close performs cleanup when the user has finished using the environment.
+func (env *TaxiEnv) Close() error {
+	return nil
+}
+
+// Step runs one timestep of the environment's dynamics using the agent action.
+func (env *TaxiEnv) Step(ctx context.Context, action int) (int, float64, bool, bool, gym.Info, error) {
+	select {
+	case <-ctx.Done():
+		return 0, 0, false, false, nil, ctx.Err()
+	default:
+	}
+
+	if !env.actionSpace.Contains(action) {
+		return 0, 0, false, false, nil, fmt.Errorf("invalid action %d", action)
+	}
+
+	if !env.reset {
+		return 0, 0, false, false, nil, fmt.Errorf("call Reset before using Step method")
+	}
+
+	transition := env.transitions[env.state][action]
+	env.state = transition.next
+	env.lastAction = &action
+
+	// truncation=false as the time limit is handled by the TimeLimit wrapper
+	return env.state, transition.reward, transition.terminated, false, env.info(), nil
+}
+
+// Reset resets the environment to an initial internal state, returning an initial observation and info.
+func (env *TaxiEnv) Reset(ctx context.Context, seed *int64, options gym.Info) (int, gym.Info, error) {
+	select {
+	case <-ctx.Done():
+		return 0, nil, ctx.Err()
+	default:
+	}
+
+	// Seed the RNG if provided
+	if seed != nil {
+		if err := env.rng.SeedExact(*seed); err != nil {
+			return 0, nil, fmt.Errorf("failed to seed RNG: %w", err)
+		}
+	}
+
+	// Sample the initial state uniformly among the states with the passenger waiting
+	env.state = env.initialStates[env.rng.IntN(len(env.initialStates))]
+	env.lastAction = nil
+	env.reset = true
+
+	return env.state, env.info(), nil
+}
+
+// info returns the info of the current state.
+func (env *TaxiEnv) info() gym.Info {
+	return gym.Info{"prob": 1.0, "action_mask": env.ActionMask(env.state)}
+}
+
+// Render computes the render frames as specified by the environment's render mode.
+func (env *TaxiEnv) Render() (gym.RenderFrame, error) {
+	if env.renderMode == "" {
+		return nil, fmt.Errorf("no render mode specified")
+	}
+
+	if !env.reset {
+		return nil, fmt.Errorf("environment state is nil, call Reset first")
+	}
+
+	return env.renderANSI(), nil
+}
+
+// renderANSI draws the map with the taxi, passenger and destination highlighted using ANSI escape codes.
+func (env *TaxiEnv) renderANSI() string {
+	desc := make([][]string, len(taxiMap))
+	for i, line := range taxiMap {
+		desc[i] = strings.Split(line, "")
+	}
+
+	taxiRow, taxiCol, passLoc, dest := env.Decode(env.state)
+	if passLoc < len(taxiLocs) {
+		// Highlight the taxi in yellow and the passenger location in blue
+		desc[1+taxiRow][2*taxiCol+1] = colorize(desc[1+taxiRow][2*taxiCol+1], 33, false, true)
+		pi, pj := taxiLocs[passLoc][0], taxiLocs[passLoc][1]
+		desc[1+pi][2*pj+1] = colorize(desc[1+pi][2*pj+1], 34, true, false)
+	} else {
+		// Highlight the taxi carrying the passenger in green
+		desc[1+taxiRow][2*taxiCol+1] = colorize(desc[1+taxiRow][2*taxiCol+1], 32, false, true)
+	}
+	di, dj := taxiLocs[dest][0], taxiLocs[dest][1]
+	desc[1+di][2*dj+1] = colorize(desc[1+di][2*dj+1], 35, false, false)
+
+	var sb strings.Builder
+	for _, line := range desc {
+		sb.WriteString(strings.Join(line, ""))
+		sb.WriteString("\n")
+	}
+	if env.lastAction != nil {
+		fmt.Fprintf(&sb, "  (%s)\n", taxiActionNames[*env.lastAction])
+	} else {
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// colorize wraps s in ANSI escape codes for the given foreground color code, using it as background if highlight is set.
+func colorize(s string, code int, bold, highlight bool) string {
+	if highlight {
+		code += 10
+	}
+	attrs := fmt.Sprint(code)
+	if bold {
+		attrs += ";1"
+	}
+	return fmt.Sprintf("\x1b[%sm%s\x1b[0m", attrs, s)
+}
+
+// ActionSpace returns the Space object corresponding to valid actions.
+func (env *TaxiEnv) ActionSpace() gym.Space[int] {
+	return env.actionSpace
+}
+
+// ObservationSpace returns the Space object corresponding to valid observations.
+func (env *TaxiEnv) ObservationSpace() gym.Space[int] {
+	return env.observationSpace
+}
+
+// Metadata returns the metadata of the environment.
+func (env *TaxiEnv) Metadata() gym.Metadata {
+	return env.metadata
+}
+
+// Unwrapped returns the base non-wrapped environment.
+func (env *TaxiEnv) Unwrapped() gym.Env[int, int] {
+	return env
+}
+
+// GetRNG returns the environment's random number generator.
+func (env *TaxiEnv) GetRNG() *rand.RNG {
+	return env.rng
+}
@@ -0,0 +1,117 @@
+package wrappers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/rand"
+)
+
+// Adapt changes the observation and action types of an environment.
+//
+// Observations returned by the wrapped environment are converted with obsFn, and actions received
+// from the agent are converted with actFn before being forwarded. The caller provides the spaces
+// matching the converted types. Specific type-changing wrappers, such as flattening or discretizing
+// observations, are special cases of Adapt.
+type Adapt[InObs any, InAct any, OutObs any, OutAct any] struct {
+	env              gym.Env[InObs, InAct]
+	obsFn            func(InObs) OutObs
+	actFn            func(OutAct) InAct
+	observationSpace gym.Space[OutObs]
+	actionSpace      gym.Space[OutAct]
+}
+
+// NewAdapt creates a new Adapt wrapper.
+//
+// Parameters:
+//   - env: The environment to wrap
+//   - obsFn: Converts observations of the wrapped environment to the new observation type
+//   - actFn: Converts actions of the new action type to actions of the wrapped environment
+//   - observationSpace: The observation space of the converted observations
+//   - actionSpace: The action space of the new actions
+//
+// Returns:
+//   - A new Adapt wrapper
+//   - An error if any argument is nil
+func NewAdapt[InObs any, InAct any, OutObs any, OutAct any](
+	env gym.Env[InObs, InAct],
+	obsFn func(InObs) OutObs,
+	actFn func(OutAct) InAct,
+	observationSpace gym.Space[OutObs],
+	actionSpace gym.Space[OutAct],
+) (*Adapt[InObs, InAct, OutObs, OutAct], error) {
+	if env == nil || obsFn == nil || actFn == nil || observationSpace == nil || actionSpace == nil {
+		return nil, fmt.Errorf("env, obsFn, actFn, observationSpace and actionSpace must not be nil")
+	}
+
+	return &Adapt[InObs, InAct, OutObs, OutAct]{
+		env:              env,
+		obsFn:            obsFn,
+		actFn:            actFn,
+		observationSpace: observationSpace,
+		actionSpace:      actionSpace,
+	}, nil
+}
+
+// Step converts the action, steps the wrapped environment and converts the observation.
+func (a *Adapt[InObs, InAct, OutObs, OutAct]) Step(ctx context.Context, action OutAct) (OutObs, float64, bool, bool, gym.Info, error) {
+	obs, reward, terminated, truncated, info, err := a.env.Step(ctx, a.actFn(action))
+	if err != nil {
+		var zero OutObs
+		return zero, reward, terminated, truncated, info, err
+	}
+	return a.obsFn(obs), reward, terminated, truncated, info, nil
+}
+
+// Reset resets the wrapped environment and converts the initial observation.
+func (a *Adapt[InObs, InAct, OutObs, OutAct]) Reset(ctx context.Context, seed *int64, options gym.Info) (OutObs, gym.Info, error) {
+	obs, info, err := a.env.Reset(ctx, seed, options)
+	if err != nil {
+		var zero OutObs
+		return zero, info, err
+	}
+	return a.obsFn(obs), info, nil
+}
+
+// Render forwards rendering to the wrapped environment.
+func (a *Adapt[InObs, InAct, OutObs, OutAct]) Render() (gym.RenderFrame, error) {
+	return a.env.Render()
+}
+
+// Close closes the wrapped environment.
+func (a *Adapt[InObs, InAct, OutObs, OutAct]) Close() error {
+	return a.env.Close()
+}
+
+// ActionSpace returns the action space of the new actions.
+func (a *Adapt[InObs, InAct, OutObs, OutAct]) ActionSpace() gym.Space[OutAct] {
+	return a.actionSpace
+}
+
+// ObservationSpace returns the observation space of the converted observations.
+func (a *Adapt[InObs, InAct, OutObs, OutAct]) ObservationSpace() gym.Space[OutObs] {
+	return a.observationSpace
+}
+
+// Metadata returns the metadata of the wrapped environment.
+func (a *Adapt[InObs, InAct, OutObs, OutAct]) Metadata() gym.Metadata {
+	return a.env.Metadata()
+}
+
+// Unwrapped returns the adapter itself, since the wrapped environment has different types.
+//
+// Use Inner to access the wrapped environment.
+func (a *Adapt[InObs, InAct, OutObs, OutAct]) Unwrapped() gym.Env[OutObs, OutAct] {
+	return a
+}
+
+// GetRNG returns the random number generator of the wrapped environment.
+func (a *Adapt[InObs, InAct, OutObs, OutAct]) GetRNG() *rand.RNG {
+	return a.env.GetRNG()
+}
+
+// Inner returns the wrapped environment.
+func (a *Adapt[InObs, InAct, OutObs, OutAct]) Inner() gym.Env[InObs, InAct] {
+	return a.env
+}
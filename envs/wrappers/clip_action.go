@@ -0,0 +1,66 @@
+package wrappers
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/space"
+)
+
+// ClipAction clips continuous actions to the bounds of the action space.
+//
+// Each component of the action is clamped to the bounds of the wrapped environment's Box action space
+// before being forwarded to Step. The exposed action space is an unbounded Box of the same shape,
+// so agents can output any action.
+type ClipAction[Obs any] struct {
+	Wrapper[Obs, []float64]
+
+	bounds      *space.Box // The wrapped action space
+	actionSpace gym.Space[[]float64]
+}
+
+// NewClipAction creates a new ClipAction wrapper.
+//
+// Parameters:
+//   - env: The environment to wrap, with a Box action space
+//
+// Returns:
+//   - A new ClipAction wrapper
+//   - An error if the action space is not a Box
+func NewClipAction[Obs any](env gym.Env[Obs, []float64]) (*ClipAction[Obs], error) {
+	box, ok := env.ActionSpace().(*space.Box)
+	if !ok {
+		return nil, fmt.Errorf("action space must be a Box, got %T", env.ActionSpace())
+	}
+
+	actionSpace, err := space.NewBoxWithRNG(spaceRNG(env), math.Inf(-1), math.Inf(1), box.Shape())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create action space: %w", err)
+	}
+
+	return &ClipAction[Obs]{
+		Wrapper:     Wrapper[Obs, []float64]{Env: env},
+		bounds:      box,
+		actionSpace: actionSpace,
+	}, nil
+}
+
+// Step clips the action to the bounds of the wrapped action space and steps the environment.
+func (c *ClipAction[Obs]) Step(ctx context.Context, action []float64) (Obs, float64, bool, bool, gym.Info, error) {
+	return c.Env.Step(ctx, c.Clip(action))
+}
+
+// Clip returns a copy of the action with each component clamped to the bounds of the wrapped action space.
+//
+// Components beyond the length of the action space are left unchanged, so that the wrapped
+// environment can reject the invalid action.
+func (c *ClipAction[Obs]) Clip(action []float64) []float64 {
+	return c.bounds.Clip(action)
+}
+
+// ActionSpace returns an unbounded Box with the shape of the wrapped action space.
+func (c *ClipAction[Obs]) ActionSpace() gym.Space[[]float64] {
+	return c.actionSpace
+}
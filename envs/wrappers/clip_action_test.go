@@ -0,0 +1,95 @@
+package wrappers_test
+
+import (
+	"context"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/envs/wrappers"
+	"github.com/gocnn/gym/rand"
+	"github.com/gocnn/gym/space"
+)
+
+// boxActionEnv is a stub environment with a Box action space of [-1, 1] x [0, 2], recording the actions it
+// receives and rejecting actions outside of its action space.
+type boxActionEnv struct {
+	actions [][]float64 // Actions received by Step since the environment was created
+
+	rng         *rand.RNG
+	actionSpace *space.Box
+	obsSpace    *space.Discrete
+}
+
+func newBoxActionEnv(t *testing.T) *boxActionEnv {
+	t.Helper()
+
+	rng, _, err := rand.NewRNG(1)
+	if err != nil {
+		t.Fatalf("NewRNG failed: %v", err)
+	}
+	actionSpace, err := space.NewBoxWithRNG(rng.Derive(), []float64{-1, 0}, []float64{1, 2})
+	if err != nil {
+		t.Fatalf("NewBoxWithRNG failed: %v", err)
+	}
+	obsSpace, err := space.NewDiscreteWithRNG(rng.Derive(), 1)
+	if err != nil {
+		t.Fatalf("NewDiscreteWithRNG failed: %v", err)
+	}
+	return &boxActionEnv{rng: rng, actionSpace: actionSpace, obsSpace: obsSpace}
+}
+
+func (e *boxActionEnv) Step(_ context.Context, action []float64) (int, float64, bool, bool, gym.Info, error) {
+	if err := e.actionSpace.Validate(action); err != nil {
+		return 0, 0, false, false, nil, err
+	}
+	e.actions = append(e.actions, action)
+	return 0, 0, false, false, gym.Info{}, nil
+}
+
+func (e *boxActionEnv) Reset(context.Context, *int64, gym.Info) (int, gym.Info, error) {
+	return 0, gym.Info{}, nil
+}
+
+func (e *boxActionEnv) Render() (gym.RenderFrame, error)   { return nil, nil }
+func (e *boxActionEnv) Close() error                       { return nil }
+func (e *boxActionEnv) ActionSpace() gym.Space[[]float64]  { return e.actionSpace }
+func (e *boxActionEnv) ObservationSpace() gym.Space[int]   { return e.obsSpace }
+func (e *boxActionEnv) Metadata() gym.Metadata             { return gym.Metadata{} }
+func (e *boxActionEnv) Unwrapped() gym.Env[int, []float64] { return e }
+func (e *boxActionEnv) GetRNG() *rand.RNG                  { return e.rng }
+
+func TestClipAction(t *testing.T) {
+	inner := newBoxActionEnv(t)
+	env, err := wrappers.NewClipAction(inner)
+	if err != nil {
+		t.Fatalf("NewClipAction failed: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, _, err := env.Reset(ctx, nil, nil); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+
+	tests := []struct {
+		action []float64
+		want   []float64
+	}{
+		{[]float64{0.5, 1}, []float64{0.5, 1}},
+		{[]float64{-3, 5}, []float64{-1, 2}},
+		{[]float64{2, -0.5}, []float64{1, 0}},
+		{[]float64{math.Inf(-1), math.Inf(1)}, []float64{-1, 2}},
+	}
+	for i, tt := range tests {
+		if !env.ActionSpace().Contains(tt.action) {
+			t.Errorf("action %v is not contained in the unbounded action space", tt.action)
+		}
+		if _, _, _, _, _, err := env.Step(ctx, tt.action); err != nil {
+			t.Fatalf("Step(%v) failed: %v", tt.action, err)
+		}
+		if got := inner.actions[i]; !slices.Equal(got, tt.want) {
+			t.Errorf("action %v was clipped to %v, want %v", tt.action, got, tt.want)
+		}
+	}
+}
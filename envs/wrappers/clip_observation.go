@@ -0,0 +1,91 @@
+package wrappers
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/space"
+)
+
+// ClipObservation clips continuous observations to a finite range.
+//
+// Each component of the observation is clamped to the intersection of the given range and the bounds of the
+// wrapped environment's Box observation space, which is exposed as the new, bounded observation space.
+// Infinite bounds of the wrapped space, such as the velocities of CartPole, become the given finite ones.
+type ClipObservation[Act any] struct {
+	Wrapper[[]float64, Act]
+
+	bounds *space.Box // The clipped observation space
+}
+
+// NewClipObservation creates a new ClipObservation wrapper.
+//
+// Parameters:
+//   - env: The environment to wrap, with a Box observation space
+//   - low: The finite lower bounds of the observation components
+//   - high: The finite upper bounds of the observation components
+//
+// Returns:
+//   - A new ClipObservation wrapper
+//   - An error if the observation space is not a Box or the bounds are invalid
+func NewClipObservation[Act any](env gym.Env[[]float64, Act], low, high []float64) (*ClipObservation[Act], error) {
+	box, ok := env.ObservationSpace().(*space.Box)
+	if !ok {
+		return nil, fmt.Errorf("observation space must be a Box, got %T", env.ObservationSpace())
+	}
+
+	dim := box.FlatDim()
+	if len(low) != dim || len(high) != dim {
+		return nil, fmt.Errorf("low and high must have length %d, got %d and %d", dim, len(low), len(high))
+	}
+
+	boxLow, boxHigh := box.Low(), box.High()
+	clipLow := make([]float64, dim)
+	clipHigh := make([]float64, dim)
+	for i := range dim {
+		if math.IsInf(low[i], 0) || math.IsInf(high[i], 0) {
+			return nil, fmt.Errorf("bounds must be finite, got [%g, %g] at index %d", low[i], high[i], i)
+		}
+		clipLow[i] = math.Max(low[i], boxLow[i])
+		clipHigh[i] = math.Min(high[i], boxHigh[i])
+		if clipLow[i] > clipHigh[i] {
+			return nil, fmt.Errorf("bounds [%g, %g] do not intersect the observation space bounds [%g, %g] at index %d",
+				low[i], high[i], boxLow[i], boxHigh[i], i)
+		}
+	}
+
+	bounds, err := space.NewBoxWithRNG(spaceRNG(env), clipLow, clipHigh, box.Shape())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create observation space: %w", err)
+	}
+
+	return &ClipObservation[Act]{
+		Wrapper: Wrapper[[]float64, Act]{Env: env},
+		bounds:  bounds,
+	}, nil
+}
+
+// Step steps the environment and clips the observation.
+func (c *ClipObservation[Act]) Step(ctx context.Context, action Act) ([]float64, float64, bool, bool, gym.Info, error) {
+	obs, reward, terminated, truncated, info, err := c.Env.Step(ctx, action)
+	if err != nil {
+		return obs, reward, terminated, truncated, info, err
+	}
+	return c.bounds.Clip(obs), reward, terminated, truncated, info, nil
+}
+
+// Reset resets the environment and clips the initial observation.
+func (c *ClipObservation[Act]) Reset(ctx context.Context, seed *int64, options gym.Info) ([]float64, gym.Info, error) {
+	obs, info, err := c.Env.Reset(ctx, seed, options)
+	if err != nil {
+		return obs, info, err
+	}
+	return c.bounds.Clip(obs), info, nil
+}
+
+// ObservationSpace returns the bounded Box the observations are clipped to.
+func (c *ClipObservation[Act]) ObservationSpace() gym.Space[[]float64] {
+	return c.bounds
+}
@@ -0,0 +1,66 @@
+package wrappers_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/gocnn/gym/envs/wrappers"
+	"github.com/gocnn/gym/space"
+)
+
+func TestClipObservation(t *testing.T) {
+	env, err := wrappers.NewClipObservation[int](newScriptedEnv(t, 0, 0, 0, 0, 0), []float64{2}, []float64{4})
+	if err != nil {
+		t.Fatalf("NewClipObservation failed: %v", err)
+	}
+
+	ctx := context.Background()
+	obs, _, err := env.Reset(ctx, nil, nil)
+	if err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	if obs[0] != 2 {
+		t.Errorf("initial observation 0 was clipped to %v, want 2", obs[0])
+	}
+
+	// The inner environment observes 1 to 5
+	for i, want := range []float64{2, 2, 3, 4, 4} {
+		obs, _, _, _, _, err := env.Step(ctx, 0)
+		if err != nil {
+			t.Fatalf("Step failed: %v", err)
+		}
+		if obs[0] != want {
+			t.Errorf("observation %d was clipped to %v, want %v", i+1, obs[0], want)
+		}
+		if !env.ObservationSpace().Contains(obs) {
+			t.Errorf("clipped observation %v is not contained in the observation space", obs)
+		}
+	}
+}
+
+func TestClipObservationSpaceIsBounded(t *testing.T) {
+	env, err := wrappers.NewClipObservation[int](newCartPole(t), []float64{-10, -3, -0.2, -3}, []float64{10, 3, 0.2, 3})
+	if err != nil {
+		t.Fatalf("NewClipObservation failed: %v", err)
+	}
+
+	// Finite bounds of CartPole are kept where they are tighter, its infinite velocity bounds are replaced
+	box := env.ObservationSpace().(*space.Box)
+	if bounded, err := box.IsBounded("both"); err != nil || !bounded {
+		t.Errorf("IsBounded(\"both\") = %v, %v, want true", bounded, err)
+	}
+	if want := []float64{-4.8, -3, -0.2, -3}; !slices.Equal(box.Low(), want) {
+		t.Errorf("Low() = %v, want %v", box.Low(), want)
+	}
+	if want := []float64{4.8, 3, 0.2, 3}; !slices.Equal(box.High(), want) {
+		t.Errorf("High() = %v, want %v", box.High(), want)
+	}
+
+	if _, err := wrappers.NewClipObservation[int](newScriptedEnv(t, 0), []float64{200}, []float64{300}); err == nil {
+		t.Error("NewClipObservation with bounds outside of the observation space succeeded, expected an error")
+	}
+	if _, err := wrappers.NewClipObservation[int](newCartPole(t), []float64{-1, -1, -1, -1}, []float64{1, 1, 1, 1, 1}); err == nil {
+		t.Error("NewClipObservation with 5 upper bounds succeeded, expected an error")
+	}
+}
@@ -0,0 +1,98 @@
+package wrappers
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/space"
+)
+
+// DiscretizeObservation converts continuous Box observations to a single Discrete index.
+//
+// Each dimension i of the observation is partitioned into bins[i] equal-width buckets over its bounds, and
+// the buckets of all dimensions are encoded in row-major order into one index of Discrete(prod(bins)).
+// Values outside the bounds fall into the first or last bucket. This lets tabular agents run on
+// continuous-observation environments such as CartPole.
+type DiscretizeObservation[Act any] struct {
+	*Adapt[[]float64, Act, int, Act]
+
+	bins []int     // Number of buckets of each dimension
+	low  []float64 // Lower bounds of the binned range
+	high []float64 // Upper bounds of the binned range
+}
+
+// NewDiscretizeObservation creates a new DiscretizeObservation wrapper.
+//
+// The binned range defaults to the bounds of the observation space. Observation spaces with infinite
+// bounds, such as the velocities of CartPole, need an explicit finite range.
+//
+// Parameters:
+//   - env: The environment to wrap, with a Box observation space
+//   - bins: The number of buckets of each dimension (all must be positive)
+//   - low: The lower bounds of the binned range, or nil for the bounds of the observation space
+//   - high: The upper bounds of the binned range, or nil for the bounds of the observation space
+//
+// Returns:
+//   - A new DiscretizeObservation wrapper
+//   - An error if the observation space is not a Box, bins is invalid or the binned range is not finite
+func NewDiscretizeObservation[Act any](env gym.Env[[]float64, Act], bins []int, low, high []float64) (*DiscretizeObservation[Act], error) {
+	box, ok := env.ObservationSpace().(*space.Box)
+	if !ok {
+		return nil, fmt.Errorf("observation space must be a Box, got %T", env.ObservationSpace())
+	}
+
+	if low == nil {
+		low = box.Low()
+	}
+	if high == nil {
+		high = box.High()
+	}
+
+	dim := box.FlatDim()
+	if len(bins) != dim || len(low) != dim || len(high) != dim {
+		return nil, fmt.Errorf("bins, low and high must have length %d, got %d, %d and %d", dim, len(bins), len(low), len(high))
+	}
+
+	n := 1
+	for i := range bins {
+		if bins[i] <= 0 {
+			return nil, fmt.Errorf("bins must be positive, got %d at index %d", bins[i], i)
+		}
+		if math.IsInf(low[i], 0) || math.IsInf(high[i], 0) || !(low[i] < high[i]) {
+			return nil, fmt.Errorf("binned range of dimension %d must be finite and non-empty, got [%g, %g]", i, low[i], high[i])
+		}
+		n *= bins[i]
+	}
+
+	observationSpace, err := space.NewDiscreteWithRNG(spaceRNG(env), n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create observation space: %w", err)
+	}
+
+	d := &DiscretizeObservation[Act]{
+		bins: append([]int(nil), bins...),
+		low:  append([]float64(nil), low...),
+		high: append([]float64(nil), high...),
+	}
+	d.Adapt, err = NewAdapt(env, d.Discretize, func(action Act) Act { return action }, gym.Space[int](observationSpace), env.ActionSpace())
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Discretize returns the Discrete index of an observation.
+func (d *DiscretizeObservation[Act]) Discretize(obs []float64) int {
+	index := 0
+	for i, val := range obs {
+		if i >= len(d.bins) {
+			break
+		}
+
+		bucket := int(math.Floor((val - d.low[i]) / (d.high[i] - d.low[i]) * float64(d.bins[i])))
+		bucket = max(0, min(bucket, d.bins[i]-1))
+		index = index*d.bins[i] + bucket
+	}
+	return index
+}
@@ -0,0 +1,60 @@
+package wrappers_test
+
+import (
+	"testing"
+
+	"github.com/gocnn/gym/envs/wrappers"
+	"github.com/gocnn/gym/space"
+)
+
+func TestDiscretizeObservationBucketEdges(t *testing.T) {
+	// Four buckets over the [-100, 100] bounds of the observation space, with edges at -50, 0 and 50
+	env, err := wrappers.NewDiscretizeObservation[int](newScriptedEnv(t, 0), []int{4}, nil, nil)
+	if err != nil {
+		t.Fatalf("NewDiscretizeObservation failed: %v", err)
+	}
+
+	for _, tc := range []struct {
+		obs  float64
+		want int
+	}{
+		{-150, 0},
+		{-100, 0},
+		{-50.001, 0},
+		{-50, 1},
+		{-0.001, 1},
+		{0, 2},
+		{49.999, 2},
+		{50, 3},
+		{100, 3},
+		{150, 3},
+	} {
+		if got := env.Discretize([]float64{tc.obs}); got != tc.want {
+			t.Errorf("Discretize([%v]) = %d, want %d", tc.obs, got, tc.want)
+		}
+	}
+}
+
+func TestDiscretizeObservationRowMajor(t *testing.T) {
+	bins := []int{2, 3, 1, 2}
+	low, high := []float64{-1, -1, -1, -1}, []float64{1, 1, 1, 1}
+	env, err := wrappers.NewDiscretizeObservation[int](newCartPole(t), bins, low, high)
+	if err != nil {
+		t.Fatalf("NewDiscretizeObservation failed: %v", err)
+	}
+
+	if d, ok := env.ObservationSpace().(*space.Discrete); !ok || d.N() != 12 {
+		t.Errorf("ObservationSpace() = %v, want Discrete(12)", env.ObservationSpace())
+	}
+	// Buckets 1, 0, 0 and 1 encode to ((1*3 + 0)*1 + 0)*2 + 1
+	if got := env.Discretize([]float64{0.5, -0.5, 0, 0.9}); got != 7 {
+		t.Errorf("Discretize = %d, want 7", got)
+	}
+
+	if _, err := wrappers.NewDiscretizeObservation[int](newCartPole(t), bins, nil, nil); err == nil {
+		t.Error("NewDiscretizeObservation with the infinite bounds of CartPole succeeded, expected an error")
+	}
+	if _, err := wrappers.NewDiscretizeObservation[int](newCartPole(t), []int{2, 0, 1, 2}, low, high); err == nil {
+		t.Error("NewDiscretizeObservation with 0 bins succeeded, expected an error")
+	}
+}
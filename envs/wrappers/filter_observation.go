@@ -0,0 +1,94 @@
+package wrappers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/space"
+)
+
+// FilterObservation restricts Dict observations to a subset of their keys.
+//
+// This is commonly used in goal-conditioned environments to drop entries such as "achieved_goal"
+// that the agent should not observe.
+type FilterObservation[Act any] struct {
+	Wrapper[map[string]any, Act]
+
+	keys             []string // Keys kept in the observations
+	observationSpace *space.Dict
+}
+
+// NewFilterObservation creates a new FilterObservation wrapper.
+//
+// Parameters:
+//   - env: The environment to wrap, whose observation space must be a Dict
+//   - keys: The keys to keep in the observations
+//
+// Returns:
+//   - A new FilterObservation wrapper
+//   - An error if the observation space is not a Dict or a key is not in it
+func NewFilterObservation[Act any](env gym.Env[map[string]any, Act], keys []string) (*FilterObservation[Act], error) {
+	dict, ok := env.ObservationSpace().(*space.Dict)
+	if !ok {
+		return nil, fmt.Errorf("observation space must be a Dict, got %T", env.ObservationSpace())
+	}
+
+	spaces := make(map[string]any, len(keys))
+	for _, key := range keys {
+		sub, ok := dict.Get(key)
+		if !ok {
+			return nil, fmt.Errorf("key %q is not in the observation space %v", key, dict)
+		}
+		spaces[key] = sub
+	}
+	observationSpace, err := space.NewDict(spaces)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create observation space: %w", err)
+	}
+
+	return &FilterObservation[Act]{
+		Wrapper:          Wrapper[map[string]any, Act]{Env: env},
+		keys:             observationSpace.Keys(),
+		observationSpace: observationSpace,
+	}, nil
+}
+
+// Step steps the environment and filters the returned observation.
+func (f *FilterObservation[Act]) Step(ctx context.Context, action Act) (map[string]any, float64, bool, bool, gym.Info, error) {
+	obs, reward, terminated, truncated, info, err := f.Env.Step(ctx, action)
+	if err != nil {
+		return nil, reward, terminated, truncated, info, err
+	}
+	return f.filter(obs), reward, terminated, truncated, info, nil
+}
+
+// Reset resets the environment and filters the initial observation.
+func (f *FilterObservation[Act]) Reset(ctx context.Context, seed *int64, options gym.Info) (map[string]any, gym.Info, error) {
+	obs, info, err := f.Env.Reset(ctx, seed, options)
+	if err != nil {
+		return nil, info, err
+	}
+	return f.filter(obs), info, nil
+}
+
+// ObservationSpace returns the Dict space with only the kept keys.
+func (f *FilterObservation[Act]) ObservationSpace() gym.Space[map[string]any] {
+	return f.observationSpace
+}
+
+// Keys returns the keys kept in the observations, in sorted order.
+func (f *FilterObservation[Act]) Keys() []string {
+	return f.observationSpace.Keys()
+}
+
+// filter returns a new map with only the kept keys of an observation.
+func (f *FilterObservation[Act]) filter(obs map[string]any) map[string]any {
+	filtered := make(map[string]any, len(f.keys))
+	for _, key := range f.keys {
+		if val, ok := obs[key]; ok {
+			filtered[key] = val
+		}
+	}
+	return filtered
+}
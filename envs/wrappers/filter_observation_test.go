@@ -0,0 +1,125 @@
+package wrappers_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/envs/wrappers"
+	"github.com/gocnn/gym/rand"
+	"github.com/gocnn/gym/space"
+)
+
+// goalEnv is a stub goal-conditioned environment with Dict observations.
+type goalEnv struct {
+	rng         *rand.RNG
+	actionSpace *space.Discrete
+	obsSpace    *space.Dict
+}
+
+func newGoalEnv(t *testing.T) *goalEnv {
+	t.Helper()
+
+	rngs := make([]*rand.RNG, 5)
+	for i := range rngs {
+		rng, _, err := rand.NewRNG(int64(i + 1))
+		if err != nil {
+			t.Fatalf("NewRNG failed: %v", err)
+		}
+		rngs[i] = rng
+	}
+	actionSpace, err := space.NewDiscreteWithRNG(rngs[1], 2)
+	if err != nil {
+		t.Fatalf("NewDiscreteWithRNG failed: %v", err)
+	}
+	spaces := make(map[string]any)
+	for i, key := range []string{"observation", "achieved_goal", "desired_goal"} {
+		box, err := space.NewBoxWithRNG(rngs[i+2], -1.0, 1.0, []int{2})
+		if err != nil {
+			t.Fatalf("NewBoxWithRNG failed: %v", err)
+		}
+		spaces[key] = box
+	}
+	obsSpace, err := space.NewDict(spaces)
+	if err != nil {
+		t.Fatalf("NewDict failed: %v", err)
+	}
+	return &goalEnv{rng: rngs[0], actionSpace: actionSpace, obsSpace: obsSpace}
+}
+
+func (e *goalEnv) observation() map[string]any {
+	return map[string]any{
+		"observation":   []float64{0.1, 0.2},
+		"achieved_goal": []float64{0.3, 0.4},
+		"desired_goal":  []float64{0.5, 0.6},
+	}
+}
+
+func (e *goalEnv) Step(context.Context, int) (map[string]any, float64, bool, bool, gym.Info, error) {
+	return e.observation(), 0, false, false, gym.Info{}, nil
+}
+
+func (e *goalEnv) Reset(context.Context, *int64, gym.Info) (map[string]any, gym.Info, error) {
+	return e.observation(), gym.Info{}, nil
+}
+
+func (e *goalEnv) Render() (gym.RenderFrame, error)            { return nil, nil }
+func (e *goalEnv) Close() error                                { return nil }
+func (e *goalEnv) ActionSpace() gym.Space[int]                 { return e.actionSpace }
+func (e *goalEnv) ObservationSpace() gym.Space[map[string]any] { return e.obsSpace }
+func (e *goalEnv) Metadata() gym.Metadata                      { return gym.Metadata{} }
+func (e *goalEnv) Unwrapped() gym.Env[map[string]any, int]     { return e }
+func (e *goalEnv) GetRNG() *rand.RNG                           { return e.rng }
+
+func TestFilterObservation(t *testing.T) {
+	env, err := wrappers.NewFilterObservation(newGoalEnv(t), []string{"observation", "desired_goal"})
+	if err != nil {
+		t.Fatalf("NewFilterObservation failed: %v", err)
+	}
+
+	want := []string{"desired_goal", "observation"}
+	dict, ok := env.ObservationSpace().(*space.Dict)
+	if !ok {
+		t.Fatalf("ObservationSpace() has type %T, want *space.Dict", env.ObservationSpace())
+	}
+	if got := dict.Keys(); !slices.Equal(got, want) {
+		t.Errorf("observation space keys = %v, want %v", got, want)
+	}
+
+	ctx := context.Background()
+	obs, _, err := env.Reset(ctx, nil, nil)
+	if err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	checkFilteredObservation(t, env, obs, want)
+
+	obs, _, _, _, _, err = env.Step(ctx, 0)
+	if err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+	checkFilteredObservation(t, env, obs, want)
+}
+
+// checkFilteredObservation checks that obs has exactly the wanted keys and is a member of the filtered space.
+func checkFilteredObservation(t *testing.T, env gym.Env[map[string]any, int], obs map[string]any, want []string) {
+	t.Helper()
+
+	keys := make([]string, 0, len(obs))
+	for key := range obs {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	if !slices.Equal(keys, want) {
+		t.Errorf("observation keys = %v, want %v", keys, want)
+	}
+	if !env.ObservationSpace().Contains(obs) {
+		t.Errorf("observation %v is not in the filtered observation space", obs)
+	}
+}
+
+func TestFilterObservationUnknownKey(t *testing.T) {
+	if _, err := wrappers.NewFilterObservation(newGoalEnv(t), []string{"observation", "reward"}); err == nil {
+		t.Fatal("NewFilterObservation succeeded with an unknown key, expected an error")
+	}
+}
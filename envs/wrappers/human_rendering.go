@@ -0,0 +1,105 @@
+package wrappers
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"maps"
+	"slices"
+
+	"github.com/gocnn/gym"
+)
+
+// HumanRendering displays the frames of an "rgb_array" environment in a window.
+//
+// The wrapped environment must be created with the "rgb_array" render mode. The wrapper renders it after
+// every Reset and Step and shows the frame in a window, giving any environment that renders RGB arrays a
+// human view. The window requires Ebiten and is unavailable when built with the "headless" build tag.
+type HumanRendering[Obs any, Act any] struct {
+	Wrapper[Obs, Act]
+
+	metadata gym.Metadata
+	window   humanWindow
+}
+
+// NewHumanRendering creates a new HumanRendering wrapper.
+//
+// Parameters:
+//   - env: The environment to wrap, created with the "rgb_array" render mode
+//
+// Returns:
+//   - A new HumanRendering wrapper
+//   - An error if the environment does not declare the "rgb_array" render mode
+func NewHumanRendering[Obs any, Act any](env gym.Env[Obs, Act]) (*HumanRendering[Obs, Act], error) {
+	modes, _ := env.Metadata()["render_modes"].([]string)
+	if !slices.Contains(modes, "rgb_array") {
+		return nil, fmt.Errorf("environment must support the \"rgb_array\" render mode, got %v", modes)
+	}
+
+	metadata := maps.Clone(env.Metadata())
+	if !slices.Contains(modes, "human") {
+		metadata["render_modes"] = append(slices.Clone(modes), "human")
+	}
+
+	return &HumanRendering[Obs, Act]{
+		Wrapper:  Wrapper[Obs, Act]{Env: env},
+		metadata: metadata,
+	}, nil
+}
+
+// Step steps the environment and displays the new frame.
+func (h *HumanRendering[Obs, Act]) Step(ctx context.Context, action Act) (Obs, float64, bool, bool, gym.Info, error) {
+	obs, reward, terminated, truncated, info, err := h.Env.Step(ctx, action)
+	if err != nil {
+		return obs, reward, terminated, truncated, info, err
+	}
+
+	if _, err := h.Render(); err != nil {
+		return obs, reward, terminated, truncated, info, err
+	}
+	return obs, reward, terminated, truncated, info, nil
+}
+
+// Reset resets the environment and displays the first frame.
+func (h *HumanRendering[Obs, Act]) Reset(ctx context.Context, seed *int64, options gym.Info) (Obs, gym.Info, error) {
+	obs, info, err := h.Env.Reset(ctx, seed, options)
+	if err != nil {
+		return obs, info, err
+	}
+
+	if _, err := h.Render(); err != nil {
+		return obs, info, err
+	}
+	return obs, info, nil
+}
+
+// Render renders the wrapped environment and displays the frame in the window.
+//
+// As in "human" mode, no frame is returned.
+func (h *HumanRendering[Obs, Act]) Render() (gym.RenderFrame, error) {
+	frame, err := h.Env.Render()
+	if err != nil {
+		return nil, err
+	}
+
+	img, ok := frame.(image.Image)
+	if !ok {
+		return nil, fmt.Errorf("expected an image frame from the \"rgb_array\" render mode, got %T", frame)
+	}
+
+	if err := h.window.show(img); err != nil {
+		return nil, fmt.Errorf("failed to display frame: %w", err)
+	}
+	return nil, nil
+}
+
+// Close closes the window and the wrapped environment.
+func (h *HumanRendering[Obs, Act]) Close() error {
+	h.window.close()
+	return h.Env.Close()
+}
+
+// Metadata returns the metadata of the wrapped environment, with "human" added to the render modes.
+func (h *HumanRendering[Obs, Act]) Metadata() gym.Metadata {
+	return h.metadata
+}
@@ -0,0 +1,19 @@
+//go:build headless
+
+package wrappers
+
+import (
+	"fmt"
+	"image"
+)
+
+// humanWindow is the window of a HumanRendering wrapper, empty in headless builds.
+type humanWindow struct{}
+
+// show always fails because windows are unavailable in headless builds.
+func (w *humanWindow) show(img image.Image) error {
+	return fmt.Errorf("human rendering is unavailable in headless builds")
+}
+
+// close releases the resources held by the window.
+func (w *humanWindow) close() {}
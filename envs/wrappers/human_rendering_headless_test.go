@@ -0,0 +1,30 @@
+//go:build headless
+
+package wrappers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gocnn/gym/envs/wrappers"
+)
+
+func TestHumanRenderingHeadless(t *testing.T) {
+	inner := newFrameEnv(t, 0, 0)
+	env, err := wrappers.NewHumanRendering[[]float64, int](inner)
+	if err != nil {
+		t.Fatalf("NewHumanRendering failed: %v", err)
+	}
+	defer env.Close()
+
+	// The wrapped environment is still stepped, but the frame cannot be displayed
+	if _, _, _, _, _, err := env.Step(context.Background(), 1); err == nil {
+		t.Error("Step succeeded in a headless build, expected an error displaying the frame")
+	}
+	if len(inner.actions) != 1 {
+		t.Errorf("wrapped environment received %d actions, want 1", len(inner.actions))
+	}
+	if _, err := env.Render(); err == nil {
+		t.Error("Render succeeded in a headless build, expected an error")
+	}
+}
@@ -0,0 +1,67 @@
+package wrappers_test
+
+import (
+	"image"
+	"slices"
+	"testing"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/envs/wrappers"
+)
+
+// frameEnv is a scriptedEnv rendering blank 4x3 frames in the "rgb_array" render mode.
+type frameEnv struct {
+	*scriptedEnv
+	metadata gym.Metadata
+	closed   bool
+}
+
+// newFrameEnv creates a frameEnv rewarding its steps with rewards.
+func newFrameEnv(t *testing.T, rewards ...float64) *frameEnv {
+	t.Helper()
+
+	return &frameEnv{
+		scriptedEnv: newScriptedEnv(t, rewards...),
+		metadata:    gym.Metadata{"render_modes": []string{"rgb_array"}, "render_fps": 50},
+	}
+}
+
+func (e *frameEnv) Render() (gym.RenderFrame, error) {
+	return image.NewRGBA(image.Rect(0, 0, 4, 3)), nil
+}
+func (e *frameEnv) Metadata() gym.Metadata { return e.metadata }
+func (e *frameEnv) Close() error           { e.closed = true; return nil }
+
+// TestHumanRenderingMetadata checks the metadata and delegation of HumanRendering without displaying a frame,
+// so it runs in headless builds and without a display.
+func TestHumanRenderingMetadata(t *testing.T) {
+	if _, err := wrappers.NewHumanRendering[[]float64, int](newScriptedEnv(t, 0)); err == nil {
+		t.Error("NewHumanRendering of an environment without the rgb_array render mode succeeded, expected an error")
+	}
+
+	inner := newFrameEnv(t, 0)
+	env, err := wrappers.NewHumanRendering[[]float64, int](inner)
+	if err != nil {
+		t.Fatalf("NewHumanRendering failed: %v", err)
+	}
+
+	if modes := env.Metadata()["render_modes"]; !slices.Equal(modes.([]string), []string{"rgb_array", "human"}) {
+		t.Errorf("render_modes = %v, want [rgb_array human]", modes)
+	}
+	if env.Metadata()["render_fps"] != 50 {
+		t.Errorf("render_fps = %v, want the 50 of the wrapped environment", env.Metadata()["render_fps"])
+	}
+	if modes := inner.metadata["render_modes"]; !slices.Equal(modes.([]string), []string{"rgb_array"}) {
+		t.Errorf("wrapping changed the render modes of the wrapped environment to %v", modes)
+	}
+
+	if env.ActionSpace() != inner.ActionSpace() || env.ObservationSpace() != inner.ObservationSpace() {
+		t.Error("spaces are not those of the wrapped environment")
+	}
+	if env.Unwrapped() != gym.Env[[]float64, int](inner.scriptedEnv) || env.GetRNG() != inner.rng {
+		t.Error("Unwrapped or GetRNG does not delegate to the wrapped environment")
+	}
+	if err := env.Close(); err != nil || !inner.closed {
+		t.Errorf("Close returned %v and closed the wrapped environment: %v, want nil and true", err, inner.closed)
+	}
+}
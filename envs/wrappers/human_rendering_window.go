@@ -0,0 +1,97 @@
+//go:build !headless
+
+package wrappers
+
+import (
+	"fmt"
+	"image"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// humanWindow is the Ebiten window of a HumanRendering wrapper, opened on the first frame.
+type humanWindow struct {
+	frame   *ebiten.Image
+	width   int
+	height  int
+	running bool
+	closed  bool
+	mutex   sync.Mutex
+}
+
+// show displays a frame, opening the window if necessary.
+func (w *humanWindow) show(img image.Image) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.closed {
+		return fmt.Errorf("window is closed")
+	}
+
+	if w.frame != nil {
+		w.frame.Dispose()
+	}
+	w.frame = ebiten.NewImageFromImage(img)
+
+	if !w.running {
+		w.running = true
+		w.width, w.height = img.Bounds().Dx(), img.Bounds().Dy()
+		go w.run()
+	}
+	return nil
+}
+
+// run runs the game loop of the window until it is closed.
+func (w *humanWindow) run() {
+	ebiten.SetWindowSize(w.width, w.height)
+	ebiten.SetWindowTitle("Gym Environment")
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+
+	// The window was closed, by the user or by close
+	_ = ebiten.RunGame(&humanGame{window: w})
+
+	w.mutex.Lock()
+	w.running = false
+	w.mutex.Unlock()
+}
+
+// close stops the game loop and releases the frame.
+func (w *humanWindow) close() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.closed = true
+	if w.frame != nil {
+		w.frame.Dispose()
+		w.frame = nil
+	}
+}
+
+// humanGame displays the latest frame of a humanWindow.
+type humanGame struct {
+	window *humanWindow
+}
+
+func (g *humanGame) Update() error {
+	g.window.mutex.Lock()
+	defer g.window.mutex.Unlock()
+
+	if g.window.closed {
+		return ebiten.Termination
+	}
+	return nil
+}
+
+func (g *humanGame) Draw(screen *ebiten.Image) {
+	g.window.mutex.Lock()
+	defer g.window.mutex.Unlock()
+
+	if g.window.frame != nil {
+		screen.DrawImage(g.window.frame, nil)
+	}
+}
+
+func (g *humanGame) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return g.window.width, g.window.height
+}
@@ -0,0 +1,97 @@
+package wrappers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/space"
+)
+
+// MaxAndSkip repeats every action for a number of steps and returns the summed reward.
+//
+// For image observations, i.e. []float64 observations of a Box space with at least two dimensions, the
+// returned observation is the element-wise maximum of the last two observations, which removes the
+// flickering of sprites drawn on alternate frames (the Atari convention). Other observations are returned
+// as observed on the last step. The repetition stops early when an intermediate step terminates or truncates
+// the episode. The observation preceding the last step is copied before max-pooling, so the wrapped
+// environment may reuse the slice of its observations across steps.
+type MaxAndSkip[Obs any, Act any] struct {
+	Wrapper[Obs, Act]
+
+	skip    int  // Number of times each action is repeated
+	maxPool bool // Whether the last two observations are max-pooled
+}
+
+// NewMaxAndSkip creates a new MaxAndSkip wrapper.
+//
+// Parameters:
+//   - env: The environment to wrap
+//   - skip: The number of times each action is repeated (must be positive)
+//
+// Returns:
+//   - A new MaxAndSkip wrapper
+//   - An error if skip is not positive
+func NewMaxAndSkip[Obs any, Act any](env gym.Env[Obs, Act], skip int) (*MaxAndSkip[Obs, Act], error) {
+	if skip <= 0 {
+		return nil, fmt.Errorf("skip must be positive, got %d", skip)
+	}
+
+	_, isVector := any(*new(Obs)).([]float64)
+	box, isBox := any(env.ObservationSpace()).(*space.Box)
+
+	return &MaxAndSkip[Obs, Act]{
+		Wrapper: Wrapper[Obs, Act]{Env: env},
+		skip:    skip,
+		maxPool: isVector && isBox && len(box.Shape()) >= 2,
+	}, nil
+}
+
+// Step repeats the action, summing the rewards, until skip steps are taken or the episode ends.
+func (m *MaxAndSkip[Obs, Act]) Step(ctx context.Context, action Act) (Obs, float64, bool, bool, gym.Info, error) {
+	var (
+		obs                   Obs
+		prev                  []float64 // Copy of the observation preceding the last step, when max-pooling
+		total                 float64
+		terminated, truncated bool
+		info                  gym.Info
+	)
+
+	for i := 0; i < m.skip; i++ {
+		var (
+			reward float64
+			err    error
+		)
+		if m.maxPool && i > 0 {
+			prev = slices.Clone(any(obs).([]float64))
+		}
+		obs, reward, terminated, truncated, info, err = m.Env.Step(ctx, action)
+		if err != nil {
+			return obs, total, terminated, truncated, info, err
+		}
+		total += reward
+
+		if terminated || truncated {
+			break
+		}
+	}
+
+	if m.maxPool {
+		obs = any(maxPool(prev, any(obs).([]float64))).(Obs)
+	}
+	return obs, total, terminated, truncated, info, nil
+}
+
+// maxPool returns the element-wise maximum of two observations, or last if there is no previous observation.
+func maxPool(prev, last []float64) []float64 {
+	if len(prev) != len(last) {
+		return last
+	}
+
+	pooled := make([]float64, len(last))
+	for i := range last {
+		pooled[i] = max(prev[i], last[i])
+	}
+	return pooled
+}
@@ -0,0 +1,96 @@
+package wrappers_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/envs/wrappers"
+	"github.com/gocnn/gym/space"
+)
+
+func TestMaxAndSkip(t *testing.T) {
+	inner := newScriptedEnv(t, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+	env, err := wrappers.NewMaxAndSkip[[]float64, int](inner, 4)
+	if err != nil {
+		t.Fatalf("NewMaxAndSkip failed: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, _, err := env.Reset(ctx, nil, nil); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+
+	// The inner environment observes its step count, so the observation tells how far it advanced;
+	// the last step is cut short by the end of the episode
+	want := []struct {
+		obs        float64
+		reward     float64
+		terminated bool
+	}{
+		{4, 1 + 2 + 3 + 4, false},
+		{8, 5 + 6 + 7 + 8, false},
+		{9, 9, true},
+	}
+	for i, w := range want {
+		obs, reward, terminated, _, _, err := env.Step(ctx, 1)
+		if err != nil {
+			t.Fatalf("Step failed: %v", err)
+		}
+		if obs[0] != w.obs || reward != w.reward || terminated != w.terminated {
+			t.Errorf("step %d = (%v, %f, %v), want ([%v], %f, %v)", i, obs, reward, terminated, w.obs, w.reward, w.terminated)
+		}
+	}
+	if len(inner.actions) != 9 {
+		t.Errorf("inner environment received %d actions, want 9", len(inner.actions))
+	}
+
+	if _, err := wrappers.NewMaxAndSkip[[]float64, int](inner, 0); err == nil {
+		t.Error("NewMaxAndSkip with skip 0 succeeded, expected an error")
+	}
+}
+
+// flickerEnv is a scriptedEnv with a 1x2 image observation space whose steps light alternate pixels, writing
+// every observation into the same slice.
+type flickerEnv struct {
+	*scriptedEnv
+	frame            []float64
+	observationSpace *space.Box
+}
+
+func (e *flickerEnv) Step(ctx context.Context, action int) ([]float64, float64, bool, bool, gym.Info, error) {
+	_, reward, terminated, truncated, info, err := e.scriptedEnv.Step(ctx, action)
+	e.frame[0], e.frame[1] = 0, 0
+	e.frame[e.steps%2] = 10
+	return e.frame, reward, terminated, truncated, info, err
+}
+
+func (e *flickerEnv) ObservationSpace() gym.Space[[]float64] { return e.observationSpace }
+
+func TestMaxAndSkipPoolsReusedObservations(t *testing.T) {
+	inner := newScriptedEnv(t, 0, 0, 0, 0)
+	observationSpace, err := space.NewBoxWithRNG(inner.rng.Derive(), 0.0, 255.0, []int{1, 2})
+	if err != nil {
+		t.Fatalf("NewBoxWithRNG failed: %v", err)
+	}
+	env, err := wrappers.NewMaxAndSkip[[]float64, int](&flickerEnv{
+		scriptedEnv:      inner,
+		frame:            make([]float64, 2),
+		observationSpace: observationSpace,
+	}, 2)
+	if err != nil {
+		t.Fatalf("NewMaxAndSkip failed: %v", err)
+	}
+
+	if _, _, err := env.Reset(context.Background(), nil, nil); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	obs, _, _, _, _, err := env.Step(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+	if want := []float64{10, 10}; !slices.Equal(obs, want) {
+		t.Errorf("max-pooled observation = %v, want %v", obs, want)
+	}
+}
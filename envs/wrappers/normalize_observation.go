@@ -0,0 +1,151 @@
+package wrappers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/space"
+)
+
+// RunningStats is a serializable snapshot of running mean and variance statistics.
+//
+// It can be saved with a checkpoint and restored later to resume normalization with the same statistics.
+type RunningStats struct {
+	Mean  []float64 `json:"mean"`
+	Var   []float64 `json:"var"`
+	Count float64   `json:"count"`
+}
+
+// NormalizeObservation normalizes observations to be centered at the mean with unit variance.
+//
+// The wrapper maintains a running estimate of the mean and variance of every observation dimension
+// and returns (obs - mean) / sqrt(var + epsilon). The statistics are updated on every Step and Reset
+// while training, and frozen otherwise.
+type NormalizeObservation[Act any] struct {
+	Wrapper[[]float64, Act]
+
+	epsilon          float64 // Stability parameter added to the variance
+	obsRMS           *runningMeanStd
+	training         bool // Whether the statistics are updated
+	observationSpace gym.Space[[]float64]
+}
+
+// NewNormalizeObservation creates a new NormalizeObservation wrapper.
+//
+// Parameters:
+//   - env: The environment to wrap, with a Box observation space
+//   - epsilon: The stability parameter added to the variance, must be positive (commonly 1e-8)
+//
+// Returns:
+//   - A new NormalizeObservation wrapper
+//   - An error if epsilon is not positive or the observation space is not a Box
+func NewNormalizeObservation[Act any](env gym.Env[[]float64, Act], epsilon float64) (*NormalizeObservation[Act], error) {
+	if epsilon <= 0 {
+		return nil, fmt.Errorf("epsilon must be positive, got %f", epsilon)
+	}
+
+	box, ok := env.ObservationSpace().(*space.Box)
+	if !ok {
+		return nil, fmt.Errorf("observation space must be a Box, got %T", env.ObservationSpace())
+	}
+
+	// Normalized observations are unbounded
+	observationSpace, err := space.NewBoxWithRNG(spaceRNG(env), math.Inf(-1), math.Inf(1), box.Shape())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create observation space: %w", err)
+	}
+
+	return &NormalizeObservation[Act]{
+		Wrapper:          Wrapper[[]float64, Act]{Env: env},
+		epsilon:          epsilon,
+		obsRMS:           newRunningMeanStd(box.FlatDim()),
+		training:         true,
+		observationSpace: observationSpace,
+	}, nil
+}
+
+// Step steps the environment and normalizes the observation.
+func (n *NormalizeObservation[Act]) Step(ctx context.Context, action Act) ([]float64, float64, bool, bool, gym.Info, error) {
+	obs, reward, terminated, truncated, info, err := n.Env.Step(ctx, action)
+	if err != nil {
+		return obs, reward, terminated, truncated, info, err
+	}
+	return n.observe(obs), reward, terminated, truncated, info, nil
+}
+
+// Reset resets the environment and normalizes the initial observation.
+func (n *NormalizeObservation[Act]) Reset(ctx context.Context, seed *int64, options gym.Info) ([]float64, gym.Info, error) {
+	obs, info, err := n.Env.Reset(ctx, seed, options)
+	if err != nil {
+		return obs, info, err
+	}
+	return n.observe(obs), info, nil
+}
+
+// observe updates the statistics with an observation while training and normalizes it.
+func (n *NormalizeObservation[Act]) observe(obs []float64) []float64 {
+	if n.training {
+		n.obsRMS.update(obs)
+	}
+	return n.Normalize(obs)
+}
+
+// Normalize normalizes an observation with the current statistics.
+func (n *NormalizeObservation[Act]) Normalize(obs []float64) []float64 {
+	normalized := make([]float64, len(obs))
+	for i, val := range obs {
+		normalized[i] = (val - n.obsRMS.mean[i]) / n.obsRMS.std(i, n.epsilon)
+	}
+	return normalized
+}
+
+// ObservationSpace returns an unbounded Box with the shape of the wrapped observation space.
+func (n *NormalizeObservation[Act]) ObservationSpace() gym.Space[[]float64] {
+	return n.observationSpace
+}
+
+// SetTraining sets whether the statistics are updated on every Step and Reset.
+//
+// Disabling training freezes the statistics, e.g. during evaluation.
+func (n *NormalizeObservation[Act]) SetTraining(training bool) {
+	n.training = training
+}
+
+// Stats returns a snapshot of the current statistics.
+func (n *NormalizeObservation[Act]) Stats() RunningStats {
+	return RunningStats{
+		Mean:  slices.Clone(n.obsRMS.mean),
+		Var:   slices.Clone(n.obsRMS.vari),
+		Count: n.obsRMS.count,
+	}
+}
+
+// SetStats replaces the current statistics, e.g. with a snapshot restored from a checkpoint.
+//
+// Parameters:
+//   - stats: The statistics to restore
+//
+// Returns:
+//   - An error if the statistics do not match the observation shape or are invalid
+func (n *NormalizeObservation[Act]) SetStats(stats RunningStats) error {
+	dim := len(n.obsRMS.mean)
+	if len(stats.Mean) != dim || len(stats.Var) != dim {
+		return fmt.Errorf("statistics must have length %d, got %d and %d", dim, len(stats.Mean), len(stats.Var))
+	}
+	if stats.Count <= 0 {
+		return fmt.Errorf("count must be positive, got %f", stats.Count)
+	}
+	for i, v := range stats.Var {
+		if v < 0 {
+			return fmt.Errorf("variance must be non-negative, got %f at index %d", v, i)
+		}
+	}
+
+	n.obsRMS.mean = slices.Clone(stats.Mean)
+	n.obsRMS.vari = slices.Clone(stats.Var)
+	n.obsRMS.count = stats.Count
+	return nil
+}
@@ -0,0 +1,79 @@
+package wrappers_test
+
+import (
+	"context"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/gocnn/gym/envs/wrappers"
+)
+
+func TestNormalizeObservationStats(t *testing.T) {
+	const steps = 50
+	env, err := wrappers.NewNormalizeObservation[int](newScriptedEnv(t, make([]float64, steps)...), 1e-8)
+	if err != nil {
+		t.Fatalf("NewNormalizeObservation failed: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, _, err := env.Reset(ctx, nil, nil); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	for range steps {
+		if _, _, _, _, _, err := env.Step(ctx, 0); err != nil {
+			t.Fatalf("Step failed: %v", err)
+		}
+	}
+
+	// The wrapper observed 0 on Reset and 1 to steps on Step
+	var mean, variance float64
+	for x := range steps + 1 {
+		mean += float64(x)
+	}
+	mean /= steps + 1
+	for x := range steps + 1 {
+		variance += (float64(x) - mean) * (float64(x) - mean)
+	}
+	variance /= steps + 1
+
+	stats := env.Stats()
+	if math.Abs(stats.Mean[0]-mean) > 1e-3 || math.Abs(stats.Var[0]-variance) > 1e-3*variance {
+		t.Errorf("running mean and variance = %f, %f, want %f, %f", stats.Mean[0], stats.Var[0], mean, variance)
+	}
+	if math.Abs(stats.Count-(steps+1)) > 1e-3 {
+		t.Errorf("count = %f, want %d", stats.Count, steps+1)
+	}
+
+	// The statistics restored into a fresh wrapper normalize identically
+	restored, err := wrappers.NewNormalizeObservation[int](newScriptedEnv(t, 0), 1e-8)
+	if err != nil {
+		t.Fatalf("NewNormalizeObservation failed: %v", err)
+	}
+	if err := restored.SetStats(stats); err != nil {
+		t.Fatalf("SetStats failed: %v", err)
+	}
+	if got := restored.Stats(); !slices.Equal(got.Mean, stats.Mean) || !slices.Equal(got.Var, stats.Var) || got.Count != stats.Count {
+		t.Errorf("Stats() after SetStats = %+v, want %+v", got, stats)
+	}
+	obs := []float64{12.5}
+	if got, want := restored.Normalize(obs), env.Normalize(obs); !slices.Equal(got, want) {
+		t.Errorf("restored Normalize(%v) = %v, want %v", obs, got, want)
+	}
+
+	// The snapshot is a copy
+	stats.Mean[0] = 1000
+	if env.Stats().Mean[0] == 1000 {
+		t.Error("modifying the snapshot modified the statistics of the wrapper")
+	}
+
+	for _, invalid := range []wrappers.RunningStats{
+		{Mean: []float64{0, 0}, Var: []float64{1, 1}, Count: 1},
+		{Mean: []float64{0}, Var: []float64{1}, Count: 0},
+		{Mean: []float64{0}, Var: []float64{-1}, Count: 1},
+	} {
+		if err := restored.SetStats(invalid); err == nil {
+			t.Errorf("SetStats(%+v) succeeded, expected an error", invalid)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package wrappers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gocnn/gym"
+)
+
+// NormalizeReward normalizes rewards so that their discounted return has approximately unit variance.
+//
+// The wrapper maintains a running estimate of the variance of the discounted return and divides every
+// reward by its standard deviation. Normalized rewards can be converted back to the original scale for
+// logging and evaluation reports with Unnormalize.
+type NormalizeReward[Obs any, Act any] struct {
+	Wrapper[Obs, Act]
+
+	gamma            float64 // Discount factor of the return
+	epsilon          float64 // Stability parameter added to the variance
+	discountedReturn float64 // Discounted return of the current episode
+	returnRMS        *runningMeanStd
+	training         bool // Whether the statistics are updated
+}
+
+// NewNormalizeReward creates a new NormalizeReward wrapper.
+//
+// Parameters:
+//   - env: The environment to wrap
+//   - gamma: The discount factor of the return, in [0, 1] (commonly 0.99)
+//   - epsilon: The stability parameter added to the variance, must be positive (commonly 1e-8)
+//
+// Returns:
+//   - A new NormalizeReward wrapper
+//   - An error if gamma or epsilon is out of range
+func NewNormalizeReward[Obs any, Act any](env gym.Env[Obs, Act], gamma, epsilon float64) (*NormalizeReward[Obs, Act], error) {
+	if gamma < 0 || gamma > 1 {
+		return nil, fmt.Errorf("gamma must be in [0, 1], got %f", gamma)
+	}
+	if epsilon <= 0 {
+		return nil, fmt.Errorf("epsilon must be positive, got %f", epsilon)
+	}
+
+	return &NormalizeReward[Obs, Act]{
+		Wrapper:   Wrapper[Obs, Act]{Env: env},
+		gamma:     gamma,
+		epsilon:   epsilon,
+		returnRMS: newRunningMeanStd(1),
+		training:  true,
+	}, nil
+}
+
+// Step steps the environment and normalizes the reward.
+func (n *NormalizeReward[Obs, Act]) Step(ctx context.Context, action Act) (Obs, float64, bool, bool, gym.Info, error) {
+	obs, reward, terminated, truncated, info, err := n.Env.Step(ctx, action)
+	if err != nil {
+		return obs, reward, terminated, truncated, info, err
+	}
+
+	if n.training {
+		n.discountedReturn = n.discountedReturn*n.gamma + reward
+		n.returnRMS.update([]float64{n.discountedReturn})
+	}
+
+	return obs, n.Normalize(reward), terminated, truncated, info, nil
+}
+
+// Reset resets the environment and the discounted return of the episode.
+func (n *NormalizeReward[Obs, Act]) Reset(ctx context.Context, seed *int64, options gym.Info) (Obs, gym.Info, error) {
+	n.discountedReturn = 0
+	return n.Env.Reset(ctx, seed, options)
+}
+
+// Normalize scales a reward with the current statistics.
+func (n *NormalizeReward[Obs, Act]) Normalize(reward float64) float64 {
+	return reward / n.returnRMS.std(0, n.epsilon)
+}
+
+// Unnormalize converts a normalized reward back to the original reward scale.
+//
+// The inversion uses the current statistics, which keep changing during training. It therefore only
+// recovers the exact original reward if no update happened since the reward was normalized; for returns
+// accumulated over many steps it gives the original scale as estimated now.
+func (n *NormalizeReward[Obs, Act]) Unnormalize(reward float64) float64 {
+	return reward * n.returnRMS.std(0, n.epsilon)
+}
+
+// SetTraining sets whether the statistics are updated on every Step.
+//
+// Disabling training freezes the statistics, e.g. during evaluation.
+func (n *NormalizeReward[Obs, Act]) SetTraining(training bool) {
+	n.training = training
+}
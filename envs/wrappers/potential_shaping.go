@@ -0,0 +1,78 @@
+package wrappers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gocnn/gym"
+)
+
+// PotentialShaping adds potential-based shaping to the rewards of an environment.
+//
+// Each Step adds gamma*phi(nextObs) - phi(obs) to the reward, where obs is the observation preceding the step,
+// starting with the observation returned by Reset. As shown by Ng, Harada and Russell in "Policy invariance
+// under reward transformations", this shaping preserves the optimal policy: the shaping terms of an episode
+// telescope, so its discounted return only changes by gamma^T*phi(sT) - phi(s0).
+type PotentialShaping[Obs any, Act any] struct {
+	Wrapper[Obs, Act]
+
+	phi     func(Obs) float64 // Potential function
+	gamma   float64           // Discount factor
+	lastPhi float64           // Potential of the observation preceding the next step
+	hasLast bool              // Whether Reset has provided an initial observation
+}
+
+// NewPotentialShaping creates a new PotentialShaping wrapper.
+//
+// Parameters:
+//   - env: The environment to wrap
+//   - phi: The potential function of observations
+//   - gamma: The discount factor of the agent, in [0, 1]
+//
+// Returns:
+//   - A new PotentialShaping wrapper
+//   - An error if phi is nil or gamma is outside [0, 1]
+func NewPotentialShaping[Obs any, Act any](env gym.Env[Obs, Act], phi func(Obs) float64, gamma float64) (*PotentialShaping[Obs, Act], error) {
+	if phi == nil {
+		return nil, fmt.Errorf("phi must not be nil")
+	}
+	if gamma < 0 || gamma > 1 {
+		return nil, fmt.Errorf("gamma must be in [0, 1], got %f", gamma)
+	}
+
+	return &PotentialShaping[Obs, Act]{
+		Wrapper: Wrapper[Obs, Act]{Env: env},
+		phi:     phi,
+		gamma:   gamma,
+	}, nil
+}
+
+// Step steps the environment and adds the shaping term to the reward.
+//
+// Steps before the first Reset have no preceding observation and are not shaped.
+func (p *PotentialShaping[Obs, Act]) Step(ctx context.Context, action Act) (Obs, float64, bool, bool, gym.Info, error) {
+	obs, reward, terminated, truncated, info, err := p.Env.Step(ctx, action)
+	if err != nil {
+		return obs, reward, terminated, truncated, info, err
+	}
+
+	nextPhi := p.phi(obs)
+	if p.hasLast {
+		reward += p.gamma*nextPhi - p.lastPhi
+	}
+	p.lastPhi = nextPhi
+	p.hasLast = true
+	return obs, reward, terminated, truncated, info, nil
+}
+
+// Reset resets the environment and uses the initial observation as the reference of the next step.
+func (p *PotentialShaping[Obs, Act]) Reset(ctx context.Context, seed *int64, options gym.Info) (Obs, gym.Info, error) {
+	obs, info, err := p.Env.Reset(ctx, seed, options)
+	if err != nil {
+		return obs, info, err
+	}
+
+	p.lastPhi = p.phi(obs)
+	p.hasLast = true
+	return obs, info, nil
+}
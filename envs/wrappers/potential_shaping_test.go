@@ -0,0 +1,65 @@
+package wrappers_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/gocnn/gym/envs/wrappers"
+)
+
+// TestPotentialShapingTelescopes checks that the shaping terms of an episode telescope: the discounted shaped
+// return differs from the raw one by gamma^T*phi(sT) - phi(s0).
+func TestPotentialShapingTelescopes(t *testing.T) {
+	rewards := []float64{1, -2, 0.5, 3, 0, 1}
+	phi := func(obs []float64) float64 { return obs[0]*obs[0]/10 - 1 }
+
+	for _, gamma := range []float64{0.9, 1} {
+		env, err := wrappers.NewPotentialShaping[[]float64, int](newScriptedEnv(t, rewards...), phi, gamma)
+		if err != nil {
+			t.Fatalf("NewPotentialShaping failed: %v", err)
+		}
+
+		ctx := context.Background()
+		first, _, err := env.Reset(ctx, nil, nil)
+		if err != nil {
+			t.Fatalf("Reset failed: %v", err)
+		}
+
+		var raw, shaped float64
+		last := first
+		discount := 1.0
+		for i, reward := range rewards {
+			obs, got, terminated, _, _, err := env.Step(ctx, 0)
+			if err != nil {
+				t.Fatalf("Step failed: %v", err)
+			}
+			raw += discount * reward
+			shaped += discount * got
+			discount *= gamma
+			last = obs
+			if terminated != (i == len(rewards)-1) {
+				t.Fatalf("step %d terminated = %v", i, terminated)
+			}
+		}
+
+		// discount is now gamma^T
+		want := discount*phi(last) - phi(first)
+		if got := shaped - raw; math.Abs(got-want) > 1e-9 {
+			t.Errorf("gamma %v: shaped minus raw return = %f, want gamma^T*phi(sT) - phi(s0) = %f", gamma, got, want)
+		}
+	}
+}
+
+func TestPotentialShapingRejectsInvalidArguments(t *testing.T) {
+	env := newScriptedEnv(t, 1)
+	if _, err := wrappers.NewPotentialShaping[[]float64, int](env, nil, 0.9); err == nil {
+		t.Error("NewPotentialShaping with a nil phi succeeded, expected an error")
+	}
+	phi := func([]float64) float64 { return 0 }
+	for _, gamma := range []float64{-0.1, 1.1} {
+		if _, err := wrappers.NewPotentialShaping[[]float64, int](env, phi, gamma); err == nil {
+			t.Errorf("NewPotentialShaping with gamma %v succeeded, expected an error", gamma)
+		}
+	}
+}
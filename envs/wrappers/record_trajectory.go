@@ -0,0 +1,162 @@
+package wrappers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/data"
+)
+
+// RecordTrajectory records the transitions of every episode to a JSON Lines file for offline datasets.
+//
+// Transitions are buffered in memory until the episode terminates or is truncated, and the completed episode
+// is then appended to the file with one data.Record per transition, which data.LoadTransitions reads back.
+// Observations and actions are converted with the ToJSONable methods of the environment's spaces. The seed
+// and options of the Reset that started the episode are stored with its first transition, so that ReplayEnv
+// can reproduce the episode; options must therefore be JSON-serializable. Episodes interrupted by Reset or
+// Close are discarded.
+type RecordTrajectory[Obs any, Act any] struct {
+	Wrapper[Obs, Act]
+
+	path     string                      // Path of the JSON Lines file
+	lastObs  Obs                         // Observation preceding the next step
+	seed     *int64                      // Seed of the Reset that started the current episode
+	options  gym.Info                    // Options of the Reset that started the current episode
+	buffer   []data.Transition[Obs, Act] // Transitions of the current episode
+	episodes int                         // Number of episodes written
+}
+
+// NewRecordTrajectory creates a new RecordTrajectory wrapper.
+//
+// Parameters:
+//   - env: The environment to wrap
+//   - path: The path of the JSON Lines file, which is created if necessary and appended to
+//
+// Returns:
+//   - A new RecordTrajectory wrapper
+//   - An error if path is empty
+func NewRecordTrajectory[Obs any, Act any](env gym.Env[Obs, Act], path string) (*RecordTrajectory[Obs, Act], error) {
+	if path == "" {
+		return nil, fmt.Errorf("path must not be empty")
+	}
+
+	return &RecordTrajectory[Obs, Act]{
+		Wrapper: Wrapper[Obs, Act]{Env: env},
+		path:    path,
+	}, nil
+}
+
+// Step steps the environment and records the transition, writing the episode once it is done.
+func (r *RecordTrajectory[Obs, Act]) Step(ctx context.Context, action Act) (Obs, float64, bool, bool, gym.Info, error) {
+	obs, reward, terminated, truncated, info, err := r.Env.Step(ctx, action)
+	if err != nil {
+		return obs, reward, terminated, truncated, info, err
+	}
+
+	r.buffer = append(r.buffer, data.Transition[Obs, Act]{
+		Obs:        r.lastObs,
+		Action:     action,
+		Reward:     reward,
+		NextObs:    obs,
+		Terminated: terminated,
+		Truncated:  truncated,
+	})
+	r.lastObs = obs
+
+	if terminated || truncated {
+		if err := r.flush(); err != nil {
+			return obs, reward, terminated, truncated, info, err
+		}
+	}
+	return obs, reward, terminated, truncated, info, nil
+}
+
+// Reset resets the environment and starts recording a new episode.
+func (r *RecordTrajectory[Obs, Act]) Reset(ctx context.Context, seed *int64, options gym.Info) (Obs, gym.Info, error) {
+	obs, info, err := r.Env.Reset(ctx, seed, options)
+	if err != nil {
+		return obs, info, err
+	}
+
+	r.buffer = r.buffer[:0]
+	r.lastObs = obs
+	r.seed = nil
+	if seed != nil {
+		r.seed = new(int64)
+		*r.seed = *seed
+	}
+	r.options = maps.Clone(options)
+	return obs, info, nil
+}
+
+// flush appends the buffered episode to the file and clears the buffer.
+func (r *RecordTrajectory[Obs, Act]) flush() error {
+	defer func() { r.buffer = r.buffer[:0] }()
+
+	var lines []byte
+	for i, t := range r.buffer {
+		record, err := r.record(t)
+		if err != nil {
+			return fmt.Errorf("failed to record transition: %w", err)
+		}
+		if i == 0 {
+			record.Seed, record.Options = r.seed, r.options
+		}
+		line, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("failed to encode transition: %w", err)
+		}
+		lines = append(append(lines, line...), '\n')
+	}
+
+	f, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open trajectory file: %w", err)
+	}
+	if _, err := f.Write(lines); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write trajectory file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close trajectory file: %w", err)
+	}
+
+	r.episodes++
+	return nil
+}
+
+// record converts a transition to its JSON Lines record.
+func (r *RecordTrajectory[Obs, Act]) record(t data.Transition[Obs, Act]) (*data.Record, error) {
+	obs, err := r.ObservationSpace().ToJSONable([]Obs{t.Obs, t.NextObs})
+	if err != nil {
+		return nil, err
+	}
+	action, err := r.ActionSpace().ToJSONable([]Act{t.Action})
+	if err != nil {
+		return nil, err
+	}
+
+	return &data.Record{
+		Episode:    r.episodes,
+		Obs:        obs[0],
+		Action:     action[0],
+		Reward:     t.Reward,
+		NextObs:    obs[1],
+		Terminated: t.Terminated,
+		Truncated:  t.Truncated,
+	}, nil
+}
+
+// Episodes returns the number of episodes written to the file.
+func (r *RecordTrajectory[Obs, Act]) Episodes() int {
+	return r.episodes
+}
+
+// Path returns the path of the JSON Lines file.
+func (r *RecordTrajectory[Obs, Act]) Path() string {
+	return r.path
+}
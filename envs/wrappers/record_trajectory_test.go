@@ -0,0 +1,76 @@
+package wrappers_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/gocnn/gym/data"
+	"github.com/gocnn/gym/envs/wrappers"
+)
+
+func TestRecordTrajectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trajectory.jsonl")
+	env, err := wrappers.NewRecordTrajectory[[]float64, int](newScriptedEnv(t, 1, 2, 3), path)
+	if err != nil {
+		t.Fatalf("NewRecordTrajectory failed: %v", err)
+	}
+
+	ctx := context.Background()
+	seed := int64(4)
+	if _, _, err := env.Reset(ctx, &seed, nil); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	actions := []int{1, 0, 1}
+	for _, action := range actions {
+		if _, _, _, _, _, err := env.Step(ctx, action); err != nil {
+			t.Fatalf("Step failed: %v", err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open the trajectory: %v", err)
+	}
+	defer f.Close()
+	var records []data.Record
+	for scanner := bufio.NewScanner(f); scanner.Scan(); {
+		var record data.Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("failed to decode line %q: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 3 {
+		t.Fatalf("recorded %d lines after 3 steps, want 3", len(records))
+	}
+	if records[0].Seed == nil || *records[0].Seed != seed || records[1].Seed != nil {
+		t.Errorf("record seeds = %v, %v, want %d on the first record only", records[0].Seed, records[1].Seed, seed)
+	}
+
+	transitions, err := data.LoadTransitions(path, env.ObservationSpace(), env.ActionSpace())
+	if err != nil {
+		t.Fatalf("LoadTransitions failed: %v", err)
+	}
+	if len(transitions) != 3 {
+		t.Fatalf("loaded %d transitions, want 3", len(transitions))
+	}
+	for i, tr := range transitions {
+		// Step i of the scripted environment observes i+1 and is rewarded with i+1
+		want := data.Transition[[]float64, int]{
+			Obs:        []float64{float64(i)},
+			Action:     actions[i],
+			Reward:     float64(i + 1),
+			NextObs:    []float64{float64(i + 1)},
+			Terminated: i == 2,
+		}
+		if !slices.Equal(tr.Obs, want.Obs) || !slices.Equal(tr.NextObs, want.NextObs) || tr.Action != want.Action ||
+			tr.Reward != want.Reward || tr.Terminated != want.Terminated || tr.Truncated {
+			t.Errorf("transition %d = %+v, want %+v", i, tr, want)
+		}
+	}
+}
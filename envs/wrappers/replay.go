@@ -0,0 +1,83 @@
+package wrappers
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/data"
+)
+
+// ReplayEnv resets the wrapped environment with the seeds and options of recorded episodes.
+//
+// Every Reset starts the next recorded episode with the seed and options it was recorded with, ignoring the
+// ones it is given, so that stepping with the actions returned by Actions reproduces the recorded transitions
+// exactly. This also covers options that shape the initial state, such as the reset bounds of CartPole.
+// Episodes are typically loaded with data.LoadEpisodes from a file written by RecordTrajectory.
+type ReplayEnv[Obs any, Act any] struct {
+	Wrapper[Obs, Act]
+
+	episodes []data.Episode[Obs, Act] // Recorded episodes to replay
+	next     int                      // Index of the episode started by the next Reset
+}
+
+// NewReplayEnv creates a new ReplayEnv wrapper.
+//
+// Parameters:
+//   - env: The environment to wrap, configured as the recorded one
+//   - episodes: The recorded episodes to replay in order
+//
+// Returns:
+//   - A new ReplayEnv wrapper
+//   - An error if there are no episodes
+func NewReplayEnv[Obs any, Act any](env gym.Env[Obs, Act], episodes []data.Episode[Obs, Act]) (*ReplayEnv[Obs, Act], error) {
+	if len(episodes) == 0 {
+		return nil, fmt.Errorf("no episodes to replay")
+	}
+
+	return &ReplayEnv[Obs, Act]{
+		Wrapper:  Wrapper[Obs, Act]{Env: env},
+		episodes: episodes,
+	}, nil
+}
+
+// Reset starts the next recorded episode with its recorded seed and options.
+//
+// The given seed and options are ignored. It fails once every recorded episode has been started.
+func (r *ReplayEnv[Obs, Act]) Reset(ctx context.Context, _ *int64, _ gym.Info) (Obs, gym.Info, error) {
+	if r.next >= len(r.episodes) {
+		var zero Obs
+		return zero, nil, fmt.Errorf("all %d recorded episodes have been replayed", len(r.episodes))
+	}
+
+	episode := r.episodes[r.next]
+	obs, info, err := r.Env.Reset(ctx, episode.Seed, maps.Clone(episode.Options))
+	if err != nil {
+		return obs, info, err
+	}
+	r.next++
+	return obs, info, nil
+}
+
+// Actions returns the recorded actions of the current episode, in order.
+//
+// Returns:
+//   - The actions, or nil before the first Reset
+func (r *ReplayEnv[Obs, Act]) Actions() []Act {
+	if r.next == 0 {
+		return nil
+	}
+
+	transitions := r.episodes[r.next-1].Transitions
+	actions := make([]Act, len(transitions))
+	for i, t := range transitions {
+		actions[i] = t.Action
+	}
+	return actions
+}
+
+// Remaining returns the number of recorded episodes not yet started.
+func (r *ReplayEnv[Obs, Act]) Remaining() int {
+	return len(r.episodes) - r.next
+}
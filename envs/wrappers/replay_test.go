@@ -0,0 +1,106 @@
+package wrappers_test
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/data"
+	"github.com/gocnn/gym/envs/wrappers"
+)
+
+// runEpisode resets env with seed and options and steps it until the episode ends, taking the given actions
+// in order or, without actions, actions sampled from the action space. It returns the observations after Reset
+// and after each step.
+func runEpisode(t *testing.T, env gym.Env[[]float64, int], seed *int64, options gym.Info, actions func() []int) [][]float64 {
+	t.Helper()
+
+	ctx := context.Background()
+	obs, _, err := env.Reset(ctx, seed, options)
+	if err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	var recorded []int
+	if actions != nil {
+		recorded = actions()
+	}
+
+	observations := [][]float64{obs}
+	for step := 0; ; step++ {
+		var action int
+		if recorded != nil {
+			action = recorded[step]
+		} else if action, err = env.ActionSpace().Sample(nil, nil); err != nil {
+			t.Fatalf("Sample failed: %v", err)
+		}
+
+		obs, _, terminated, truncated, _, err := env.Step(ctx, action)
+		if err != nil {
+			t.Fatalf("Step failed: %v", err)
+		}
+		observations = append(observations, obs)
+		if terminated || truncated || (recorded != nil && step == len(recorded)-1) {
+			return observations
+		}
+	}
+}
+
+func TestReplayEnvReproducesResetOptions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trajectory.jsonl")
+	recorder, err := wrappers.NewRecordTrajectory[[]float64, int](newCartPole(t), path)
+	if err != nil {
+		t.Fatalf("NewRecordTrajectory failed: %v", err)
+	}
+
+	seeds := []int64{3, 7}
+	options := []gym.Info{
+		{"low": []float64{-0.2, -0.1, -0.1, -0.1}, "high": []float64{0.2, 0.1, 0.1, 0.1}},
+		{"low": -0.01, "high": 0.01},
+	}
+	var recorded [][][]float64
+	for i := range seeds {
+		recorded = append(recorded, runEpisode(t, recorder, &seeds[i], options[i], nil))
+	}
+
+	episodes, err := data.LoadEpisodes(path, recorder.ObservationSpace(), recorder.ActionSpace())
+	if err != nil {
+		t.Fatalf("LoadEpisodes failed: %v", err)
+	}
+	if len(episodes) != len(seeds) {
+		t.Fatalf("loaded %d episodes, want %d", len(episodes), len(seeds))
+	}
+	for i, episode := range episodes {
+		if episode.Seed == nil || *episode.Seed != seeds[i] {
+			t.Errorf("episode %d has seed %v, want %d", i, episode.Seed, seeds[i])
+		}
+	}
+	if low, ok := episodes[0].Options["low"].([]float64); !ok || !slices.Equal(low, options[0]["low"].([]float64)) {
+		t.Errorf("episode 0 has low option %#v, want %v", episodes[0].Options["low"], options[0]["low"])
+	}
+
+	replay, err := wrappers.NewReplayEnv[[]float64, int](newCartPole(t), episodes)
+	if err != nil {
+		t.Fatalf("NewReplayEnv failed: %v", err)
+	}
+	for i := range episodes {
+		// The seed and options given to Reset are replaced by the recorded ones
+		replayed := runEpisode(t, replay, nil, nil, replay.Actions)
+		if len(replayed) != len(recorded[i]) {
+			t.Fatalf("episode %d replayed %d observations, want %d", i, len(replayed), len(recorded[i]))
+		}
+		for j := range replayed {
+			if !slices.Equal(replayed[j], recorded[i][j]) {
+				t.Fatalf("episode %d observation %d = %v, want %v", i, j, replayed[j], recorded[i][j])
+			}
+		}
+	}
+
+	if replay.Remaining() != 0 {
+		t.Errorf("Remaining() = %d after replaying every episode, want 0", replay.Remaining())
+	}
+	if _, _, err := replay.Reset(context.Background(), nil, nil); err == nil {
+		t.Error("Reset after the last recorded episode succeeded, expected an error")
+	}
+}
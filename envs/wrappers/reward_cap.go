@@ -0,0 +1,95 @@
+package wrappers
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/gocnn/gym"
+)
+
+// RewardCap truncates an episode once its cumulative reward reaches a maximum value.
+//
+// This is useful for early-stopping episodes that are already "solved" during evaluation,
+// e.g. stopping CartPole once it has balanced for 475 steps, matching its reward threshold.
+// The episode is truncated on the step at which the cumulative reward rises to the cap. The cap must be
+// positive: the return of a fresh episode is 0, so a non-positive cap would either fire before any reward is
+// collected or, for tasks whose rewards are all negative such as MountainCar, never fire at all.
+// The cumulative reward is cleared on every Reset.
+type RewardCap[Obs any, Act any] struct {
+	Wrapper[Obs, Act]
+
+	maxReward     float64 // Cumulative reward at which the episode is truncated
+	episodeReward float64 // Cumulative reward of the current episode
+}
+
+// NewRewardCap creates a new RewardCap wrapper.
+//
+// Parameters:
+//   - env: The environment to wrap
+//   - maxReward: The cumulative episode reward at which the episode is truncated, must be positive
+//
+// Returns:
+//   - A new RewardCap wrapper
+//   - An error if maxReward is not positive and finite
+func NewRewardCap[Obs any, Act any](env gym.Env[Obs, Act], maxReward float64) (*RewardCap[Obs, Act], error) {
+	if math.IsNaN(maxReward) || math.IsInf(maxReward, 0) {
+		return nil, fmt.Errorf("maxReward must be finite, got %f", maxReward)
+	}
+	if maxReward <= 0 {
+		return nil, fmt.Errorf("maxReward must be positive, got %f", maxReward)
+	}
+
+	return &RewardCap[Obs, Act]{
+		Wrapper:   Wrapper[Obs, Act]{Env: env},
+		maxReward: maxReward,
+	}, nil
+}
+
+// NewRewardCapFromSpec creates a new RewardCap wrapper capped at the reward threshold of a spec.
+//
+// Parameters:
+//   - env: The environment to wrap
+//   - spec: The spec of the environment, e.g. from gym.Spec, whose RewardThreshold is used as the cap
+//
+// Returns:
+//   - A new RewardCap wrapper
+//   - An error if spec is nil or has no positive reward threshold, e.g. for MountainCar
+func NewRewardCapFromSpec[Obs any, Act any](env gym.Env[Obs, Act], spec *gym.EnvSpec[Obs, Act]) (*RewardCap[Obs, Act], error) {
+	if spec == nil || spec.RewardThreshold == nil {
+		return nil, fmt.Errorf("spec declares no reward threshold")
+	}
+	return NewRewardCap(env, *spec.RewardThreshold)
+}
+
+// Step steps the environment and truncates the episode once the cumulative reward reaches the cap.
+func (r *RewardCap[Obs, Act]) Step(ctx context.Context, action Act) (Obs, float64, bool, bool, gym.Info, error) {
+	obs, reward, terminated, truncated, info, err := r.Env.Step(ctx, action)
+	if err != nil {
+		return obs, reward, terminated, truncated, info, err
+	}
+
+	previous := r.episodeReward
+	r.episodeReward += reward
+	if previous < r.maxReward && r.episodeReward >= r.maxReward {
+		truncated = true
+	}
+
+	return obs, reward, terminated, truncated, info, nil
+}
+
+// Reset resets the environment and clears the cumulative episode reward.
+func (r *RewardCap[Obs, Act]) Reset(ctx context.Context, seed *int64, options gym.Info) (Obs, gym.Info, error) {
+	r.episodeReward = 0
+	return r.Env.Reset(ctx, seed, options)
+}
+
+// MaxReward returns the cumulative episode reward at which the episode is truncated.
+func (r *RewardCap[Obs, Act]) MaxReward() float64 {
+	return r.maxReward
+}
+
+// EpisodeReward returns the cumulative reward of the current episode.
+func (r *RewardCap[Obs, Act]) EpisodeReward() float64 {
+	return r.episodeReward
+}
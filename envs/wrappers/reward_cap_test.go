@@ -0,0 +1,112 @@
+package wrappers_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/envs/wrappers"
+)
+
+// stepsUntilTruncated steps env with action 0 until it is truncated or terminated, returning the number of
+// steps taken and whether the episode was truncated.
+func stepsUntilTruncated(t *testing.T, env gym.Env[[]float64, int], maxSteps int) (int, bool) {
+	t.Helper()
+
+	ctx := context.Background()
+	seed := int64(1)
+	if _, _, err := env.Reset(ctx, &seed, nil); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	for step := 1; step <= maxSteps; step++ {
+		_, _, terminated, truncated, _, err := env.Step(ctx, 0)
+		if err != nil {
+			t.Fatalf("Step failed: %v", err)
+		}
+		if terminated || truncated {
+			return step, truncated
+		}
+	}
+	return maxSteps, false
+}
+
+func TestRewardCapTruncatesAtCap(t *testing.T) {
+	capped, err := wrappers.NewRewardCap[[]float64, int](newScriptedEnv(t, 1, 1, 1, 1, 1), 3)
+	if err != nil {
+		t.Fatalf("NewRewardCap failed: %v", err)
+	}
+
+	steps, truncated := stepsUntilTruncated(t, capped, 5)
+	if steps != 3 || !truncated {
+		t.Errorf("episode ended after %d steps with truncated = %v, want truncated after 3 steps", steps, truncated)
+	}
+	if capped.EpisodeReward() != 3 {
+		t.Errorf("EpisodeReward() = %f, want 3", capped.EpisodeReward())
+	}
+
+	if _, _, err := capped.Reset(context.Background(), nil, nil); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	if capped.EpisodeReward() != 0 {
+		t.Errorf("EpisodeReward() = %f after Reset, want 0", capped.EpisodeReward())
+	}
+}
+
+func TestRewardCapFromSpec(t *testing.T) {
+	spec, err := gym.Spec[[]float64, int]("CartPole-v0")
+	if err != nil {
+		t.Fatalf("Spec failed: %v", err)
+	}
+	env, err := gym.Make[[]float64, int]("CartPole-v0", nil, gym.WithNoWrappers())
+	if err != nil {
+		t.Fatalf("Make failed: %v", err)
+	}
+	defer env.Close()
+
+	capped, err := wrappers.NewRewardCapFromSpec(env, spec)
+	if err != nil {
+		t.Fatalf("NewRewardCapFromSpec failed: %v", err)
+	}
+	if capped.MaxReward() != 195 {
+		t.Fatalf("MaxReward() = %f, want the spec threshold 195", capped.MaxReward())
+	}
+
+	// CartPole-v0 terminates long before 195 steps when always pushed left
+	if _, truncated := stepsUntilTruncated(t, capped, 1000); truncated {
+		t.Error("episode of CartPole-v0 was truncated by the reward cap")
+	}
+}
+
+func TestRewardCapRejectsInvalidCaps(t *testing.T) {
+	env := newScriptedEnv(t, 1)
+	if _, err := wrappers.NewRewardCapFromSpec(env, &gym.EnvSpec[[]float64, int]{ID: "Stub-v0"}); err == nil {
+		t.Error("NewRewardCapFromSpec succeeded for a spec without a reward threshold, expected an error")
+	}
+	if _, err := wrappers.NewRewardCapFromSpec[[]float64, int](env, nil); err == nil {
+		t.Error("NewRewardCapFromSpec succeeded for a nil spec, expected an error")
+	}
+
+	// A non-positive cap would fire before any reward is collected or, with only negative rewards, never
+	for _, maxReward := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		if _, err := wrappers.NewRewardCap[[]float64, int](env, maxReward); err == nil {
+			t.Errorf("NewRewardCap succeeded with cap %f, expected an error", maxReward)
+		}
+	}
+}
+
+func TestRewardCapFromSpecRejectsNegativeThreshold(t *testing.T) {
+	spec, err := gym.Spec[[]float64, int]("MountainCar-v0")
+	if err != nil {
+		t.Fatalf("Spec failed: %v", err)
+	}
+	env, err := gym.Make[[]float64, int]("MountainCar-v0", nil, gym.WithNoWrappers())
+	if err != nil {
+		t.Fatalf("Make failed: %v", err)
+	}
+	defer env.Close()
+
+	if _, err := wrappers.NewRewardCapFromSpec(env, spec); err == nil {
+		t.Errorf("NewRewardCapFromSpec succeeded for the threshold %f of MountainCar-v0, expected an error", *spec.RewardThreshold)
+	}
+}
@@ -0,0 +1,46 @@
+package wrappers
+
+import "math"
+
+// runningMeanStd tracks the running mean and variance of a stream of vectors.
+//
+// Batches are merged with the parallel algorithm of Chan et al., which reduces to Welford's
+// online algorithm for batches of a single sample.
+type runningMeanStd struct {
+	mean  []float64
+	vari  []float64
+	count float64
+}
+
+// newRunningMeanStd creates running statistics for vectors of length n.
+//
+// The statistics start from a mean of 0 and a variance of 1 with a negligible count, so that
+// normalizing before any update is the identity.
+func newRunningMeanStd(n int) *runningMeanStd {
+	vari := make([]float64, n)
+	for i := range vari {
+		vari[i] = 1
+	}
+	return &runningMeanStd{
+		mean:  make([]float64, n),
+		vari:  vari,
+		count: 1e-4,
+	}
+}
+
+// update merges a single sample into the statistics.
+func (r *runningMeanStd) update(x []float64) {
+	total := r.count + 1
+	for i, val := range x {
+		delta := val - r.mean[i]
+		m2 := r.vari[i]*r.count + delta*delta*r.count/total
+		r.mean[i] += delta / total
+		r.vari[i] = m2 / total
+	}
+	r.count = total
+}
+
+// std returns the standard deviation of dimension i, regularized by epsilon.
+func (r *runningMeanStd) std(i int, epsilon float64) float64 {
+	return math.Sqrt(r.vari[i] + epsilon)
+}
@@ -0,0 +1,149 @@
+package wrappers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gocnn/gym"
+)
+
+// ErrEnvBusy is returned by StepTimeout when a previous call that exceeded its deadline is still running.
+var ErrEnvBusy = errors.New("environment is still running a call that exceeded its deadline")
+
+// StepTimeout runs every Step and Reset of an environment under a deadline.
+//
+// Each call is given a context derived from the caller's context with a timeout of d. If the wrapped
+// environment does not complete in time, the call returns immediately with an error wrapping
+// context.DeadlineExceeded, protecting training loops from hung remote or slow environments.
+//
+// The abandoned call keeps running in the background, so the wrapped environment should honor context
+// cancellation in Step and Reset in order to stop promptly. Until the abandoned call has returned,
+// further calls to Step, Reset, Render and Close fail with ErrEnvBusy instead of racing with it.
+type StepTimeout[Obs any, Act any] struct {
+	Wrapper[Obs, Act]
+
+	timeout time.Duration
+	pending chan struct{} // Closed when the last abandoned call returns, nil if there is none
+}
+
+// NewStepTimeout creates a new StepTimeout wrapper.
+//
+// Parameters:
+//   - env: The environment to wrap
+//   - d: The maximum duration of a single Step or Reset
+//
+// Returns:
+//   - A new StepTimeout wrapper
+//   - An error if d is not positive
+func NewStepTimeout[Obs any, Act any](env gym.Env[Obs, Act], d time.Duration) (*StepTimeout[Obs, Act], error) {
+	if d <= 0 {
+		return nil, fmt.Errorf("timeout must be positive, got %s", d)
+	}
+
+	return &StepTimeout[Obs, Act]{
+		Wrapper: Wrapper[Obs, Act]{Env: env},
+		timeout: d,
+	}, nil
+}
+
+// Step steps the environment, failing if it does not complete before the deadline.
+func (s *StepTimeout[Obs, Act]) Step(ctx context.Context, action Act) (Obs, float64, bool, bool, gym.Info, error) {
+	var (
+		obs                   Obs
+		reward                float64
+		terminated, truncated bool
+		info                  gym.Info
+		err                   error
+	)
+
+	if runErr := s.run(ctx, "step", func(ctx context.Context) {
+		obs, reward, terminated, truncated, info, err = s.Env.Step(ctx, action)
+	}); runErr != nil {
+		var zero Obs
+		return zero, 0, false, false, nil, runErr
+	}
+
+	return obs, reward, terminated, truncated, info, err
+}
+
+// Reset resets the environment, failing if it does not complete before the deadline.
+func (s *StepTimeout[Obs, Act]) Reset(ctx context.Context, seed *int64, options gym.Info) (Obs, gym.Info, error) {
+	var (
+		obs  Obs
+		info gym.Info
+		err  error
+	)
+
+	if runErr := s.run(ctx, "reset", func(ctx context.Context) {
+		obs, info, err = s.Env.Reset(ctx, seed, options)
+	}); runErr != nil {
+		var zero Obs
+		return zero, nil, runErr
+	}
+
+	return obs, info, err
+}
+
+// Render renders the environment, failing with ErrEnvBusy while an abandoned call is still running.
+func (s *StepTimeout[Obs, Act]) Render() (gym.RenderFrame, error) {
+	if s.busy() {
+		return nil, ErrEnvBusy
+	}
+	return s.Env.Render()
+}
+
+// Close closes the environment, failing with ErrEnvBusy while an abandoned call is still running.
+func (s *StepTimeout[Obs, Act]) Close() error {
+	if s.busy() {
+		return ErrEnvBusy
+	}
+	return s.Env.Close()
+}
+
+// Timeout returns the maximum duration of a single Step or Reset.
+func (s *StepTimeout[Obs, Act]) Timeout() time.Duration {
+	return s.timeout
+}
+
+// busy reports whether an abandoned call is still running, forgetting it once it has returned.
+func (s *StepTimeout[Obs, Act]) busy() bool {
+	if s.pending == nil {
+		return false
+	}
+	select {
+	case <-s.pending:
+		s.pending = nil
+		return false
+	default:
+		return true
+	}
+}
+
+// run calls fn under a context with the configured timeout and waits for it to return or for the deadline.
+func (s *StepTimeout[Obs, Act]) run(ctx context.Context, name string, fn func(ctx context.Context)) error {
+	if s.busy() {
+		return ErrEnvBusy
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(ctx)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.pending = done
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("%s did not complete within %s: %w", name, s.timeout, ctx.Err())
+		}
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,82 @@
+package wrappers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/envs/wrappers"
+)
+
+// hangingEnv is a scriptedEnv whose Step blocks, ignoring its context, until release is closed.
+type hangingEnv struct {
+	*scriptedEnv
+	release chan struct{}
+}
+
+func (e *hangingEnv) Step(ctx context.Context, action int) ([]float64, float64, bool, bool, gym.Info, error) {
+	<-e.release
+	return e.scriptedEnv.Step(ctx, action)
+}
+
+func TestStepTimeout(t *testing.T) {
+	inner := &hangingEnv{scriptedEnv: newScriptedEnv(t, 1, 1, 1), release: make(chan struct{})}
+	env, err := wrappers.NewStepTimeout[[]float64, int](inner, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewStepTimeout failed: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, _, err := env.Reset(ctx, nil, nil); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	if _, _, _, _, _, err := env.Step(ctx, 0); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Step of a hanging environment returned %v, want context.DeadlineExceeded", err)
+	}
+
+	// Every call fails while the abandoned Step is still running
+	if _, _, _, _, _, err := env.Step(ctx, 0); !errors.Is(err, wrappers.ErrEnvBusy) {
+		t.Errorf("Step while busy returned %v, want ErrEnvBusy", err)
+	}
+	if _, _, err := env.Reset(ctx, nil, nil); !errors.Is(err, wrappers.ErrEnvBusy) {
+		t.Errorf("Reset while busy returned %v, want ErrEnvBusy", err)
+	}
+	if _, err := env.Render(); !errors.Is(err, wrappers.ErrEnvBusy) {
+		t.Errorf("Render while busy returned %v, want ErrEnvBusy", err)
+	}
+	if err := env.Close(); !errors.Is(err, wrappers.ErrEnvBusy) {
+		t.Errorf("Close while busy returned %v, want ErrEnvBusy", err)
+	}
+
+	// Once the abandoned Step returns, the environment can be used again
+	close(inner.release)
+	deadline := time.Now().Add(time.Second)
+	for {
+		_, _, err = env.Reset(ctx, nil, nil)
+		if !errors.Is(err, wrappers.ErrEnvBusy) || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("Reset after the abandoned Step returned failed: %v", err)
+	}
+	obs, reward, _, _, _, err := env.Step(ctx, 0)
+	if err != nil {
+		t.Fatalf("Step after recovery failed: %v", err)
+	}
+	if obs[0] != 1 || reward != 1 {
+		t.Errorf("Step after recovery returned observation %v and reward %f, want [1] and 1", obs, reward)
+	}
+	if err := env.Close(); err != nil {
+		t.Errorf("Close after recovery failed: %v", err)
+	}
+}
+
+func TestStepTimeoutRejectsNonPositiveTimeout(t *testing.T) {
+	if _, err := wrappers.NewStepTimeout[[]float64, int](newScriptedEnv(t, 1), 0); err == nil {
+		t.Error("NewStepTimeout succeeded with a zero timeout, expected an error")
+	}
+}
@@ -0,0 +1,81 @@
+package wrappers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/rand"
+)
+
+// StickyAction repeats the previous action with a fixed probability instead of the agent's action.
+//
+// This implements the sticky actions of Machado et al. "Revisiting the Arcade Learning Environment", which
+// inject stochasticity to test the robustness of agents. The first action of every episode is never
+// repeated. The wrapper draws from its own RNG, which is seeded from the Reset seed, so that the dynamics
+// of the wrapped environment are unaffected.
+type StickyAction[Obs any, Act any] struct {
+	Wrapper[Obs, Act]
+
+	probability float64 // Probability of repeating the previous action
+	lastAction  Act     // Action forwarded on the previous step
+	hasLast     bool    // Whether an action was forwarded in the current episode
+	rng         *rand.RNG
+}
+
+// NewStickyAction creates a new StickyAction wrapper.
+//
+// Parameters:
+//   - env: The environment to wrap
+//   - probability: The probability of repeating the previous action, in [0, 1)
+//
+// Returns:
+//   - A new StickyAction wrapper
+//   - An error if probability is out of range
+func NewStickyAction[Obs any, Act any](env gym.Env[Obs, Act], probability float64) (*StickyAction[Obs, Act], error) {
+	if probability < 0 || probability >= 1 {
+		return nil, fmt.Errorf("probability must be in [0, 1), got %f", probability)
+	}
+
+	rng, _, err := rand.NewRNG(0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RNG: %w", err)
+	}
+
+	return &StickyAction[Obs, Act]{
+		Wrapper:     Wrapper[Obs, Act]{Env: env},
+		probability: probability,
+		rng:         rng,
+	}, nil
+}
+
+// Step forwards either the previous action, with the configured probability, or the given action.
+func (s *StickyAction[Obs, Act]) Step(ctx context.Context, action Act) (Obs, float64, bool, bool, gym.Info, error) {
+	if s.hasLast && s.probability > 0 && s.rng.Float64() < s.probability {
+		action = s.lastAction
+	}
+
+	s.lastAction = action
+	s.hasLast = true
+	return s.Env.Step(ctx, action)
+}
+
+// Reset resets the environment and forgets the previous action, seeding the wrapper's RNG if a seed is given.
+func (s *StickyAction[Obs, Act]) Reset(ctx context.Context, seed *int64, options gym.Info) (Obs, gym.Info, error) {
+	if seed != nil {
+		seq, err := rand.NewSeedSequenceExact(*seed)
+		if err != nil {
+			var zero Obs
+			return zero, nil, fmt.Errorf("failed to derive seed: %w", err)
+		}
+		if _, err := s.rng.Seed(seq.Spawn(1)[0]); err != nil {
+			var zero Obs
+			return zero, nil, fmt.Errorf("failed to seed RNG: %w", err)
+		}
+	}
+
+	var zero Act
+	s.lastAction = zero
+	s.hasLast = false
+	return s.Env.Reset(ctx, seed, options)
+}
@@ -0,0 +1,55 @@
+package wrappers_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/gocnn/gym/envs/wrappers"
+)
+
+func TestStickyActionRepeatFraction(t *testing.T) {
+	const steps, probability = 2000, 0.25
+
+	inner := newScriptedEnv(t, make([]float64, steps)...)
+	env, err := wrappers.NewStickyAction[[]float64, int](inner, probability)
+	if err != nil {
+		t.Fatalf("NewStickyAction failed: %v", err)
+	}
+
+	ctx := context.Background()
+	seed := int64(3)
+	if _, _, err := env.Reset(ctx, &seed, nil); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+
+	// Every action differs from the previously forwarded one, so a forwarded action equal to its predecessor
+	// was repeated by the wrapper
+	repeated := 0
+	for i := range steps {
+		action := 0
+		if i > 0 {
+			action = 1 - inner.actions[i-1]
+		}
+		if _, _, _, _, _, err := env.Step(ctx, action); err != nil {
+			t.Fatalf("Step failed: %v", err)
+		}
+		if i > 0 && inner.actions[i] == inner.actions[i-1] {
+			repeated++
+		}
+	}
+
+	// The standard deviation of the fraction is about 0.01
+	fraction := float64(repeated) / (steps - 1)
+	if math.Abs(fraction-probability) > 0.04 {
+		t.Errorf("repeated %.3f of the actions, want about %.2f", fraction, probability)
+	}
+}
+
+func TestStickyActionRejectsInvalidProbability(t *testing.T) {
+	for _, probability := range []float64{-0.1, 1} {
+		if _, err := wrappers.NewStickyAction[[]float64, int](newScriptedEnv(t, 0), probability); err == nil {
+			t.Errorf("NewStickyAction with probability %v succeeded, expected an error", probability)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package wrappers
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/gocnn/gym"
+)
+
+// TransformReward applies a function to every reward returned by Step.
+//
+// Common uses are clipping rewards to [-1, 1] or log-scaling them.
+type TransformReward[Obs any, Act any] struct {
+	Wrapper[Obs, Act]
+
+	fn func(float64) float64
+}
+
+// NewTransformReward creates a new TransformReward wrapper.
+//
+// Parameters:
+//   - env: The environment to wrap
+//   - fn: The function applied to every reward
+//
+// Returns:
+//   - A new TransformReward wrapper
+//   - An error if fn is nil
+func NewTransformReward[Obs any, Act any](env gym.Env[Obs, Act], fn func(float64) float64) (*TransformReward[Obs, Act], error) {
+	if fn == nil {
+		return nil, fmt.Errorf("reward function must not be nil")
+	}
+
+	return &TransformReward[Obs, Act]{
+		Wrapper: Wrapper[Obs, Act]{Env: env},
+		fn:      fn,
+	}, nil
+}
+
+// NewClipReward creates a TransformReward wrapper that clips rewards to [minReward, maxReward].
+//
+// Parameters:
+//   - env: The environment to wrap
+//   - minReward: The lower bound of the rewards
+//   - maxReward: The upper bound of the rewards
+//
+// Returns:
+//   - A new TransformReward wrapper
+//   - An error if minReward is greater than maxReward
+func NewClipReward[Obs any, Act any](env gym.Env[Obs, Act], minReward, maxReward float64) (*TransformReward[Obs, Act], error) {
+	if minReward > maxReward {
+		return nil, fmt.Errorf("min reward must not be greater than max reward, got %f and %f", minReward, maxReward)
+	}
+
+	return NewTransformReward(env, func(reward float64) float64 {
+		return math.Max(minReward, math.Min(reward, maxReward))
+	})
+}
+
+// Step steps the environment and transforms the reward.
+func (t *TransformReward[Obs, Act]) Step(ctx context.Context, action Act) (Obs, float64, bool, bool, gym.Info, error) {
+	obs, reward, terminated, truncated, info, err := t.Env.Step(ctx, action)
+	if err != nil {
+		return obs, reward, terminated, truncated, info, err
+	}
+	return obs, t.fn(reward), terminated, truncated, info, nil
+}
@@ -0,0 +1,60 @@
+package wrappers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gocnn/gym/envs/wrappers"
+)
+
+func TestTransformReward(t *testing.T) {
+	env, err := wrappers.NewTransformReward(newScriptedEnv(t, 1, -2, 3), func(reward float64) float64 {
+		return 10 * reward
+	})
+	if err != nil {
+		t.Fatalf("NewTransformReward failed: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, _, err := env.Reset(ctx, nil, nil); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	for i, want := range []float64{10, -20, 30} {
+		_, reward, _, _, _, err := env.Step(ctx, 0)
+		if err != nil {
+			t.Fatalf("Step failed: %v", err)
+		}
+		if reward != want {
+			t.Errorf("reward %d was transformed to %v, want %v", i, reward, want)
+		}
+	}
+
+	if _, err := wrappers.NewTransformReward(newScriptedEnv(t), nil); err == nil {
+		t.Error("NewTransformReward with a nil function succeeded, expected an error")
+	}
+}
+
+func TestClipReward(t *testing.T) {
+	env, err := wrappers.NewClipReward(newScriptedEnv(t, -5, 0.5, 5), -1, 1)
+	if err != nil {
+		t.Fatalf("NewClipReward failed: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, _, err := env.Reset(ctx, nil, nil); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	for i, want := range []float64{-1, 0.5, 1} {
+		_, reward, _, _, _, err := env.Step(ctx, 0)
+		if err != nil {
+			t.Fatalf("Step failed: %v", err)
+		}
+		if reward != want {
+			t.Errorf("reward %d was clipped to %v, want %v", i, reward, want)
+		}
+	}
+
+	if _, err := wrappers.NewClipReward(newScriptedEnv(t), 1, -1); err == nil {
+		t.Error("NewClipReward with min reward greater than max reward succeeded, expected an error")
+	}
+}
@@ -0,0 +1,166 @@
+package wrappers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/space"
+)
+
+// TransposeImage converts image observations between axis layouts, e.g. from HWC to CHW.
+//
+// Images are naturally represented in height-width-channel (HWC) layout, while frameworks such as
+// PyTorch expect channel-first (CHW) layout. The observation is a flat []float64 in row-major order
+// whose shape is given by the wrapped environment's Box observation space. The transposed observation
+// space keeps the data type of the wrapped one.
+type TransposeImage[Act any] struct {
+	Wrapper[[]float64, Act]
+
+	from             string // Axis layout of the wrapped environment's observations
+	to               string // Axis layout of the returned observations
+	index            []int  // index[i] is the position in the input of the i-th output element
+	observationSpace *space.Box
+}
+
+// NewTransposeImage creates a new TransposeImage wrapper.
+//
+// The layouts name each axis with a single letter, e.g. "HWC" or "CHW", and must be permutations
+// of each other with one letter per dimension of the observation space.
+//
+// Parameters:
+//   - env: The environment to wrap, whose observation space must be a Box
+//   - from: The axis layout of the wrapped environment's observations
+//   - to: The axis layout of the returned observations
+//
+// Returns:
+//   - A new TransposeImage wrapper
+//   - An error if the observation space is not a Box or the layouts are invalid
+func NewTransposeImage[Act any](env gym.Env[[]float64, Act], from, to string) (*TransposeImage[Act], error) {
+	box, ok := env.ObservationSpace().(*space.Box)
+	if !ok {
+		return nil, fmt.Errorf("observation space must be a Box, got %T", env.ObservationSpace())
+	}
+
+	shape := box.Shape()
+	if len(from) != len(shape) || len(to) != len(shape) {
+		return nil, fmt.Errorf("layouts %q and %q must have one axis per dimension of shape %v", from, to, shape)
+	}
+
+	axes := make([]int, len(to))
+	for i, axis := range to {
+		j := strings.IndexRune(from, axis)
+		if j < 0 || strings.Count(from, string(axis)) != 1 || strings.Count(to, string(axis)) != 1 {
+			return nil, fmt.Errorf("layout %q is not a permutation of %q", to, from)
+		}
+		axes[i] = j
+	}
+
+	size := 1
+	for _, dim := range shape {
+		size *= dim
+	}
+	low, high := box.Low(), box.High()
+	if len(low) != size {
+		return nil, fmt.Errorf("observation space has %d elements, but shape %v requires %d", len(low), shape, size)
+	}
+
+	index := transposeIndex(shape, axes)
+	newShape := make([]int, len(axes))
+	for i, axis := range axes {
+		newShape[i] = shape[axis]
+	}
+	observationSpace, err := space.NewBoxWithDTypeWithRNG(spaceRNG(env), box.DType(), transpose(low, index), transpose(high, index), newShape)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create observation space: %w", err)
+	}
+
+	return &TransposeImage[Act]{
+		Wrapper:          Wrapper[[]float64, Act]{Env: env},
+		from:             from,
+		to:               to,
+		index:            index,
+		observationSpace: observationSpace,
+	}, nil
+}
+
+// Step steps the environment and transposes the returned observation.
+func (t *TransposeImage[Act]) Step(ctx context.Context, action Act) ([]float64, float64, bool, bool, gym.Info, error) {
+	obs, reward, terminated, truncated, info, err := t.Env.Step(ctx, action)
+	if err != nil {
+		return nil, reward, terminated, truncated, info, err
+	}
+	if len(obs) != len(t.index) {
+		return nil, reward, terminated, truncated, info, fmt.Errorf("expected observation of length %d, got %d", len(t.index), len(obs))
+	}
+	return transpose(obs, t.index), reward, terminated, truncated, info, nil
+}
+
+// Reset resets the environment and transposes the initial observation.
+func (t *TransposeImage[Act]) Reset(ctx context.Context, seed *int64, options gym.Info) ([]float64, gym.Info, error) {
+	obs, info, err := t.Env.Reset(ctx, seed, options)
+	if err != nil {
+		return nil, info, err
+	}
+	if len(obs) != len(t.index) {
+		return nil, info, fmt.Errorf("expected observation of length %d, got %d", len(t.index), len(obs))
+	}
+	return transpose(obs, t.index), info, nil
+}
+
+// ObservationSpace returns the transposed observation space.
+func (t *TransposeImage[Act]) ObservationSpace() gym.Space[[]float64] {
+	return t.observationSpace
+}
+
+// From returns the axis layout of the wrapped environment's observations.
+func (t *TransposeImage[Act]) From() string {
+	return t.from
+}
+
+// To returns the axis layout of the returned observations.
+func (t *TransposeImage[Act]) To() string {
+	return t.to
+}
+
+// transposeIndex computes, for each element of the transposed array, its position in the original
+// row-major array of the given shape, where axes[i] is the original axis of the i-th transposed axis.
+func transposeIndex(shape, axes []int) []int {
+	// Row-major strides of the original array
+	strides := make([]int, len(shape))
+	stride := 1
+	for i := len(shape) - 1; i >= 0; i-- {
+		strides[i] = stride
+		stride *= shape[i]
+	}
+
+	index := make([]int, stride)
+	counter := make([]int, len(axes)) // Multi-index into the transposed array
+	for k := range index {
+		offset := 0
+		for i, axis := range axes {
+			offset += counter[i] * strides[axis]
+		}
+		index[k] = offset
+
+		// Increment the multi-index in row-major order
+		for i := len(axes) - 1; i >= 0; i-- {
+			counter[i]++
+			if counter[i] < shape[axes[i]] {
+				break
+			}
+			counter[i] = 0
+		}
+	}
+	return index
+}
+
+// transpose returns a new slice with x permuted according to index.
+func transpose(x []float64, index []int) []float64 {
+	result := make([]float64, len(index))
+	for i, j := range index {
+		result[i] = x[j]
+	}
+	return result
+}
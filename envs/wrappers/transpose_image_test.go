@@ -0,0 +1,106 @@
+package wrappers_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/envs/wrappers"
+	"github.com/gocnn/gym/rand"
+	"github.com/gocnn/gym/space"
+)
+
+// imageEnv is a stub environment observing a float32 image of shape [2, 3, 2] in HWC layout, whose elements
+// are 0 to 11 in row-major order.
+type imageEnv struct {
+	rng              *rand.RNG
+	actionSpace      *space.Discrete
+	observationSpace *space.Box
+}
+
+func newImageEnv(t *testing.T) *imageEnv {
+	t.Helper()
+
+	rng, _, err := rand.NewRNG(1)
+	if err != nil {
+		t.Fatalf("NewRNG failed: %v", err)
+	}
+	actionSpace, err := space.NewDiscreteWithRNG(rng.Derive(), 2)
+	if err != nil {
+		t.Fatalf("NewDiscreteWithRNG failed: %v", err)
+	}
+	observationSpace, err := space.NewBoxWithDTypeWithRNG(rng.Derive(), "float32", 0.0, 255.0, []int{2, 3, 2})
+	if err != nil {
+		t.Fatalf("NewBoxWithDTypeWithRNG failed: %v", err)
+	}
+	return &imageEnv{rng: rng, actionSpace: actionSpace, observationSpace: observationSpace}
+}
+
+func (e *imageEnv) image() []float64 {
+	image := make([]float64, 12)
+	for i := range image {
+		image[i] = float64(i)
+	}
+	return image
+}
+
+func (e *imageEnv) Step(context.Context, int) ([]float64, float64, bool, bool, gym.Info, error) {
+	return e.image(), 0, false, false, gym.Info{}, nil
+}
+
+func (e *imageEnv) Reset(context.Context, *int64, gym.Info) ([]float64, gym.Info, error) {
+	return e.image(), gym.Info{}, nil
+}
+
+func (e *imageEnv) Render() (gym.RenderFrame, error)       { return nil, nil }
+func (e *imageEnv) Close() error                           { return nil }
+func (e *imageEnv) ActionSpace() gym.Space[int]            { return e.actionSpace }
+func (e *imageEnv) ObservationSpace() gym.Space[[]float64] { return e.observationSpace }
+func (e *imageEnv) Metadata() gym.Metadata                 { return gym.Metadata{} }
+func (e *imageEnv) Unwrapped() gym.Env[[]float64, int]     { return e }
+func (e *imageEnv) GetRNG() *rand.RNG                      { return e.rng }
+
+func TestTransposeImageHWCToCHW(t *testing.T) {
+	env, err := wrappers.NewTransposeImage[int](newImageEnv(t), "HWC", "CHW")
+	if err != nil {
+		t.Fatalf("NewTransposeImage failed: %v", err)
+	}
+
+	box := env.ObservationSpace().(*space.Box)
+	if !slices.Equal(box.Shape(), []int{2, 2, 3}) {
+		t.Errorf("Shape() = %v, want [2 2 3]", box.Shape())
+	}
+	if box.DType() != "float32" {
+		t.Errorf("DType() = %q, want the float32 of the wrapped space", box.DType())
+	}
+
+	// Element (h, w, c) = 6h + 2w + c moves to (c, h, w)
+	want := []float64{0, 2, 4, 6, 8, 10, 1, 3, 5, 7, 9, 11}
+	ctx := context.Background()
+	obs, _, err := env.Reset(ctx, nil, nil)
+	if err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	if !slices.Equal(obs, want) {
+		t.Errorf("Reset observation = %v, want %v", obs, want)
+	}
+	obs, _, _, _, _, err = env.Step(ctx, 0)
+	if err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+	if !slices.Equal(obs, want) {
+		t.Errorf("Step observation = %v, want %v", obs, want)
+	}
+	if !env.ObservationSpace().Contains(obs) {
+		t.Errorf("transposed observation %v is not contained in the observation space", obs)
+	}
+}
+
+func TestTransposeImageRejectsInvalidLayouts(t *testing.T) {
+	for _, layouts := range [][2]string{{"HWC", "CHH"}, {"HWC", "CH"}, {"HW", "WH"}, {"HWC", "CHX"}} {
+		if _, err := wrappers.NewTransposeImage[int](newImageEnv(t), layouts[0], layouts[1]); err == nil {
+			t.Errorf("NewTransposeImage(%q, %q) succeeded, expected an error", layouts[0], layouts[1])
+		}
+	}
+}
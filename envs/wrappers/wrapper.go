@@ -0,0 +1,79 @@
+// Package wrappers provides environment wrappers that modify the behavior of an existing environment
+// without changing its underlying implementation.
+package wrappers
+
+import (
+	"context"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/rand"
+)
+
+// Wrapper wraps an environment to allow a modular transformation of the Step and Reset methods.
+//
+// Wrapper forwards every method of the Env interface to the wrapped environment. Concrete wrappers
+// embed it and override only the methods whose behavior they change.
+type Wrapper[Obs any, Act any] struct {
+	Env gym.Env[Obs, Act] // The wrapped environment
+}
+
+// Step forwards the action to the wrapped environment.
+func (w *Wrapper[Obs, Act]) Step(ctx context.Context, action Act) (Obs, float64, bool, bool, gym.Info, error) {
+	return w.Env.Step(ctx, action)
+}
+
+// Reset forwards the reset to the wrapped environment.
+func (w *Wrapper[Obs, Act]) Reset(ctx context.Context, seed *int64, options gym.Info) (Obs, gym.Info, error) {
+	return w.Env.Reset(ctx, seed, options)
+}
+
+// Render forwards rendering to the wrapped environment.
+func (w *Wrapper[Obs, Act]) Render() (gym.RenderFrame, error) {
+	return w.Env.Render()
+}
+
+// Close closes the wrapped environment.
+func (w *Wrapper[Obs, Act]) Close() error {
+	return w.Env.Close()
+}
+
+// ActionSpace returns the action space of the wrapped environment.
+func (w *Wrapper[Obs, Act]) ActionSpace() gym.Space[Act] {
+	return w.Env.ActionSpace()
+}
+
+// ObservationSpace returns the observation space of the wrapped environment.
+func (w *Wrapper[Obs, Act]) ObservationSpace() gym.Space[Obs] {
+	return w.Env.ObservationSpace()
+}
+
+// Metadata returns the metadata of the wrapped environment.
+func (w *Wrapper[Obs, Act]) Metadata() gym.Metadata {
+	return w.Env.Metadata()
+}
+
+// Unwrapped returns the base non-wrapped environment.
+func (w *Wrapper[Obs, Act]) Unwrapped() gym.Env[Obs, Act] {
+	return w.Env.Unwrapped()
+}
+
+// GetRNG returns the random number generator of the wrapped environment.
+func (w *Wrapper[Obs, Act]) GetRNG() *rand.RNG {
+	return w.Env.GetRNG()
+}
+
+// Inner returns the wrapped environment.
+func (w *Wrapper[Obs, Act]) Inner() gym.Env[Obs, Act] {
+	return w.Env
+}
+
+// spaceRNG returns the RNG of a space created by a wrapper, spawned from the RNG of the wrapped environment
+// so that wrappers do not depend on the default RNG. Spawning does not advance the RNG of the environment, and
+// every wrapper of the same environment gets a distinct RNG.
+func spaceRNG[Obs any, Act any](env gym.Env[Obs, Act]) *rand.RNG {
+	if rng := env.GetRNG(); rng != nil {
+		return rng.Spawn()
+	}
+	rng, _, _ := rand.NewRNG(0)
+	return rng
+}
@@ -0,0 +1,124 @@
+package wrappers_test
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/envs/classic"
+	"github.com/gocnn/gym/envs/wrappers"
+	"github.com/gocnn/gym/rand"
+	"github.com/gocnn/gym/space"
+)
+
+// newCartPole creates a CartPole environment, failing the test on error.
+func newCartPole(t *testing.T) *classic.CartPoleEnv {
+	t.Helper()
+
+	env, err := classic.NewCartPoleEnv(nil)
+	if err != nil {
+		t.Fatalf("NewCartPoleEnv failed: %v", err)
+	}
+	t.Cleanup(func() { env.Close() })
+	return env
+}
+
+// scriptedEnv is a stub environment with a Discrete(2) action space and a one-element Box observation space
+// in [-100, 100]. Step i of an episode, counting from 0, observes i+1 and is rewarded with rewards[i], the
+// episode terminating after the last reward. The actions it receives are recorded.
+type scriptedEnv struct {
+	rewards []float64
+	steps   int
+	actions []int // Actions received by Step since the environment was created
+
+	rng              *rand.RNG
+	actionSpace      *space.Discrete
+	observationSpace *space.Box
+}
+
+// newScriptedEnv creates a scriptedEnv rewarding its steps with rewards.
+func newScriptedEnv(t *testing.T, rewards ...float64) *scriptedEnv {
+	t.Helper()
+
+	rng, _, err := rand.NewRNG(1)
+	if err != nil {
+		t.Fatalf("NewRNG failed: %v", err)
+	}
+	actionSpace, err := space.NewDiscreteWithRNG(rng.Derive(), 2)
+	if err != nil {
+		t.Fatalf("NewDiscreteWithRNG failed: %v", err)
+	}
+	observationSpace, err := space.NewBoxWithRNG(rng.Derive(), []float64{-100}, []float64{100})
+	if err != nil {
+		t.Fatalf("NewBoxWithRNG failed: %v", err)
+	}
+	return &scriptedEnv{rewards: rewards, rng: rng, actionSpace: actionSpace, observationSpace: observationSpace}
+}
+
+func (e *scriptedEnv) Step(_ context.Context, action int) ([]float64, float64, bool, bool, gym.Info, error) {
+	reward := e.rewards[e.steps]
+	e.steps++
+	e.actions = append(e.actions, action)
+	return []float64{float64(e.steps)}, reward, e.steps == len(e.rewards), false, gym.Info{}, nil
+}
+
+func (e *scriptedEnv) Reset(context.Context, *int64, gym.Info) ([]float64, gym.Info, error) {
+	e.steps = 0
+	return []float64{0}, gym.Info{}, nil
+}
+
+func (e *scriptedEnv) Render() (gym.RenderFrame, error)       { return nil, nil }
+func (e *scriptedEnv) Close() error                           { return nil }
+func (e *scriptedEnv) ActionSpace() gym.Space[int]            { return e.actionSpace }
+func (e *scriptedEnv) ObservationSpace() gym.Space[[]float64] { return e.observationSpace }
+func (e *scriptedEnv) Metadata() gym.Metadata                 { return gym.Metadata{} }
+func (e *scriptedEnv) Unwrapped() gym.Env[[]float64, int]     { return e }
+func (e *scriptedEnv) GetRNG() *rand.RNG                      { return e.rng }
+
+func TestWrappingDoesNotAdvanceEnvRNG(t *testing.T) {
+	env := newCartPole(t)
+	before, err := env.GetRNG().State()
+	if err != nil {
+		t.Fatalf("State failed: %v", err)
+	}
+
+	if _, err := wrappers.NewNormalizeObservation[int](env, 1e-8); err != nil {
+		t.Fatalf("NewNormalizeObservation failed: %v", err)
+	}
+	if _, err := wrappers.NewClipObservation[int](env, []float64{-1, -1, -1, -1}, []float64{1, 1, 1, 1}); err != nil {
+		t.Fatalf("NewClipObservation failed: %v", err)
+	}
+
+	after, err := env.GetRNG().State()
+	if err != nil {
+		t.Fatalf("State failed: %v", err)
+	}
+	if !bytes.Equal(before, after) {
+		t.Error("creating wrappers advanced the RNG of the wrapped environment")
+	}
+}
+
+func TestStackedWrapperSpacesAreIndependent(t *testing.T) {
+	inner, err := wrappers.NewNormalizeObservation[int](newCartPole(t), 1e-8)
+	if err != nil {
+		t.Fatalf("NewNormalizeObservation failed: %v", err)
+	}
+	outer, err := wrappers.NewNormalizeObservation[int](inner, 1e-8)
+	if err != nil {
+		t.Fatalf("NewNormalizeObservation failed: %v", err)
+	}
+
+	a, err := inner.ObservationSpace().Sample(nil, nil)
+	if err != nil {
+		t.Fatalf("Sample failed: %v", err)
+	}
+	b, err := outer.ObservationSpace().Sample(nil, nil)
+	if err != nil {
+		t.Fatalf("Sample failed: %v", err)
+	}
+	if slices.Equal(a, b) {
+		t.Errorf("stacked wrappers sampled the same observation %v", a)
+	}
+}
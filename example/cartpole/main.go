@@ -19,7 +19,7 @@ func main() {
 	}
 	defer env.Close()
 
-	obs, _, err := env.Reset(context.Background(), 0, nil)
+	obs, _, err := env.Reset(context.Background(), nil, nil)
 	if err != nil {
 		log.Fatal(err)
 	}
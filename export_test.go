@@ -0,0 +1,22 @@
+package gym
+
+import (
+	"maps"
+	"testing"
+)
+
+// IsolateRegistry empties the registry for the duration of a test, restoring the registered environments
+// when the test ends. Tests calling it must not run in parallel.
+func IsolateRegistry(t testing.TB) {
+	registryMu.RLock()
+	saved := maps.Clone(registry)
+	registryMu.RUnlock()
+
+	resetRegistry()
+	t.Cleanup(func() {
+		resetRegistry()
+		registryMu.Lock()
+		defer registryMu.Unlock()
+		maps.Copy(registry, saved)
+	})
+}
@@ -1 +1,810 @@
+// Package gym provides the core interfaces of Gym for Go and a registry of environments.
+//
+// Environments are registered under an ID of the form "[namespace/]name[-vversion]", e.g. "CartPole-v1",
+// and created with Make, which returns a fresh instance of the environment each time it is called.
 package gym
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gocnn/gym/rand"
+)
+
+// EnvSpec is a specification for creating environments with Make.
+//
+// Specs are registered with Register and store the entry point used to construct the environment,
+// together with the information needed to configure it.
+type EnvSpec[Obs any, Act any] struct {
+	ID         string                                             // The environment ID used with Make
+	EntryPoint func(kwargs map[string]any) (Env[Obs, Act], error) // Constructs the environment from kwargs
+
+	Namespace string // The namespace of the environment, empty if none
+	Name      string // The name of the environment
+	Version   *int   // The version of the environment, nil if unversioned
+
+	RewardThreshold   *float64 // The reward threshold for considering the task solved, nil if none
+	Nondeterministic  bool     // Whether the environment is nondeterministic even after seeding
+	MaxEpisodeSteps   *int     // The maximum number of steps of an episode, nil if unlimited
+	OrderEnforce      bool     // Whether Reset must be called before Step
+	DisableEnvChecker bool     // Whether the environment checker is disabled
+
+	Kwargs map[string]any // Default keyword arguments passed to the entry point
+
+	force bool // Whether Register overwrites an existing spec with the same ID
+}
+
+// envSpecJSON is the JSON representation of an EnvSpec, without its entry point.
+type envSpecJSON struct {
+	ID                string         `json:"id"`
+	Namespace         string         `json:"namespace,omitempty"`
+	Name              string         `json:"name"`
+	Version           *int           `json:"version,omitempty"`
+	RewardThreshold   *float64       `json:"reward_threshold,omitempty"`
+	Nondeterministic  bool           `json:"nondeterministic"`
+	MaxEpisodeSteps   *int           `json:"max_episode_steps,omitempty"`
+	OrderEnforce      bool           `json:"order_enforce"`
+	DisableEnvChecker bool           `json:"disable_env_checker"`
+	Kwargs            map[string]any `json:"kwargs,omitempty"`
+}
+
+// MarshalJSON encodes the spec as JSON, omitting the entry point.
+func (spec *EnvSpec[Obs, Act]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(envSpecJSON{
+		ID:                spec.ID,
+		Namespace:         spec.Namespace,
+		Name:              spec.Name,
+		Version:           spec.Version,
+		RewardThreshold:   spec.RewardThreshold,
+		Nondeterministic:  spec.Nondeterministic,
+		MaxEpisodeSteps:   spec.MaxEpisodeSteps,
+		OrderEnforce:      spec.OrderEnforce,
+		DisableEnvChecker: spec.DisableEnvChecker,
+		Kwargs:            spec.Kwargs,
+	})
+}
+
+// UnmarshalJSON decodes a spec encoded by MarshalJSON.
+//
+// The entry point is linked to the one of the environment registered under the decoded ID, which must be
+// registered with matching types. Numbers in the kwargs are decoded as float64, following encoding/json.
+func (spec *EnvSpec[Obs, Act]) UnmarshalJSON(data []byte) error {
+	var decoded envSpecJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	registered, err := Spec[Obs, Act](decoded.ID)
+	if err != nil {
+		return fmt.Errorf("failed to link entry point: %w", err)
+	}
+
+	*spec = EnvSpec[Obs, Act]{
+		ID:                decoded.ID,
+		EntryPoint:        registered.EntryPoint,
+		Namespace:         decoded.Namespace,
+		Name:              decoded.Name,
+		Version:           decoded.Version,
+		RewardThreshold:   decoded.RewardThreshold,
+		Nondeterministic:  decoded.Nondeterministic,
+		MaxEpisodeSteps:   decoded.MaxEpisodeSteps,
+		OrderEnforce:      decoded.OrderEnforce,
+		DisableEnvChecker: decoded.DisableEnvChecker,
+		Kwargs:            decoded.Kwargs,
+	}
+	return nil
+}
+
+// SpecOption configures an EnvSpec during Register.
+type SpecOption[Obs any, Act any] func(*EnvSpec[Obs, Act])
+
+// WithRewardThreshold sets the reward threshold of the spec.
+func WithRewardThreshold[Obs any, Act any](threshold float64) SpecOption[Obs, Act] {
+	return func(spec *EnvSpec[Obs, Act]) {
+		spec.RewardThreshold = &threshold
+	}
+}
+
+// WithMaxEpisodeSteps sets the maximum number of steps of an episode.
+func WithMaxEpisodeSteps[Obs any, Act any](steps int) SpecOption[Obs, Act] {
+	return func(spec *EnvSpec[Obs, Act]) {
+		spec.MaxEpisodeSteps = &steps
+	}
+}
+
+// WithNondeterministic marks the environment as nondeterministic even after seeding.
+func WithNondeterministic[Obs any, Act any](nondeterministic bool) SpecOption[Obs, Act] {
+	return func(spec *EnvSpec[Obs, Act]) {
+		spec.Nondeterministic = nondeterministic
+	}
+}
+
+// WithOrderEnforce sets whether Reset must be called before Step (defaults to true).
+func WithOrderEnforce[Obs any, Act any](enforce bool) SpecOption[Obs, Act] {
+	return func(spec *EnvSpec[Obs, Act]) {
+		spec.OrderEnforce = enforce
+	}
+}
+
+// WithDisableEnvChecker sets whether the environment checker is disabled (defaults to false).
+func WithDisableEnvChecker[Obs any, Act any](disable bool) SpecOption[Obs, Act] {
+	return func(spec *EnvSpec[Obs, Act]) {
+		spec.DisableEnvChecker = disable
+	}
+}
+
+// WithForce makes Register overwrite an environment already registered with the same ID.
+func WithForce[Obs any, Act any]() SpecOption[Obs, Act] {
+	return func(spec *EnvSpec[Obs, Act]) {
+		spec.force = true
+	}
+}
+
+// WithKwargs sets the default keyword arguments passed to the entry point.
+func WithKwargs[Obs any, Act any](kwargs map[string]any) SpecOption[Obs, Act] {
+	return func(spec *EnvSpec[Obs, Act]) {
+		spec.Kwargs = maps.Clone(kwargs)
+	}
+}
+
+// registeredSpec is implemented by every EnvSpec regardless of its type parameters.
+type registeredSpec interface {
+	makeAny(kwargs map[string]any, config makeConfig) (any, error)
+	envType() string
+	parsedID() (namespace, name string, version *int)
+	summary() SpecSummary
+}
+
+// summary returns the type-independent details of the spec.
+func (spec *EnvSpec[Obs, Act]) summary() SpecSummary {
+	return SpecSummary{
+		ID:              spec.ID,
+		Namespace:       spec.Namespace,
+		Name:            spec.Name,
+		Version:         spec.Version,
+		MaxEpisodeSteps: spec.MaxEpisodeSteps,
+		RewardThreshold: spec.RewardThreshold,
+	}
+}
+
+// parsedID returns the namespace, name and version of the spec.
+func (spec *EnvSpec[Obs, Act]) parsedID() (string, string, *int) {
+	return spec.Namespace, spec.Name, spec.Version
+}
+
+// envType returns the environment type of the spec, e.g. "Env[[]float64,int]".
+func (spec *EnvSpec[Obs, Act]) envType() string {
+	return envTypeName[Obs, Act]()
+}
+
+// envTypeName formats the environment type for the given type parameters, e.g. "Env[[]float64,int]".
+func envTypeName[Obs any, Act any]() string {
+	return fmt.Sprintf("Env[%s,%s]", reflect.TypeFor[Obs](), reflect.TypeFor[Act]())
+}
+
+// makeAny creates the environment described by the spec, merging kwargs over the spec's defaults.
+func (spec *EnvSpec[Obs, Act]) makeAny(kwargs map[string]any, config makeConfig) (any, error) {
+	return spec.make(kwargs, config)
+}
+
+// make creates the environment described by the spec, merging kwargs over the spec's defaults.
+func (spec *EnvSpec[Obs, Act]) make(kwargs map[string]any, config makeConfig) (Env[Obs, Act], error) {
+	if config.seed != nil && *config.seed <= 0 {
+		return nil, fmt.Errorf("seed must be positive, got %d", *config.seed)
+	}
+
+	merged := maps.Clone(spec.Kwargs)
+	if merged == nil {
+		merged = make(map[string]any, len(kwargs))
+	}
+	maps.Copy(merged, kwargs)
+
+	env, err := spec.EntryPoint(merged)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make environment %s: %w", spec.ID, err)
+	}
+	if env == nil {
+		return nil, fmt.Errorf("entry point of environment %s returned a nil environment", spec.ID)
+	}
+
+	if !config.noWrappers {
+		wrapped, err := spec.applyWrappers(env)
+		if err != nil {
+			env.Close()
+			return nil, fmt.Errorf("failed to wrap environment %s: %w", spec.ID, err)
+		}
+		env = wrapped
+	}
+
+	if config.seed != nil {
+		if err := seedEnv(env, *config.seed); err != nil {
+			env.Close()
+			return nil, fmt.Errorf("failed to seed environment %s: %w", spec.ID, err)
+		}
+	}
+	return env, nil
+}
+
+// applyWrappers wraps env as described by the spec, from the innermost PassiveEnvChecker unless
+// DisableEnvChecker is set, through OrderEnforcing if OrderEnforce is set, to the outermost TimeLimit
+// if MaxEpisodeSteps is set.
+func (spec *EnvSpec[Obs, Act]) applyWrappers(env Env[Obs, Act]) (Env[Obs, Act], error) {
+	if !spec.DisableEnvChecker {
+		checker, err := NewPassiveEnvChecker(env)
+		if err != nil {
+			return nil, err
+		}
+		env = checker
+	}
+
+	if spec.OrderEnforce {
+		enforcing, err := NewOrderEnforcing(env)
+		if err != nil {
+			return nil, err
+		}
+		env = enforcing
+	}
+
+	if spec.MaxEpisodeSteps != nil {
+		limited, err := NewTimeLimit(env, *spec.MaxEpisodeSteps)
+		if err != nil {
+			return nil, err
+		}
+		env = limited
+	}
+	return env, nil
+}
+
+// MakeOption configures how Make and MakeAny create an environment.
+type MakeOption func(*makeConfig)
+
+// makeConfig holds the settings of MakeOption.
+type makeConfig struct {
+	noWrappers bool   // Whether the spec's wrappers are skipped
+	seed       *int64 // Seed of the environment, or nil to leave it unseeded
+}
+
+// newMakeConfig applies opts to the default settings.
+func newMakeConfig(opts []MakeOption) makeConfig {
+	var config makeConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config
+}
+
+// WithNoWrappers makes Make return the environment created by the entry point without the wrappers of its spec.
+func WithNoWrappers() MakeOption {
+	return func(config *makeConfig) {
+		config.noWrappers = true
+	}
+}
+
+// WithSeed makes Make seed the environment's RNG, action space and observation space with three distinct seeds
+// spawned from seed by a rand.SeedSequence.
+//
+// A seeded environment reproduces the same first observation on Reset with a nil seed and the same sampled
+// actions as any other environment made with the same ID, kwargs and seed. The seed must be positive, as 0
+// selects a time-based seed.
+func WithSeed(seed int64) MakeOption {
+	return func(config *makeConfig) {
+		config.seed = &seed
+	}
+}
+
+// Logger receives structured debug logs from the registry.
+//
+// The arguments after msg are alternating keys and values, matching the convention of log/slog,
+// so a *slog.Logger can be used directly.
+type Logger interface {
+	Debug(msg string, keysAndValues ...any)
+}
+
+// nopLogger is the default Logger, which discards every log.
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...any) {}
+
+var (
+	registry   = make(map[string]registeredSpec)
+	registryMu sync.RWMutex
+	logger     atomic.Pointer[Logger]
+)
+
+// SetLogger sets the Logger used by the registry. A nil Logger disables logging.
+//
+// Parameters:
+//   - l: The Logger receiving the registry's debug logs
+func SetLogger(l Logger) {
+	if l == nil {
+		logger.Store(nil)
+		return
+	}
+	logger.Store(&l)
+}
+
+// getLogger returns the Logger used by the registry.
+func getLogger() Logger {
+	if l := logger.Load(); l != nil {
+		return *l
+	}
+	return nopLogger{}
+}
+
+// envIDPattern matches environment IDs of the form "[namespace/]name[-vversion]".
+var envIDPattern = regexp.MustCompile(`^(?:([\w:-]+)/)?([\w:.-]+?)(?:-v(\d+))?$`)
+
+// ParseEnvID parses an environment ID into its namespace, name and version.
+//
+// Parameters:
+//   - id: The environment ID, e.g. "CartPole-v1" or "namespace/Name-v0"
+//
+// Returns:
+//   - The namespace, empty if none
+//   - The name
+//   - The version, nil if unversioned
+//   - An error if the ID is malformed
+func ParseEnvID(id string) (string, string, *int, error) {
+	match := envIDPattern.FindStringSubmatch(id)
+	if match == nil {
+		return "", "", nil, fmt.Errorf("malformed environment id: %s", id)
+	}
+
+	namespace, name := match[1], match[2]
+	if match[3] == "" {
+		return namespace, name, nil, nil
+	}
+
+	version, err := strconv.Atoi(match[3])
+	if err != nil {
+		return "", "", nil, fmt.Errorf("malformed environment version in id %s: %w", id, err)
+	}
+	return namespace, name, &version, nil
+}
+
+// Register registers an environment under the given ID so that it can be created with Make.
+//
+// Parameters:
+//   - id: The environment ID, e.g. "CartPole-v1"
+//   - entryPoint: The function constructing the environment from keyword arguments
+//   - opts: Options configuring the spec, e.g. WithMaxEpisodeSteps and WithRewardThreshold
+//
+// Returns:
+//   - An error if the ID is malformed, the entry point is nil, or the ID is already registered without WithForce
+func Register[Obs any, Act any](id string, entryPoint func(kwargs map[string]any) (Env[Obs, Act], error), opts ...SpecOption[Obs, Act]) error {
+	err := register(id, entryPoint, opts...)
+	if err != nil {
+		getLogger().Debug("failed to register environment", "id", id, "error", err)
+		return err
+	}
+	getLogger().Debug("registered environment", "id", id)
+	return nil
+}
+
+func register[Obs any, Act any](id string, entryPoint func(kwargs map[string]any) (Env[Obs, Act], error), opts ...SpecOption[Obs, Act]) error {
+	if entryPoint == nil {
+		return fmt.Errorf("entry point of environment %s must not be nil", id)
+	}
+
+	namespace, name, version, err := ParseEnvID(id)
+	if err != nil {
+		return err
+	}
+
+	spec := &EnvSpec[Obs, Act]{
+		ID:           id,
+		EntryPoint:   entryPoint,
+		Namespace:    namespace,
+		Name:         name,
+		Version:      version,
+		OrderEnforce: true,
+	}
+	for _, opt := range opts {
+		opt(spec)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := registry[id]; ok && !spec.force {
+		return fmt.Errorf("environment %s is already registered, use WithForce to overwrite it", id)
+	}
+	spec.force = false
+	registry[id] = spec
+
+	return nil
+}
+
+// Deregister removes the environment registered under the given ID.
+//
+// Parameters:
+//   - id: The environment ID, e.g. "CartPole-v1"
+//
+// Returns:
+//   - An error if the ID is not registered
+func Deregister(id string) error {
+	registryMu.Lock()
+	_, ok := registry[id]
+	delete(registry, id)
+	registryMu.Unlock()
+
+	if !ok {
+		err := fmt.Errorf("no registered environment with id: %s", id)
+		getLogger().Debug("failed to deregister environment", "id", id, "error", err)
+		return err
+	}
+	getLogger().Debug("deregistered environment", "id", id)
+	return nil
+}
+
+// resetRegistry removes every registered environment, isolating tests from each other.
+func resetRegistry() {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	clear(registry)
+}
+
+// Make creates an environment previously registered with Register.
+//
+// The environment is wrapped according to its spec: with PassiveEnvChecker unless DisableEnvChecker is set,
+// OrderEnforcing if OrderEnforce is set and TimeLimit if MaxEpisodeSteps is set. WithNoWrappers skips them.
+// The problems found by PassiveEnvChecker are only logged once a Logger is installed with SetLogger.
+//
+// Parameters:
+//   - id: The environment ID, e.g. "CartPole-v1", or "CartPole" for the latest registered version
+//   - kwargs: Keyword arguments passed to the entry point, overriding the spec's defaults (may be nil)
+//   - opts: Options such as WithNoWrappers and WithSeed
+//
+// Returns:
+//   - A new instance of the environment
+//   - An error if the ID is not registered, the types do not match the spec, or construction or seeding fails
+func Make[Obs any, Act any](id string, kwargs map[string]any, opts ...MakeOption) (Env[Obs, Act], error) {
+	env, err := makeEnv[Obs, Act](id, kwargs, newMakeConfig(opts))
+	if err != nil {
+		getLogger().Debug("failed to make environment", "id", id, "error", err)
+		return nil, err
+	}
+	getLogger().Debug("made environment", "id", id)
+	return env, nil
+}
+
+func makeEnv[Obs any, Act any](id string, kwargs map[string]any, config makeConfig) (Env[Obs, Act], error) {
+	spec, err := Spec[Obs, Act](id)
+	if err != nil {
+		return nil, err
+	}
+	return spec.make(kwargs, config)
+}
+
+// MakeVec creates n instances of an environment previously registered with Register, each seeded deterministically.
+//
+// Instance i is seeded as by WithSeed(baseSeed + i), so the returned slice is ready to be used by a vector
+// environment and reproduces the same samples for the same baseSeed.
+// If baseSeed is 0, the instances are left with their default random seeds.
+//
+// Parameters:
+//   - id: The environment ID, e.g. "CartPole-v1", or "CartPole" for the latest registered version
+//   - n: The number of instances to create (must be positive)
+//   - baseSeed: The seed of the first instance (must be non-negative)
+//
+// Returns:
+//   - The created instances
+//   - An error naming every failed index if any instance could not be created or seeded
+func MakeVec[Obs any, Act any](id string, n int, baseSeed int64) ([]Env[Obs, Act], error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("number of environments must be positive, got %d", n)
+	}
+	if baseSeed < 0 {
+		return nil, fmt.Errorf("base seed must be non-negative, got %d", baseSeed)
+	}
+
+	envs := make([]Env[Obs, Act], n)
+	var errs []error
+	for i := range envs {
+		env, err := Make[Obs, Act](id, nil)
+		if err == nil && baseSeed != 0 {
+			err = seedEnv(env, baseSeed+int64(i))
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("environment %d: %w", i, err))
+			continue
+		}
+		envs[i] = env
+	}
+
+	if len(errs) > 0 {
+		for _, env := range envs {
+			if env != nil {
+				env.Close()
+			}
+		}
+		return nil, errors.Join(errs...)
+	}
+	return envs, nil
+}
+
+// seedEnv seeds the RNG, action space and observation space of an environment with seeds spawned from seed, so
+// that they do not draw the same stream.
+func seedEnv[Obs any, Act any](env Env[Obs, Act], seed int64) error {
+	seq, err := rand.NewSeedSequenceExact(seed)
+	if err != nil {
+		return fmt.Errorf("failed to derive seeds: %w", err)
+	}
+	seeds := seq.Spawn(3)
+
+	if _, err := env.GetRNG().Seed(seeds[0]); err != nil {
+		return fmt.Errorf("failed to seed RNG: %w", err)
+	}
+	if _, err := env.ActionSpace().Seed(seeds[1]); err != nil {
+		return fmt.Errorf("failed to seed action space: %w", err)
+	}
+	if _, err := env.ObservationSpace().Seed(seeds[2]); err != nil {
+		return fmt.Errorf("failed to seed observation space: %w", err)
+	}
+	return nil
+}
+
+// MakeAny creates an environment previously registered with Register without knowing its types.
+//
+// The returned value is an Env whose type parameters are those of the registered spec, wrapped as by Make.
+//
+// Parameters:
+//   - id: The environment ID, e.g. "CartPole-v1", or "CartPole" for the latest registered version
+//   - kwargs: Keyword arguments passed to the entry point, overriding the spec's defaults (may be nil)
+//   - opts: Options such as WithNoWrappers and WithSeed
+//
+// Returns:
+//   - A new instance of the environment
+//   - An error if the ID is not registered, or construction or seeding fails
+func MakeAny(id string, kwargs map[string]any, opts ...MakeOption) (any, error) {
+	spec, err := lookup(id)
+	if err != nil {
+		getLogger().Debug("failed to make environment", "id", id, "error", err)
+		return nil, err
+	}
+
+	env, err := spec.makeAny(kwargs, newMakeConfig(opts))
+	if err != nil {
+		getLogger().Debug("failed to make environment", "id", id, "error", err)
+		return nil, err
+	}
+	getLogger().Debug("made environment", "id", id)
+	return env, nil
+}
+
+// Spec returns a copy of the spec registered under the given ID.
+//
+// Parameters:
+//   - id: The environment ID, e.g. "CartPole-v1", or "CartPole" for the latest registered version
+//
+// Returns:
+//   - A copy of the registered spec
+//   - An error if the ID is not registered or the types do not match the spec
+func Spec[Obs any, Act any](id string) (*EnvSpec[Obs, Act], error) {
+	entry, err := lookup(id)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, ok := entry.(*EnvSpec[Obs, Act])
+	if !ok {
+		return nil, fmt.Errorf("environment %s has incompatible types: registered as %s but requested %s",
+			id, entry.envType(), envTypeName[Obs, Act]())
+	}
+
+	specCopy := *spec
+	specCopy.Kwargs = maps.Clone(spec.Kwargs)
+	return &specCopy, nil
+}
+
+// IsSolved reports whether an environment counts as solved by recent episode returns.
+//
+// The environment is solved when the mean of the last window returns meets or exceeds the reward threshold
+// of its spec. Fewer than window returns are never enough to decide, so the result is false.
+//
+// Parameters:
+//   - spec: The spec of the environment
+//   - recentReturns: The episode returns in chronological order
+//   - window: The number of most recent returns to average (must be positive)
+//
+// Returns:
+//   - true if the spec has a reward threshold and the mean of the last window returns reaches it, false otherwise
+func IsSolved[Obs any, Act any](spec *EnvSpec[Obs, Act], recentReturns []float64, window int) bool {
+	if spec == nil || spec.RewardThreshold == nil || window <= 0 || len(recentReturns) < window {
+		return false
+	}
+
+	sum := 0.0
+	for _, ret := range recentReturns[len(recentReturns)-window:] {
+		sum += ret
+	}
+	return sum/float64(window) >= *spec.RewardThreshold
+}
+
+// lookup returns the spec registered under the given ID.
+//
+// An ID without a version resolves to the highest registered version of the environment. Without a
+// namespace, the environment must not be registered under several namespaces.
+func lookup(id string) (registeredSpec, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	if spec, ok := registry[id]; ok {
+		return spec, nil
+	}
+
+	namespace, name, version, err := ParseEnvID(id)
+	if err != nil || version != nil {
+		return nil, unknownIDError(id)
+	}
+
+	var latest registeredSpec
+	var latestVersion int
+	namespaces := make(map[string]bool)
+	for _, spec := range registry {
+		specNamespace, specName, specVersion := spec.parsedID()
+		if specName != name || specVersion == nil || (namespace != "" && specNamespace != namespace) {
+			continue
+		}
+		namespaces[specNamespace] = true
+		if latest == nil || *specVersion > latestVersion {
+			latest, latestVersion = spec, *specVersion
+		}
+	}
+
+	if len(namespaces) > 1 {
+		return nil, fmt.Errorf("environment %s is registered in multiple namespaces %q, specify one",
+			id, slices.Sorted(maps.Keys(namespaces)))
+	}
+	if latest == nil {
+		return nil, unknownIDError(id)
+	}
+	return latest, nil
+}
+
+// maxSuggestions is the maximum number of registered IDs suggested for an unknown ID.
+const maxSuggestions = 5
+
+// unknownIDError returns the error for an unregistered ID, suggesting the closest registered IDs.
+//
+// The caller must hold registryMu.
+func unknownIDError(id string) error {
+	suggestions := suggestIDs(id, slices.Collect(maps.Keys(registry)))
+	if len(suggestions) == 0 {
+		return fmt.Errorf("no registered environment with id: %s", id)
+	}
+	return fmt.Errorf("no registered environment with id: %s, did you mean %s?", id, strings.Join(suggestions, ", "))
+}
+
+// suggestIDs returns up to maxSuggestions IDs close to id, closest first.
+//
+// An ID is close if its name shares a prefix with the name of id, ignoring case, or if its Levenshtein
+// distance to id is at most a third of the length of id.
+func suggestIDs(id string, ids []string) []string {
+	_, name, _, _ := ParseEnvID(id)
+	name = strings.ToLower(name)
+
+	type candidate struct {
+		id       string
+		distance int
+	}
+	var candidates []candidate
+	for _, other := range ids {
+		_, otherName, _, _ := ParseEnvID(other)
+		otherName = strings.ToLower(otherName)
+
+		distance := levenshtein(strings.ToLower(id), strings.ToLower(other))
+		prefix := name != "" && otherName != "" && (strings.HasPrefix(otherName, name) || strings.HasPrefix(name, otherName))
+		if prefix || distance <= len(id)/3 {
+			candidates = append(candidates, candidate{id: other, distance: distance})
+		}
+	}
+
+	slices.SortFunc(candidates, func(a, b candidate) int {
+		if a.distance != b.distance {
+			return a.distance - b.distance
+		}
+		return strings.Compare(a.id, b.id)
+	})
+
+	suggestions := make([]string, 0, maxSuggestions)
+	for _, c := range candidates[:min(len(candidates), maxSuggestions)] {
+		suggestions = append(suggestions, c.id)
+	}
+	return suggestions
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// ListRegistered returns the IDs of all registered environments in sorted order.
+//
+// Returns:
+//   - A sorted slice of environment IDs
+func ListRegistered() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	return slices.Sorted(maps.Keys(registry))
+}
+
+// SpecSummary describes a registered environment independently of its type parameters.
+type SpecSummary struct {
+	ID              string
+	Namespace       string
+	Name            string
+	Version         *int
+	MaxEpisodeSteps *int
+	RewardThreshold *float64
+}
+
+// AllSpecs returns the summaries of all registered environments.
+//
+// Returns:
+//   - A slice of spec summaries sorted by ID
+func AllSpecs() []SpecSummary {
+	return specSummaries(func(SpecSummary) bool { return true })
+}
+
+// SpecsByNamespace returns the summaries of the environments registered in a namespace.
+//
+// Parameters:
+//   - ns: The namespace to list, or "" for environments without a namespace
+//
+// Returns:
+//   - A slice of spec summaries sorted by ID
+func SpecsByNamespace(ns string) []SpecSummary {
+	return specSummaries(func(s SpecSummary) bool { return s.Namespace == ns })
+}
+
+// specSummaries returns the summaries of the registered environments accepted by keep, sorted by ID.
+func specSummaries(keep func(SpecSummary) bool) []SpecSummary {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	summaries := make([]SpecSummary, 0, len(registry))
+	for _, spec := range registry {
+		if s := spec.summary(); keep(s) {
+			summaries = append(summaries, s)
+		}
+	}
+	slices.SortStableFunc(summaries, func(a, b SpecSummary) int { return strings.Compare(a.ID, b.ID) })
+	return summaries
+}
+
+// PrintRegistry prints the IDs of all registered environments.
+func PrintRegistry() {
+	for _, id := range ListRegistered() {
+		fmt.Println(id)
+	}
+}
@@ -0,0 +1,403 @@
+package gym_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gocnn/gym"
+)
+
+// balance returns the action of a linear controller that keeps the CartPole pole upright and the cart near the
+// center indefinitely.
+func balance(obs []float64) int {
+	if obs[0]+1.6*obs[1]+18*obs[2]+3*obs[3] > 0 {
+		return 1
+	}
+	return 0
+}
+
+func TestMakeCartPoleTruncatesAtMaxEpisodeSteps(t *testing.T) {
+	env, err := gym.Make[[]float64, int]("CartPole-v1", nil, gym.WithSeed(1))
+	if err != nil {
+		t.Fatalf("Make failed: %v", err)
+	}
+	defer env.Close()
+
+	ctx := context.Background()
+	obs, _, err := env.Reset(ctx, nil, nil)
+	if err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	for step := 1; ; step++ {
+		var terminated, truncated bool
+		obs, _, terminated, truncated, _, err = env.Step(ctx, balance(obs))
+		if err != nil {
+			t.Fatalf("Step failed: %v", err)
+		}
+		if terminated {
+			t.Fatalf("episode terminated at step %d, the controller should keep the pole upright", step)
+		}
+		if truncated {
+			if step != 500 {
+				t.Errorf("episode truncated at step %d, want 500", step)
+			}
+			return
+		}
+		if step > 500 {
+			t.Fatal("episode not truncated after 500 steps")
+		}
+	}
+}
+
+func TestMakeWithSeed(t *testing.T) {
+	// run makes a CartPole seeded with seed, returning its first observation and sampled actions and observations
+	run := func(seed int64) ([]float64, []int, [][]float64) {
+		t.Helper()
+
+		env, err := gym.Make[[]float64, int]("CartPole-v1", nil, gym.WithSeed(seed))
+		if err != nil {
+			t.Fatalf("Make failed: %v", err)
+		}
+		defer env.Close()
+
+		obs, _, err := env.Reset(context.Background(), nil, nil)
+		if err != nil {
+			t.Fatalf("Reset failed: %v", err)
+		}
+		actions := make([]int, 20)
+		observations := make([][]float64, 5)
+		for i := range actions {
+			if actions[i], err = env.ActionSpace().Sample(nil, nil); err != nil {
+				t.Fatalf("Sample failed: %v", err)
+			}
+		}
+		for i := range observations {
+			if observations[i], err = env.ObservationSpace().Sample(nil, nil); err != nil {
+				t.Fatalf("Sample failed: %v", err)
+			}
+		}
+		return obs, actions, observations
+	}
+
+	obs, actions, observations := run(7)
+	againObs, againActions, againObservations := run(7)
+	if !slices.Equal(obs, againObs) {
+		t.Errorf("seed 7 gave the first observation %v, then %v", obs, againObs)
+	}
+	if !slices.Equal(actions, againActions) {
+		t.Errorf("seed 7 sampled the actions %v, then %v", actions, againActions)
+	}
+	if !slices.EqualFunc(observations, againObservations, slices.Equal) {
+		t.Errorf("seed 7 sampled the observations %v, then %v", observations, againObservations)
+	}
+
+	if otherObs, otherActions, _ := run(8); slices.Equal(obs, otherObs) || slices.Equal(actions, otherActions) {
+		t.Errorf("seeds 7 and 8 gave the same first observation %v or actions %v", obs, actions)
+	}
+
+	if _, err := gym.Make[[]float64, int]("CartPole-v1", nil, gym.WithSeed(0)); err == nil {
+		t.Error("Make with seed 0 succeeded, expected an error")
+	}
+}
+
+// registerStub registers a stubEnv under id in the registry, failing the test on error.
+func registerStub(t *testing.T, id string, opts ...gym.SpecOption[[]float64, int]) {
+	t.Helper()
+
+	entryPoint := func(map[string]any) (gym.Env[[]float64, int], error) { return newStubEnv(t), nil }
+	if err := gym.Register(id, entryPoint, opts...); err != nil {
+		t.Fatalf("Register(%q) failed: %v", id, err)
+	}
+}
+
+func TestRegisterMakeDeregister(t *testing.T) {
+	gym.IsolateRegistry(t)
+	if ids := gym.ListRegistered(); len(ids) != 0 {
+		t.Fatalf("isolated registry holds %v, want none", ids)
+	}
+
+	registerStub(t, "Stub-v0")
+	registerStub(t, "Stub-v1")
+	if ids := gym.ListRegistered(); !slices.Equal(ids, []string{"Stub-v0", "Stub-v1"}) {
+		t.Errorf("ListRegistered() = %v, want [Stub-v0 Stub-v1]", ids)
+	}
+
+	for _, id := range []string{"Stub-v0", "Stub"} {
+		env, err := gym.Make[[]float64, int](id, nil)
+		if err != nil {
+			t.Fatalf("Make(%q) failed: %v", id, err)
+		}
+		env.Close()
+	}
+	spec, err := gym.Spec[[]float64, int]("Stub")
+	if err != nil {
+		t.Fatalf("Spec failed: %v", err)
+	}
+	if spec.ID != "Stub-v1" {
+		t.Errorf("unversioned ID resolved to %s, want the latest version Stub-v1", spec.ID)
+	}
+
+	if err := gym.Deregister("Stub-v1"); err != nil {
+		t.Fatalf("Deregister failed: %v", err)
+	}
+	if _, err := gym.Make[[]float64, int]("Stub-v1", nil); err == nil {
+		t.Error("Make succeeded after Deregister, expected an error")
+	}
+	if err := gym.Deregister("Stub-v1"); err == nil {
+		t.Error("second Deregister succeeded, expected an error")
+	}
+}
+
+func TestMakeUnversionedCartPole(t *testing.T) {
+	spec, err := gym.Spec[[]float64, int]("CartPole")
+	if err != nil {
+		t.Fatalf("Spec failed: %v", err)
+	}
+	if spec.ID != "CartPole-v1" {
+		t.Errorf("CartPole resolved to %s, want the latest version CartPole-v1", spec.ID)
+	}
+
+	// CartPole-v1 truncates at 500 steps, CartPole-v0 at 200
+	env, err := gym.Make[[]float64, int]("CartPole", nil, gym.WithSeed(1))
+	if err != nil {
+		t.Fatalf("Make failed: %v", err)
+	}
+	defer env.Close()
+
+	ctx := context.Background()
+	obs, _, err := env.Reset(ctx, nil, nil)
+	if err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	for step := 1; step <= 500; step++ {
+		var terminated, truncated bool
+		obs, _, terminated, truncated, _, err = env.Step(ctx, balance(obs))
+		if err != nil {
+			t.Fatalf("Step failed: %v", err)
+		}
+		if terminated || truncated {
+			if step != 500 {
+				t.Errorf("episode ended at step %d, want the truncation of CartPole-v1 at 500", step)
+			}
+			return
+		}
+	}
+	t.Error("episode not truncated after 500 steps")
+}
+
+func TestMakeUnknownIDSuggestsRegisteredIDs(t *testing.T) {
+	for _, id := range []string{"CartPole-v2", "Cartpole-v1", "CartPol-v1"} {
+		_, err := gym.Make[[]float64, int](id, nil)
+		if err == nil {
+			t.Fatalf("Make(%q) succeeded, expected an error", id)
+		}
+		if !strings.Contains(err.Error(), "did you mean") || !strings.Contains(err.Error(), "CartPole-v1") {
+			t.Errorf("Make(%q) error = %q, want it to suggest CartPole-v1", id, err)
+		}
+	}
+
+	if _, err := gym.Make[[]float64, int]("Zzzzzzzzzzzz-v0", nil); err == nil || strings.Contains(err.Error(), "did you mean") {
+		t.Errorf("Make of an unrelated ID returned %v, want an error without suggestions", err)
+	}
+}
+
+func TestRegisterTwice(t *testing.T) {
+	gym.IsolateRegistry(t)
+	registerStub(t, "Stub-v0", gym.WithMaxEpisodeSteps[[]float64, int](10))
+
+	entryPoint := func(map[string]any) (gym.Env[[]float64, int], error) { return newStubEnv(t), nil }
+	err := gym.Register("Stub-v0", entryPoint, gym.WithMaxEpisodeSteps[[]float64, int](20))
+	if err == nil || !strings.Contains(err.Error(), "already registered") {
+		t.Fatalf("second Register returned %v, want an already registered error", err)
+	}
+	spec, err := gym.Spec[[]float64, int]("Stub-v0")
+	if err != nil {
+		t.Fatalf("Spec failed: %v", err)
+	}
+	if *spec.MaxEpisodeSteps != 10 {
+		t.Errorf("failed Register changed MaxEpisodeSteps to %d, want 10", *spec.MaxEpisodeSteps)
+	}
+
+	registerStub(t, "Stub-v0", gym.WithMaxEpisodeSteps[[]float64, int](20), gym.WithForce[[]float64, int]())
+	if spec, err = gym.Spec[[]float64, int]("Stub-v0"); err != nil {
+		t.Fatalf("Spec failed: %v", err)
+	}
+	if *spec.MaxEpisodeSteps != 20 {
+		t.Errorf("Register with WithForce left MaxEpisodeSteps at %d, want 20", *spec.MaxEpisodeSteps)
+	}
+
+	// The force option applies to a single registration
+	if err := gym.Register("Stub-v0", entryPoint); err == nil {
+		t.Error("Register after a forced registration succeeded, expected an error")
+	}
+}
+
+func TestSpecSummaries(t *testing.T) {
+	gym.IsolateRegistry(t)
+	registerStub(t, "Stub-v1", gym.WithMaxEpisodeSteps[[]float64, int](100), gym.WithRewardThreshold[[]float64, int](90))
+	registerStub(t, "ns/Beta-v0")
+	registerStub(t, "Stub-v0")
+	registerStub(t, "ns/Alpha-v2")
+
+	ids := func(summaries []gym.SpecSummary) []string {
+		var ids []string
+		for _, s := range summaries {
+			ids = append(ids, s.ID)
+		}
+		return ids
+	}
+
+	all := gym.AllSpecs()
+	if got, want := ids(all), []string{"Stub-v0", "Stub-v1", "ns/Alpha-v2", "ns/Beta-v0"}; !slices.Equal(got, want) {
+		t.Fatalf("AllSpecs() IDs = %v, want %v", got, want)
+	}
+	stub := all[1]
+	if stub.Name != "Stub" || stub.Namespace != "" || *stub.Version != 1 || *stub.MaxEpisodeSteps != 100 || *stub.RewardThreshold != 90 {
+		t.Errorf("summary of Stub-v1 = %+v, want name Stub, version 1, 100 steps and threshold 90", stub)
+	}
+	alpha := all[2]
+	if alpha.Name != "Alpha" || alpha.Namespace != "ns" || *alpha.Version != 2 || alpha.MaxEpisodeSteps != nil || alpha.RewardThreshold != nil {
+		t.Errorf("summary of ns/Alpha-v2 = %+v, want name Alpha in ns, version 2 and no limits", alpha)
+	}
+
+	if got, want := ids(gym.SpecsByNamespace("ns")), []string{"ns/Alpha-v2", "ns/Beta-v0"}; !slices.Equal(got, want) {
+		t.Errorf("SpecsByNamespace(\"ns\") IDs = %v, want %v", got, want)
+	}
+	if got, want := ids(gym.SpecsByNamespace("")), []string{"Stub-v0", "Stub-v1"}; !slices.Equal(got, want) {
+		t.Errorf("SpecsByNamespace(\"\") IDs = %v, want %v", got, want)
+	}
+	if got := gym.SpecsByNamespace("other"); len(got) != 0 {
+		t.Errorf("SpecsByNamespace(\"other\") = %v, want none", got)
+	}
+}
+
+func TestIsSolved(t *testing.T) {
+	spec, err := gym.Spec[[]float64, int]("CartPole-v1")
+	if err != nil {
+		t.Fatalf("Spec failed: %v", err)
+	}
+
+	// CartPole-v1 is solved by a mean return of 475
+	tests := []struct {
+		returns []float64
+		window  int
+		want    bool
+	}{
+		{[]float64{475, 475, 475}, 3, true},
+		{[]float64{450, 500, 475}, 3, true},
+		{[]float64{500, 474, 475, 475}, 3, false},
+		{[]float64{0, 0, 500, 500}, 2, true},
+		{[]float64{500, 500}, 3, false},
+		{nil, 1, false},
+		{[]float64{500}, 0, false},
+	}
+	for _, tt := range tests {
+		if got := gym.IsSolved(spec, tt.returns, tt.window); got != tt.want {
+			t.Errorf("IsSolved(CartPole-v1, %v, %d) = %t, want %t", tt.returns, tt.window, got, tt.want)
+		}
+	}
+
+	spec.RewardThreshold = nil
+	if gym.IsSolved(spec, []float64{500, 500, 500}, 3) {
+		t.Error("IsSolved without a reward threshold = true, want false")
+	}
+	if gym.IsSolved[[]float64, int](nil, []float64{500}, 1) {
+		t.Error("IsSolved with a nil spec = true, want false")
+	}
+}
+
+func TestRegisterOptions(t *testing.T) {
+	gym.IsolateRegistry(t)
+
+	registerStub(t, "Stub-v0")
+	spec, err := gym.Spec[[]float64, int]("Stub-v0")
+	if err != nil {
+		t.Fatalf("Spec failed: %v", err)
+	}
+	if !spec.OrderEnforce || spec.DisableEnvChecker || spec.Nondeterministic {
+		t.Errorf("default spec has OrderEnforce %t, DisableEnvChecker %t and Nondeterministic %t, want true, false and false",
+			spec.OrderEnforce, spec.DisableEnvChecker, spec.Nondeterministic)
+	}
+	if spec.RewardThreshold != nil || spec.MaxEpisodeSteps != nil || spec.Kwargs != nil {
+		t.Errorf("default spec has RewardThreshold %v, MaxEpisodeSteps %v and Kwargs %v, want nil",
+			spec.RewardThreshold, spec.MaxEpisodeSteps, spec.Kwargs)
+	}
+
+	registerStub(t, "Stub-v1",
+		gym.WithOrderEnforce[[]float64, int](false),
+		gym.WithDisableEnvChecker[[]float64, int](true),
+		gym.WithNondeterministic[[]float64, int](true),
+		gym.WithRewardThreshold[[]float64, int](10),
+		gym.WithMaxEpisodeSteps[[]float64, int](20),
+		gym.WithKwargs[[]float64, int](map[string]any{"size": 3}),
+	)
+	spec, err = gym.Spec[[]float64, int]("Stub-v1")
+	if err != nil {
+		t.Fatalf("Spec failed: %v", err)
+	}
+	if spec.OrderEnforce || !spec.DisableEnvChecker || !spec.Nondeterministic {
+		t.Errorf("spec has OrderEnforce %t, DisableEnvChecker %t and Nondeterministic %t, want false, true and true",
+			spec.OrderEnforce, spec.DisableEnvChecker, spec.Nondeterministic)
+	}
+	if spec.RewardThreshold == nil || *spec.RewardThreshold != 10 {
+		t.Errorf("RewardThreshold = %v, want 10", spec.RewardThreshold)
+	}
+	if spec.MaxEpisodeSteps == nil || *spec.MaxEpisodeSteps != 20 {
+		t.Errorf("MaxEpisodeSteps = %v, want 20", spec.MaxEpisodeSteps)
+	}
+	if spec.Kwargs["size"] != 3 || len(spec.Kwargs) != 1 {
+		t.Errorf("Kwargs = %v, want map[size:3]", spec.Kwargs)
+	}
+}
+
+func TestMakeVecSeedsDistinctStreams(t *testing.T) {
+	// sample returns 20 draws of IntN(2) from the RNG of each instance and 20 sampled actions of each instance
+	sample := func(baseSeed int64) ([][]int, [][]int) {
+		t.Helper()
+
+		envs, err := gym.MakeVec[[]float64, int]("CartPole-v1", 3, baseSeed)
+		if err != nil {
+			t.Fatalf("MakeVec failed: %v", err)
+		}
+		draws := make([][]int, len(envs))
+		actions := make([][]int, len(envs))
+		for i, env := range envs {
+			defer env.Close()
+
+			draws[i] = make([]int, 20)
+			actions[i] = make([]int, 20)
+			for j := range 20 {
+				draws[i][j] = env.GetRNG().IntN(2)
+				if actions[i][j], err = env.ActionSpace().Sample(nil, nil); err != nil {
+					t.Fatalf("Sample failed: %v", err)
+				}
+			}
+		}
+		return draws, actions
+	}
+
+	draws, actions := sample(10)
+	againDraws, againActions := sample(10)
+	for i := range draws {
+		if !slices.Equal(draws[i], againDraws[i]) || !slices.Equal(actions[i], againActions[i]) {
+			t.Errorf("instance %d of base seed 10 drew %v and %v, then %v and %v",
+				i, draws[i], actions[i], againDraws[i], againActions[i])
+		}
+		// The RNG and the action space are seeded with distinct seeds, so they do not draw the same stream
+		if slices.Equal(draws[i], actions[i]) {
+			t.Errorf("RNG and action space of instance %d drew the same stream %v", i, draws[i])
+		}
+	}
+	if slices.Equal(actions[0], actions[1]) || slices.Equal(actions[1], actions[2]) {
+		t.Errorf("instances sampled the actions %v, want distinct sequences", actions)
+	}
+
+	if _, err := gym.MakeVec[[]float64, int]("CartPole-v1", 0, 1); err == nil {
+		t.Error("MakeVec of 0 instances succeeded, expected an error")
+	}
+	if _, err := gym.MakeVec[[]float64, int]("CartPole-v1", 2, -1); err == nil {
+		t.Error("MakeVec with base seed -1 succeeded, expected an error")
+	}
+}
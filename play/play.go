@@ -0,0 +1,106 @@
+// Package play provides utilities for playing environments interactively with the keyboard.
+//
+// It is kept separate from the core gym package so that only programs that play environments
+// interactively depend on Ebiten's input handling.
+package play
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gocnn/gym"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Play lets a human control an environment in real time with the keyboard.
+//
+// The environment should be created with the "human" render mode, so that rendering opens the window
+// receiving the keyboard input. At every frame, the pressed key with the lowest key code found in keymap
+// selects the action, or noop is used when no mapped key is pressed. Episodes are reset automatically when
+// they terminate or truncate. Play runs at the environment's "render_fps" metadata (30 if absent) until ctx
+// is cancelled or the Escape key is pressed.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - env: The environment to play, rendered in "human" mode
+//   - keymap: The action taken while each key is pressed
+//   - noop: The action taken when no mapped key is pressed
+//
+// Returns:
+//   - An error if a mapped action is not in the action space or the environment fails
+func Play[Obs any, Act any](ctx context.Context, env gym.Env[Obs, Act], keymap map[ebiten.Key]Act, noop Act) error {
+	actionSpace := env.ActionSpace()
+	for key, action := range keymap {
+		if !actionSpace.Contains(action) {
+			return fmt.Errorf("action %v mapped to key %s is not in the action space", action, key)
+		}
+	}
+	if !actionSpace.Contains(noop) {
+		return fmt.Errorf("noop action %v is not in the action space", noop)
+	}
+
+	// Check keys in a fixed order so that simultaneous presses resolve deterministically
+	keys := make([]ebiten.Key, 0, len(keymap))
+	for key := range keymap {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	fps := 30
+	switch v := env.Metadata()["render_fps"].(type) {
+	case int:
+		fps = v
+	case float64:
+		fps = int(v)
+	}
+	if fps <= 0 {
+		return fmt.Errorf("render_fps must be positive, got %d", fps)
+	}
+
+	if _, _, err := env.Reset(ctx, nil, nil); err != nil {
+		return fmt.Errorf("failed to reset environment: %w", err)
+	}
+	if _, err := env.Render(); err != nil {
+		return fmt.Errorf("failed to render environment: %w", err)
+	}
+
+	ticker := time.NewTicker(time.Second / time.Duration(fps))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		if ebiten.IsKeyPressed(ebiten.KeyEscape) {
+			return nil
+		}
+
+		action := noop
+		for _, key := range keys {
+			if ebiten.IsKeyPressed(key) {
+				action = keymap[key]
+				break
+			}
+		}
+
+		_, _, terminated, truncated, _, err := env.Step(ctx, action)
+		if err != nil {
+			return fmt.Errorf("failed to step environment: %w", err)
+		}
+
+		if terminated || truncated {
+			if _, _, err := env.Reset(ctx, nil, nil); err != nil {
+				return fmt.Errorf("failed to reset environment: %w", err)
+			}
+		}
+
+		if _, err := env.Render(); err != nil {
+			return fmt.Errorf("failed to render environment: %w", err)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package rand
+
+import (
+	"fmt"
+	"math"
+)
+
+// Thresholds above which the rejection algorithms replace inversion, following NumPy.
+const (
+	binomialInversionLimit = 30 // Limit of n*min(p, 1-p)
+	poissonInversionLimit  = 10 // Limit of lambda
+)
+
+// Binomial returns the number of successes in n independent trials that each succeed with probability p.
+// It panics if n < 0 or p is not in [0, 1].
+//
+// Small expected counts are drawn by inversion and large ones by the BTPE algorithm of Kachitvichyanukul
+// and Schmeiser, so the cost does not grow with n.
+func (r *RNG) Binomial(n int, p float64) int {
+	if n < 0 {
+		panic(fmt.Sprintf("invalid argument to Binomial: n %d", n))
+	}
+	if !(p >= 0 && p <= 1) {
+		panic(fmt.Sprintf("invalid argument to Binomial: p %f", p))
+	}
+
+	if n == 0 || p == 0 {
+		return 0
+	}
+	if p == 1 {
+		return n
+	}
+
+	// Both algorithms draw the count of the less likely outcome
+	prob := min(p, 1-p)
+	var count int
+	if float64(n)*prob < binomialInversionLimit {
+		count = r.binomialInversion(n, prob)
+	} else {
+		count = r.binomialBTPE(n, prob)
+	}
+
+	if p > 0.5 {
+		return n - count
+	}
+	return count
+}
+
+// binomialInversion draws a binomial variate with p <= 0.5 by sequential search of the distribution function.
+func (r *RNG) binomialInversion(n int, p float64) int {
+	q := 1 - p
+	qn := math.Exp(float64(n) * math.Log(q))
+	np := float64(n) * p
+	bound := min(float64(n), np+10*math.Sqrt(np*q+1))
+
+	x := 0
+	px := qn
+	u := r.Float64()
+	for u > px {
+		x++
+		if float64(x) > bound {
+			// Restart, the remaining mass beyond the bound is negligible
+			x = 0
+			px = qn
+			u = r.Float64()
+		} else {
+			u -= px
+			px = float64(n-x+1) * p * px / (float64(x) * q)
+		}
+	}
+	return x
+}
+
+// binomialBTPE draws a binomial variate with p <= 0.5 and n*p >= 30 with the BTPE algorithm.
+func (r *RNG) binomialBTPE(n int, p float64) int {
+	nf := float64(n)
+	q := 1 - p
+	fm := nf*p + p
+	m := math.Floor(fm)
+	npq := nf * p * q
+
+	p1 := math.Floor(2.195*math.Sqrt(npq)-4.6*q) + 0.5
+	xm := m + 0.5
+	xl := xm - p1
+	xr := xm + p1
+	c := 0.134 + 20.5/(15.3+m)
+	a := (fm - xl) / (fm - xl*p)
+	laml := a * (1 + a/2)
+	a = (xr - fm) / (xr * q)
+	lamr := a * (1 + a/2)
+	p2 := p1 * (1 + 2*c)
+	p3 := p2 + c/laml
+	p4 := p3 + c/lamr
+
+	for {
+		u := r.Float64() * p4
+		v := r.Float64()
+		var y float64
+
+		switch {
+		case u <= p1:
+			// Triangular region, always accepted
+			return int(math.Floor(xm - p1*v + u))
+		case u <= p2:
+			// Parallelogram region
+			x := xl + (u-p1)/c
+			v = v*c + 1 - math.Abs(m-x+0.5)/p1
+			if v > 1 {
+				continue
+			}
+			y = math.Floor(x)
+		case u <= p3:
+			// Left exponential tail
+			y = math.Floor(xl + math.Log(v)/laml)
+			if y < 0 || v == 0 {
+				continue
+			}
+			v = v * (u - p2) * laml
+		default:
+			// Right exponential tail
+			y = math.Floor(xr - math.Log(v)/lamr)
+			if y > nf || v == 0 {
+				continue
+			}
+			v = v * (u - p3) * lamr
+		}
+
+		k := math.Abs(y - m)
+		if k <= 20 || k >= npq/2-1 {
+			// Explicit evaluation of the probability ratio f(y)/f(m)
+			s := p / q
+			a := s * (nf + 1)
+			f := 1.0
+			if m < y {
+				for i := m + 1; i <= y; i++ {
+					f *= a/i - s
+				}
+			} else if m > y {
+				for i := y + 1; i <= m; i++ {
+					f /= a/i - s
+				}
+			}
+			if v <= f {
+				return int(y)
+			}
+			continue
+		}
+
+		// Squeeze using upper and lower bounds on log(f(y)/f(m))
+		rho := (k / npq) * ((k*(k/3+0.625)+1.0/6)/npq + 0.5)
+		t := -k * k / (2 * npq)
+		logV := math.Log(v)
+		if logV < t-rho {
+			return int(y)
+		}
+		if logV > t+rho {
+			continue
+		}
+
+		x1 := y + 1
+		f1 := m + 1
+		z := nf + 1 - m
+		w := nf - y + 1
+		bound := xm*math.Log(f1/x1) + (nf-m+0.5)*math.Log(z/w) + (y-m)*math.Log(w*p/(x1*q)) +
+			stirlingCorrection(f1) + stirlingCorrection(z) + stirlingCorrection(x1) + stirlingCorrection(w)
+		if logV <= bound {
+			return int(y)
+		}
+	}
+}
+
+// stirlingCorrection returns the correction term of Stirling's approximation used by BTPE.
+func stirlingCorrection(x float64) float64 {
+	x2 := x * x
+	return (13860 - (462-(132-(99-140/x2)/x2)/x2)/x2) / x / 166320
+}
+
+// Poisson returns a Poisson distributed count with mean lambda.
+// It panics if lambda < 0.
+//
+// Small means are drawn by inversion and large ones by the PTRS transformed rejection algorithm of Hörmann,
+// so the cost does not grow with lambda.
+func (r *RNG) Poisson(lambda float64) int {
+	if !(lambda >= 0) || math.IsInf(lambda, 1) {
+		panic(fmt.Sprintf("invalid argument to Poisson: lambda %f", lambda))
+	}
+
+	if lambda == 0 {
+		return 0
+	}
+	if lambda < poissonInversionLimit {
+		return r.poissonInversion(lambda)
+	}
+	return r.poissonPTRS(lambda)
+}
+
+// poissonInversion draws a Poisson variate by sequential search of the distribution function.
+func (r *RNG) poissonInversion(lambda float64) int {
+	x := 0
+	px := math.Exp(-lambda)
+	u := r.Float64()
+	for u > px {
+		u -= px
+		x++
+		px *= lambda / float64(x)
+		if px == 0 {
+			// The remaining mass is below float64 precision
+			break
+		}
+	}
+	return x
+}
+
+// poissonPTRS draws a Poisson variate with lambda >= 10 with the PTRS algorithm.
+func (r *RNG) poissonPTRS(lambda float64) int {
+	sqrtLambda := math.Sqrt(lambda)
+	logLambda := math.Log(lambda)
+	b := 0.931 + 2.53*sqrtLambda
+	a := -0.059 + 0.02483*b
+	invAlpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+
+	for {
+		u := r.Float64() - 0.5
+		v := r.Float64()
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + lambda + 0.43)
+
+		if us >= 0.07 && v <= vr {
+			return int(k)
+		}
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+
+		lg, _ := math.Lgamma(k + 1)
+		if math.Log(v)+math.Log(invAlpha)-math.Log(a/(us*us)+b) <= -lambda+k*logLambda-lg {
+			return int(k)
+		}
+	}
+}
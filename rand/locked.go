@@ -0,0 +1,58 @@
+package rand
+
+import "fmt"
+
+// LockedRNG gives access to an RNG whose lock is already held, see RNG.WithLock.
+//
+// Its methods produce exactly the same values as the RNG methods of the same name, without locking
+// for every call. It must not be used after the function passed to WithLock returns.
+type LockedRNG struct {
+	r *RNG
+}
+
+// WithLock calls fn with the lock of the RNG held for its whole duration.
+//
+// Drawing many values through the LockedRNG passed to fn acquires the lock once instead of once per value,
+// which reduces contention when sampling large batches. fn must not call methods of the RNG itself, which
+// would deadlock.
+//
+// Parameters:
+//   - fn: The function drawing values from the locked RNG
+func (r *RNG) WithLock(fn func(l *LockedRNG)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fn(&LockedRNG{r: r})
+}
+
+// IntN returns, as an int, a non-negative pseudo-random number in the half-open interval [0,n).
+// It panics if n <= 0.
+func (l *LockedRNG) IntN(n int) int {
+	if n <= 0 {
+		panic(fmt.Sprintf("invalid argument to IntN: %d", n))
+	}
+	return int(l.r.uint64n(uint64(n)))
+}
+
+// Int64N returns, as an int64, a non-negative pseudo-random number in the half-open interval [0,n).
+// It panics if n <= 0.
+func (l *LockedRNG) Int64N(n int64) int64 {
+	if n <= 0 {
+		panic(fmt.Sprintf("invalid argument to Int64N: %d", n))
+	}
+	return int64(l.r.uint64n(uint64(n)))
+}
+
+// Float64 returns, as a float64, a pseudo-random number in the half-open interval [0.0,1.0).
+func (l *LockedRNG) Float64() float64 {
+	return toFloat64(l.r.src.Uint64())
+}
+
+// NormFloat64 returns a normally distributed float64 with standard normal distribution.
+func (l *LockedRNG) NormFloat64() float64 {
+	return l.r.normFloat64()
+}
+
+// ExpFloat64 returns an exponentially distributed float64 whose rate parameter (lambda) is 1.
+func (l *LockedRNG) ExpFloat64() float64 {
+	return l.r.expFloat64()
+}
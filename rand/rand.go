@@ -3,27 +3,43 @@
 // This package provides seeding, generator, and random number generation functions
 // similar to Python Gymnasium's seeding utilities, ensuring reproducible behavior
 // across reinforcement learning experiments.
+//
+// Reproducibility: a seed s initializes a PCG-DXSM generator (math/rand/v2's PCG) with both of its
+// 64-bit state words set to uint64(s). Every value, including bounded integers, permutations and normal and
+// exponential variates, is derived from the generator's output with fixed algorithms defined in this
+// package rather than by math/rand/v2, so a given positive seed yields the same sequence on every platform
+// and Go version. Arithmetic on drawn values that feeds a reproducible
+// result converts products with float64() before adding them, so the compiler cannot fuse them into FMA
+// instructions on architectures that have them. A seed of 0 is replaced by a time-based seed and is
+// therefore never reproducible.
 package rand
 
 import (
+	"encoding/binary"
 	"fmt"
+	"math"
+	"math/bits"
 	"math/rand/v2"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // RNG is a seeded random number generator that provides thread-safe random number generation
 // with comprehensive utility methods for reinforcement learning environments.
 type RNG struct {
-	rng  *rand.Rand
+	src  *rand.PCG // The generator every value is derived from
 	seed int64
 	mu   sync.RWMutex
+
+	children *SeedSequence // Seeds of the RNGs spawned since the last seeding, nil until the first Spawn
 }
 
 // DefaultRNG is a singleton instance for global use
 var (
-	defaultRNG     *RNG
-	defaultRNGOnce sync.Once
+	defaultRNG         *RNG
+	defaultRNGOnce     sync.Once
+	defaultRNGDisabled atomic.Bool
 )
 
 // NewRNG creates a new RNG instance with the given seed.
@@ -48,22 +64,30 @@ func NewRNG(seed int64) (*RNG, int64, error) {
 		effectiveSeed = time.Now().UnixNano()
 	}
 
-	source := rand.NewPCG(uint64(effectiveSeed), uint64(effectiveSeed))
 	rng := &RNG{
-		rng:  rand.New(source),
+		src:  newSource(effectiveSeed),
 		seed: effectiveSeed,
 	}
 
 	return rng, effectiveSeed, nil
 }
 
+// newSource creates the PCG source for a seed, with both state words set to the seed.
+func newSource(seed int64) *rand.PCG {
+	return rand.NewPCG(uint64(seed), uint64(seed))
+}
+
 // DefaultRNG returns the singleton default RNG instance.
 //
 // This is thread-safe and will be initialized with a random seed on first access.
+// It panics if the default RNG has been disabled with DisableDefaultRNG.
 //
 // Returns:
 //   - The default RNG instance
 func GetDefaultRNG() *RNG {
+	if defaultRNGDisabled.Load() {
+		panic("rand: default RNG is disabled, an explicit RNG must be provided")
+	}
 	defaultRNGOnce.Do(func() {
 		rng, _, _ := NewRNG(0) // Use random seed
 		defaultRNG = rng
@@ -71,6 +95,28 @@ func GetDefaultRNG() *RNG {
 	return defaultRNG
 }
 
+// DisableDefaultRNG forbids any further use of the default RNG.
+//
+// Once disabled, GetDefaultRNG panics and spaces constructed without an explicit RNG return an error.
+// This flushes out hidden reliance on shared global randomness in code that requires strict reproducibility.
+// The default RNG can also be disabled at build time with the "strictrng" build tag.
+func DisableDefaultRNG() {
+	defaultRNGDisabled.Store(true)
+}
+
+// EnableDefaultRNG allows use of the default RNG again after DisableDefaultRNG.
+func EnableDefaultRNG() {
+	defaultRNGDisabled.Store(false)
+}
+
+// DefaultRNGDisabled reports whether the default RNG has been disabled.
+//
+// Returns:
+//   - true if the default RNG must not be used, false otherwise
+func DefaultRNGDisabled() bool {
+	return defaultRNGDisabled.Load()
+}
+
 // Seed resets the RNG with a new seed value.
 //
 // Parameters:
@@ -89,14 +135,36 @@ func (r *RNG) Seed(seed int64) (int64, error) {
 		effectiveSeed = time.Now().UnixNano()
 	}
 
+	r.reseed(effectiveSeed)
+	return effectiveSeed, nil
+}
+
+// SeedExact resets the RNG with a new seed value, using 0 as an ordinary seed.
+//
+// Unlike Seed, a seed of 0 is not replaced by a time-based seed, so every valid seed gives a reproducible sequence.
+//
+// Parameters:
+//   - seed: The new seed value. Must be non-negative.
+//
+// Returns:
+//   - An error if the seed is invalid
+func (r *RNG) SeedExact(seed int64) error {
+	if seed < 0 {
+		return fmt.Errorf("seed must be non-negative, got: %d", seed)
+	}
+
+	r.reseed(seed)
+	return nil
+}
+
+// reseed replaces the source of the RNG with a source for seed.
+func (r *RNG) reseed(seed int64) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	source := rand.NewPCG(uint64(effectiveSeed), uint64(effectiveSeed))
-	r.rng = rand.New(source)
-	r.seed = effectiveSeed
-
-	return effectiveSeed, nil
+	r.src = newSource(seed)
+	r.seed = seed
+	r.children = nil
 }
 
 // GetSeed returns the current seed value.
@@ -109,11 +177,57 @@ func (r *RNG) GetSeed() int64 {
 	return r.seed
 }
 
+// State returns a snapshot of the internal state of the RNG.
+//
+// The snapshot holds the seed and the state of the PCG generator, so restoring it with SetState
+// continues the sequence exactly where it was taken.
+//
+// Returns:
+//   - The serialized state
+//   - An error if the generator state cannot be serialized
+func (r *RNG) State() ([]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	pcg, err := r.src.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal generator state: %w", err)
+	}
+	return binary.BigEndian.AppendUint64(pcg, uint64(r.seed)), nil
+}
+
+// SetState restores a snapshot of the internal state taken with State.
+//
+// Parameters:
+//   - state: The serialized state returned by State
+//
+// Returns:
+//   - An error if the state is malformed
+func (r *RNG) SetState(state []byte) error {
+	if len(state) < 8 {
+		return fmt.Errorf("invalid RNG state: too short")
+	}
+	pcg, seed := state[:len(state)-8], int64(binary.BigEndian.Uint64(state[len(state)-8:]))
+
+	src := &rand.PCG{}
+	if err := src.UnmarshalBinary(pcg); err != nil {
+		return fmt.Errorf("invalid RNG state: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.src = src
+	r.seed = seed
+	r.children = nil
+	return nil
+}
+
 // Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
 func (r *RNG) Int63() int64 {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	return r.rng.Int64() >> 1 // Ensure non-negative by clearing sign bit
+	return r.int64() >> 1
 }
 
 // Int returns a non-negative pseudo-random int.
@@ -125,14 +239,14 @@ func (r *RNG) Int() int {
 func (r *RNG) Int64() int64 {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	return r.rng.Int64()
+	return r.int64()
 }
 
 // Uint64 returns a pseudo-random 64-bit value as a uint64.
 func (r *RNG) Uint64() uint64 {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	return r.rng.Uint64()
+	return r.src.Uint64()
 }
 
 // IntN returns, as an int, a non-negative pseudo-random number in the half-open interval [0,n).
@@ -143,7 +257,7 @@ func (r *RNG) IntN(n int) int {
 	}
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	return r.rng.IntN(n)
+	return int(r.uint64n(uint64(n)))
 }
 
 // Int64N returns, as an int64, a non-negative pseudo-random number in the half-open interval [0,n).
@@ -154,21 +268,100 @@ func (r *RNG) Int64N(n int64) int64 {
 	}
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	return r.rng.Int64N(n)
+	return int64(r.uint64n(uint64(n)))
 }
 
 // Float32 returns, as a float32, a pseudo-random number in the half-open interval [0.0,1.0).
 func (r *RNG) Float32() float32 {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	return r.rng.Float32()
+	// There are exactly 1<<24 float32s in [0,1), taken from the upper half of the output.
+	return float32(uint32(r.src.Uint64()>>32)<<8>>8) / (1 << 24)
 }
 
 // Float64 returns, as a float64, a pseudo-random number in the half-open interval [0.0,1.0).
 func (r *RNG) Float64() float64 {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	return r.rng.Float64()
+	return toFloat64(r.src.Uint64())
+}
+
+// toFloat64 maps a generator output to a float64 in the half-open interval [0.0,1.0).
+func toFloat64(u uint64) float64 {
+	// There are exactly 1<<53 float64s in [0,1), taken from the low bits of the output.
+	return float64(u<<11>>11) / (1 << 53)
+}
+
+// int64 returns the next generator output with its top bit cleared. The caller must hold the lock.
+func (r *RNG) int64() int64 {
+	return int64(r.src.Uint64() &^ (1 << 63))
+}
+
+// uint64n returns a pseudo-random number in the half-open interval [0,n) for n > 0. The caller must hold the
+// lock.
+//
+// It uses Lemire's multiply-shift method, rejecting the few outputs that would bias the result, with 64-bit
+// arithmetic on every platform.
+func (r *RNG) uint64n(n uint64) uint64 {
+	if n&(n-1) == 0 {
+		return r.src.Uint64() & (n - 1)
+	}
+
+	hi, lo := bits.Mul64(r.src.Uint64(), n)
+	if lo < n {
+		thresh := -n % n
+		for lo < thresh {
+			hi, lo = bits.Mul64(r.src.Uint64(), n)
+		}
+	}
+	return hi
+}
+
+// normFloat64 returns a standard normal variate drawn with the Marsaglia polar method. The caller must hold
+// the lock.
+//
+// Only the first variate of each accepted pair is used, so the RNG keeps no state besides the generator.
+func (r *RNG) normFloat64() float64 {
+	for {
+		u := 2*toFloat64(r.src.Uint64()) - 1
+		v := 2*toFloat64(r.src.Uint64()) - 1
+		s := float64(u*u) + float64(v*v)
+		if s > 0 && s < 1 {
+			return u * math.Sqrt(-2*math.Log(s)/s)
+		}
+	}
+}
+
+// expFloat64 returns an exponential variate with rate 1 drawn by inversion. The caller must hold the lock.
+func (r *RNG) expFloat64() float64 {
+	for {
+		if u := toFloat64(r.src.Uint64()); u > 0 {
+			return -math.Log(u)
+		}
+	}
+}
+
+// Float64Range returns, as a float64, a pseudo-random number in the half-open interval [low,high).
+// It panics if high < low, and returns low if high == low.
+func (r *RNG) Float64Range(low, high float64) float64 {
+	if !(high >= low) {
+		panic(fmt.Sprintf("invalid argument to Float64Range: [%f, %f)", low, high))
+	}
+	// The conversion keeps the product from being fused into an FMA instruction, which would make the
+	// result depend on the platform
+	return low + float64(r.Float64()*(high-low))
+}
+
+// IntRange returns, as an int, a pseudo-random number in the half-open interval [low,high).
+// It panics if high < low, and returns low without drawing a number if high == low.
+func (r *RNG) IntRange(low, high int) int {
+	if high < low {
+		panic(fmt.Sprintf("invalid argument to IntRange: [%d, %d)", low, high))
+	}
+	if high == low {
+		return low
+	}
+	return low + r.IntN(high-low)
 }
 
 // NormFloat64 returns a normally distributed float64 in the range [-math.MaxFloat64, +math.MaxFloat64]
@@ -176,7 +369,7 @@ func (r *RNG) Float64() float64 {
 func (r *RNG) NormFloat64() float64 {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	return r.rng.NormFloat64()
+	return r.normFloat64()
 }
 
 // ExpFloat64 returns an exponentially distributed float64 in the range (0, +math.MaxFloat64]
@@ -184,7 +377,7 @@ func (r *RNG) NormFloat64() float64 {
 func (r *RNG) ExpFloat64() float64 {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	return r.rng.ExpFloat64()
+	return r.expFloat64()
 }
 
 // Perm returns, as a slice of n ints, a pseudo-random permutation of the integers [0,n).
@@ -193,9 +386,12 @@ func (r *RNG) Perm(n int) []int {
 	if n < 0 {
 		panic(fmt.Sprintf("invalid argument to Perm: %d", n))
 	}
-	r.mu.Lock()
-	defer r.mu.Unlock()
-	return r.rng.Perm(n)
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	r.Shuffle(n, func(i, j int) { perm[i], perm[j] = perm[j], perm[i] })
+	return perm
 }
 
 // Shuffle pseudo-randomizes the order of elements using the Fisher-Yates shuffle algorithm.
@@ -206,5 +402,142 @@ func (r *RNG) Shuffle(n int, swap func(i, j int)) {
 	}
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.rng.Shuffle(n, swap)
+	for i := n - 1; i > 0; i-- {
+		swap(i, int(r.uint64n(uint64(i+1))))
+	}
+}
+
+// Choice returns a random index in the half-open interval [0,n).
+//
+// Parameters:
+//   - n: The number of choices (must be positive)
+//   - weights: The relative weights of the choices, or nil for a uniform choice. They are normalized by
+//     their sum, must have length n, be non-negative and have a positive sum.
+//
+// Returns:
+//   - The chosen index
+//   - An error if n or weights are invalid
+func (r *RNG) Choice(n int, weights []float64) (int, error) {
+	if n <= 0 {
+		return 0, fmt.Errorf("n must be positive, got %d", n)
+	}
+
+	if weights == nil {
+		return r.IntN(n), nil
+	}
+
+	if len(weights) != n {
+		return 0, fmt.Errorf("weights must have length %d, got %d", n, len(weights))
+	}
+
+	total := 0.0
+	for i, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return 0, fmt.Errorf("weights must be finite and non-negative, got %f at index %d", w, i)
+		}
+		total += w
+	}
+	if total <= 0 {
+		return 0, fmt.Errorf("weights must have a positive sum")
+	}
+
+	target := r.Float64() * total
+	last := 0
+	for i, w := range weights {
+		if w == 0 {
+			continue
+		}
+		if target < w {
+			return i, nil
+		}
+		target -= w
+		last = i
+	}
+	// Rounding can leave a tiny remainder, which belongs to the last choice with positive weight
+	return last, nil
+}
+
+// Normal returns a normally distributed float64 with the given mean and standard deviation.
+// It panics if stddev < 0.
+func (r *RNG) Normal(mean, stddev float64) float64 {
+	if stddev < 0 {
+		panic(fmt.Sprintf("invalid argument to Normal: stddev %f", stddev))
+	}
+	return mean + float64(stddev*r.NormFloat64())
+}
+
+// NormalN returns n normally distributed float64s with the given mean and standard deviation.
+// It panics if stddev < 0 or n < 0.
+func (r *RNG) NormalN(mean, stddev float64, n int) []float64 {
+	if stddev < 0 {
+		panic(fmt.Sprintf("invalid argument to NormalN: stddev %f", stddev))
+	}
+	if n < 0 {
+		panic(fmt.Sprintf("invalid argument to NormalN: %d", n))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	samples := make([]float64, n)
+	for i := range samples {
+		samples[i] = mean + float64(stddev*r.normFloat64())
+	}
+	return samples
+}
+
+// Clone returns an independent copy of the RNG that continues the same sequence.
+//
+// Returns:
+//   - A new RNG in the same state
+func (r *RNG) Clone() *RNG {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	src := *r.src
+	clone := &RNG{
+		src:  &src,
+		seed: r.seed,
+	}
+	if r.children != nil {
+		clone.children = &SeedSequence{state: r.children.state}
+	}
+	return clone
+}
+
+// Derive creates a new RNG seeded from the next value of this RNG.
+//
+// The derived RNG has its own sequence, so it can be handed to a space or another component without
+// sharing draws with this RNG, and it is reproducible whenever this RNG is seeded.
+//
+// Returns:
+//   - A new RNG seeded with a positive value drawn from this RNG
+func (r *RNG) Derive() *RNG {
+	seed := r.Int64N(math.MaxInt64) + 1
+	return &RNG{
+		src:  newSource(seed),
+		seed: seed,
+	}
+}
+
+// Spawn creates a new RNG with its own sequence without advancing this RNG.
+//
+// The spawned RNGs are seeded with the successive seeds of a SeedSequence rooted at the next value of this
+// RNG when it first spawns, so RNGs spawned from the same RNG are distinct from each other, and the k-th RNG
+// spawned after seeding is reproducible. Seeding the RNG or restoring its state restarts the spawned seeds.
+//
+// Returns:
+//   - A new RNG seeded with the next spawned seed
+func (r *RNG) Spawn() *RNG {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.children == nil {
+		src := *r.src
+		r.children = &SeedSequence{state: src.Uint64()}
+	}
+	seed := r.children.Spawn(1)[0]
+	return &RNG{
+		src:  newSource(seed),
+		seed: seed,
+	}
 }
@@ -0,0 +1,370 @@
+package rand_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/gocnn/gym/rand"
+)
+
+// newRNG creates an RNG with the given seed, failing the test on error.
+func newRNG(t *testing.T, seed int64) *rand.RNG {
+	t.Helper()
+
+	rng, _, err := rand.NewRNG(seed)
+	if err != nil {
+		t.Fatalf("NewRNG failed: %v", err)
+	}
+	return rng
+}
+
+// draw returns the next n values of rng.
+func draw(rng *rand.RNG, n int) []float64 {
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = rng.Float64()
+	}
+	return values
+}
+
+func TestStateRestoresSequence(t *testing.T) {
+	rng := newRNG(t, 42)
+	draw(rng, 10)
+
+	state, err := rng.State()
+	if err != nil {
+		t.Fatalf("State failed: %v", err)
+	}
+	want := draw(rng, 100)
+
+	// Restoring into the same RNG and into a differently seeded one both replay the sequence
+	if err := rng.SetState(state); err != nil {
+		t.Fatalf("SetState failed: %v", err)
+	}
+	if got := draw(rng, 100); !slices.Equal(got, want) {
+		t.Error("restored RNG drew a different sequence")
+	}
+
+	other := newRNG(t, 7)
+	if err := other.SetState(state); err != nil {
+		t.Fatalf("SetState failed: %v", err)
+	}
+	if got := draw(other, 100); !slices.Equal(got, want) {
+		t.Error("RNG restored from another RNG's state drew a different sequence")
+	}
+	if other.GetSeed() != 42 {
+		t.Errorf("GetSeed() after SetState = %d, want 42", other.GetSeed())
+	}
+
+	if err := other.SetState([]byte{1, 2, 3}); err == nil {
+		t.Error("SetState with a truncated state succeeded, expected an error")
+	}
+}
+
+func TestChoiceFrequencies(t *testing.T) {
+	const draws = 100000
+	rng := newRNG(t, 3)
+	weights := []float64{1, 0, 3, 6}
+
+	counts := make([]int, len(weights))
+	for range draws {
+		i, err := rng.Choice(len(weights), weights)
+		if err != nil {
+			t.Fatalf("Choice failed: %v", err)
+		}
+		counts[i]++
+	}
+
+	// The standard deviation of each frequency is at most 0.0016
+	for i, w := range weights {
+		want := w / 10
+		if got := float64(counts[i]) / draws; math.Abs(got-want) > 0.01 {
+			t.Errorf("index %d chosen with frequency %.4f, want %.2f", i, got, want)
+		}
+	}
+	if counts[1] != 0 {
+		t.Errorf("index with weight 0 chosen %d times", counts[1])
+	}
+
+	for _, invalid := range [][]float64{{1, 2}, {1, -1, 1, 1}, {0, 0, 0, 0}, {1, math.NaN(), 1, 1}} {
+		if _, err := rng.Choice(len(weights), invalid); err == nil {
+			t.Errorf("Choice with weights %v succeeded, expected an error", invalid)
+		}
+	}
+}
+
+func TestNormalMoments(t *testing.T) {
+	const n, mean, stddev = 100000, 2.5, 3.0
+	rng := newRNG(t, 11)
+
+	check := func(name string, samples []float64) {
+		t.Helper()
+
+		var sum, sumSq float64
+		for _, x := range samples {
+			sum += x
+		}
+		sampleMean := sum / n
+		for _, x := range samples {
+			sumSq += (x - sampleMean) * (x - sampleMean)
+		}
+		sampleVar := sumSq / (n - 1)
+
+		// The standard errors of the mean and variance are about 0.01 and 0.04
+		if math.Abs(sampleMean-mean) > 0.05 {
+			t.Errorf("%s sample mean = %f, want %f", name, sampleMean, mean)
+		}
+		if math.Abs(sampleVar-stddev*stddev) > 0.2 {
+			t.Errorf("%s sample variance = %f, want %f", name, sampleVar, stddev*stddev)
+		}
+	}
+
+	samples := make([]float64, n)
+	for i := range samples {
+		samples[i] = rng.Normal(mean, stddev)
+	}
+	check("Normal", samples)
+	check("NormalN", rng.NormalN(mean, stddev, n))
+}
+
+// moments returns the sample mean and variance of samples.
+func moments(samples []float64) (float64, float64) {
+	var sum, sumSq float64
+	for _, x := range samples {
+		sum += x
+	}
+	mean := sum / float64(len(samples))
+	for _, x := range samples {
+		sumSq += (x - mean) * (x - mean)
+	}
+	return mean, sumSq / float64(len(samples)-1)
+}
+
+func TestBinomialPoissonMoments(t *testing.T) {
+	const n = 100000
+	rng := newRNG(t, 13)
+
+	tests := []struct {
+		name           string
+		draw           func() int
+		mean, variance float64
+	}{
+		// Inversion and BTPE, the latter also for p > 0.5
+		{"Binomial(20, 0.3)", func() int { return rng.Binomial(20, 0.3) }, 6, 4.2},
+		{"Binomial(1000, 0.4)", func() int { return rng.Binomial(1000, 0.4) }, 400, 240},
+		{"Binomial(1000, 0.9)", func() int { return rng.Binomial(1000, 0.9) }, 900, 90},
+		// Inversion and PTRS
+		{"Poisson(3)", func() int { return rng.Poisson(3) }, 3, 3},
+		{"Poisson(50)", func() int { return rng.Poisson(50) }, 50, 50},
+	}
+	for _, tt := range tests {
+		samples := make([]float64, n)
+		for i := range samples {
+			samples[i] = float64(tt.draw())
+		}
+		mean, variance := moments(samples)
+
+		// Allow five standard errors of the sample mean and variance
+		if tol := 5 * math.Sqrt(tt.variance/n); math.Abs(mean-tt.mean) > tol {
+			t.Errorf("%s sample mean = %f, want %f", tt.name, mean, tt.mean)
+		}
+		if tol := 5 * tt.variance * math.Sqrt(2.0/n); math.Abs(variance-tt.variance) > tol {
+			t.Errorf("%s sample variance = %f, want %f", tt.name, variance, tt.variance)
+		}
+	}
+
+	if got := rng.Binomial(10, 0); got != 0 {
+		t.Errorf("Binomial(10, 0) = %d, want 0", got)
+	}
+	if got := rng.Binomial(10, 1); got != 10 {
+		t.Errorf("Binomial(10, 1) = %d, want 10", got)
+	}
+	if got := rng.Poisson(0); got != 0 {
+		t.Errorf("Poisson(0) = %d, want 0", got)
+	}
+}
+
+func TestBinomialPoissonPanic(t *testing.T) {
+	rng := newRNG(t, 13)
+	invalid := map[string]func(){
+		"Binomial(-1, 0.5)": func() { rng.Binomial(-1, 0.5) },
+		"Binomial(10, 1.5)": func() { rng.Binomial(10, 1.5) },
+		"Binomial(10, NaN)": func() { rng.Binomial(10, math.NaN()) },
+		"Poisson(-1)":       func() { rng.Poisson(-1) },
+		"Poisson(+Inf)":     func() { rng.Poisson(math.Inf(1)) },
+	}
+	for name, call := range invalid {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", name)
+				}
+			}()
+			call()
+		}()
+	}
+}
+
+func TestRanges(t *testing.T) {
+	rng := newRNG(t, 5)
+
+	for range 10000 {
+		if x := rng.Float64Range(-2, 3); x < -2 || x >= 3 {
+			t.Fatalf("Float64Range(-2, 3) = %v, want a value in [-2, 3)", x)
+		}
+	}
+	seen := make(map[int]bool)
+	for range 10000 {
+		x := rng.IntRange(-2, 3)
+		if x < -2 || x >= 3 {
+			t.Fatalf("IntRange(-2, 3) = %d, want a value in [-2, 3)", x)
+		}
+		seen[x] = true
+	}
+	if len(seen) != 5 {
+		t.Errorf("IntRange(-2, 3) drew %v, want every value from -2 to 2", seen)
+	}
+
+	// A degenerate range returns its bound, and IntRange draws no value for it
+	if x := rng.Float64Range(1.5, 1.5); x != 1.5 {
+		t.Errorf("Float64Range(1.5, 1.5) = %v, want 1.5", x)
+	}
+	want := rng.Clone().Float64()
+	if x := rng.IntRange(4, 4); x != 4 {
+		t.Errorf("IntRange(4, 4) = %d, want 4", x)
+	}
+	if got := rng.Float64(); got != want {
+		t.Error("IntRange(4, 4) advanced the RNG")
+	}
+
+	invalid := map[string]func(){
+		"Float64Range(1, 0)":   func() { rng.Float64Range(1, 0) },
+		"Float64Range(NaN, 1)": func() { rng.Float64Range(math.NaN(), 1) },
+		"IntRange(1, 0)":       func() { rng.IntRange(1, 0) },
+	}
+	for name, call := range invalid {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", name)
+				}
+			}()
+			call()
+		}()
+	}
+}
+
+func TestSpawn(t *testing.T) {
+	rng := newRNG(t, 42)
+	want := draw(rng.Clone(), 10)
+
+	a, b := rng.Spawn(), rng.Spawn()
+	if got := draw(rng, 10); !slices.Equal(got, want) {
+		t.Error("spawning advanced the RNG")
+	}
+	if first := draw(a, 10); slices.Equal(first, draw(b, 10)) {
+		t.Errorf("two spawned RNGs drew the same sequence %v", first)
+	}
+
+	// Reseeding restarts the spawned seeds
+	if _, err := rng.Seed(42); err != nil {
+		t.Fatalf("Seed failed: %v", err)
+	}
+	again := rng.Spawn()
+	if again.GetSeed() != a.GetSeed() {
+		t.Errorf("first RNG spawned after reseeding has seed %d, want %d", again.GetSeed(), a.GetSeed())
+	}
+}
+
+func TestSeedSequence(t *testing.T) {
+	spawn := func(root int64, counts ...int) []int64 {
+		t.Helper()
+
+		seq, err := rand.NewSeedSequenceExact(root)
+		if err != nil {
+			t.Fatalf("NewSeedSequenceExact failed: %v", err)
+		}
+		var seeds []int64
+		for _, n := range counts {
+			seeds = append(seeds, seq.Spawn(n)...)
+		}
+		return seeds
+	}
+
+	// The seeds are the SplitMix64 outputs of the root, shifted to 63 bits, on every run and platform
+	want := []int64{6839728766377637706, 1474913046063446145, 2569641874231381929, 3174599030129127882}
+	if got := spawn(42, 4); !slices.Equal(got, want) {
+		t.Errorf("Spawn(4) from root 42 = %v, want %v", got, want)
+	}
+	if got := spawn(42, 1, 0, 3); !slices.Equal(got, want) {
+		t.Errorf("successive spawns from root 42 = %v, want the continued sequence %v", got, want)
+	}
+
+	seq, effectiveSeed, err := rand.NewSeedSequence(42)
+	if err != nil {
+		t.Fatalf("NewSeedSequence failed: %v", err)
+	}
+	if effectiveSeed != 42 {
+		t.Errorf("effective seed = %d, want 42", effectiveSeed)
+	}
+	if got := seq.Spawn(4); !slices.Equal(got, want) {
+		t.Errorf("NewSeedSequence(42) spawned %v, want %v", got, want)
+	}
+
+	// Children are distinct, positive and differ between roots
+	children := spawn(0, 1000)
+	seen := make(map[int64]bool)
+	for _, seed := range children {
+		if seed <= 0 || seen[seed] {
+			t.Fatalf("spawned seed %d is not positive or repeats", seed)
+		}
+		seen[seed] = true
+	}
+	if other := spawn(1, 4); slices.Equal(other, children[:4]) {
+		t.Errorf("roots 0 and 1 spawned the same seeds %v", other)
+	}
+
+	if _, err := rand.NewSeedSequenceExact(-1); err == nil {
+		t.Error("NewSeedSequenceExact(-1) succeeded, expected an error")
+	}
+	if _, _, err := rand.NewSeedSequence(-1); err == nil {
+		t.Error("NewSeedSequence(-1) succeeded, expected an error")
+	}
+}
+
+// TestGoldenSequence checks the values drawn from seed 42 against committed references. Every method derives
+// its values from the generator output with algorithms defined in this package, so the values must not
+// change with the platform or Go version.
+func TestGoldenSequence(t *testing.T) {
+	rng := newRNG(t, 42)
+
+	check := func(name string, got, want any) {
+		t.Helper()
+		if got != want {
+			t.Errorf("%s = %v, want %v", name, got, want)
+		}
+	}
+	check("Uint64", rng.Uint64(), uint64(11423875981923235010))
+	check("Uint64", rng.Uint64(), uint64(6939021390147428351))
+	check("Int63", rng.Int63(), int64(1273897579030916171))
+	check("Int64", rng.Int64(), int64(238070618545708802))
+	check("IntN(10)", rng.IntN(10), 9)
+	check("IntN(1000)", rng.IntN(1000), 266)
+	check("IntN(7)", rng.IntN(7), 1)
+	check("Int64N(1<<40)", rng.Int64N(1<<40), int64(936803163919))
+	check("Int64N(1e12)", rng.Int64N(1e12), int64(819760182608))
+	check("Int64N(3)", rng.Int64N(3), int64(1))
+	check("Float64", rng.Float64(), 0.7371461260447054)
+	check("Float32", rng.Float32(), float32(0.2209726))
+	if got, want := rng.Perm(6), []int{2, 0, 1, 5, 4, 3}; !slices.Equal(got, want) {
+		t.Errorf("Perm(6) = %v, want %v", got, want)
+	}
+	check("NormFloat64", rng.NormFloat64(), -1.408834998067231)
+	check("NormFloat64", rng.NormFloat64(), 0.54334412177297)
+	check("ExpFloat64", rng.ExpFloat64(), 0.8066795678143502)
+	check("ExpFloat64", rng.ExpFloat64(), 0.08155703250343477)
+	check("Normal(1, 2)", rng.Normal(1, 2), -0.1549137850636566)
+	check("Binomial(100, 0.3)", rng.Binomial(100, 0.3), 29)
+	check("Poisson(4)", rng.Poisson(4), 5)
+}
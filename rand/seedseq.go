@@ -0,0 +1,95 @@
+package rand
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SeedSequence spawns independent seeds from a single root seed.
+//
+// The seeds are the successive outputs of a SplitMix64 generator started at the root seed, so the same
+// root always spawns the same seeds on every platform. SplitMix64 mixes every output thoroughly, which keeps
+// generators seeded from consecutive spawned seeds uncorrelated. It is used to seed the subspaces of
+// composite spaces from the seed of the composite.
+type SeedSequence struct {
+	state uint64
+	mu    sync.Mutex
+}
+
+// NewSeedSequence creates a new SeedSequence with the given root seed.
+//
+// If seed is 0, a random seed based on current time will be used, as in NewRNG.
+//
+// Parameters:
+//   - seed: The root seed. Must be non-negative.
+//
+// Returns:
+//   - A new SeedSequence
+//   - The effective root seed used (useful when seed=0)
+//   - An error if the seed is invalid
+func NewSeedSequence(seed int64) (*SeedSequence, int64, error) {
+	if seed < 0 {
+		return nil, 0, fmt.Errorf("seed must be non-negative, got: %d", seed)
+	}
+
+	effectiveSeed := seed
+	if seed == 0 {
+		effectiveSeed = time.Now().UnixNano()
+	}
+
+	return &SeedSequence{state: uint64(effectiveSeed)}, effectiveSeed, nil
+}
+
+// NewSeedSequenceExact creates a new SeedSequence with the given root seed, using 0 as an ordinary seed.
+//
+// Parameters:
+//   - seed: The root seed. Must be non-negative.
+//
+// Returns:
+//   - A new SeedSequence
+//   - An error if the seed is invalid
+func NewSeedSequenceExact(seed int64) (*SeedSequence, error) {
+	if seed < 0 {
+		return nil, fmt.Errorf("seed must be non-negative, got: %d", seed)
+	}
+
+	return &SeedSequence{state: uint64(seed)}, nil
+}
+
+// Spawn returns the next n seeds of the sequence.
+//
+// Spawned seeds are positive, so they are valid, reproducible seeds for NewRNG. Successive calls continue the
+// sequence rather than repeating it. It panics if n < 0.
+//
+// Parameters:
+//   - n: The number of seeds to spawn
+//
+// Returns:
+//   - A slice of n seeds
+func (s *SeedSequence) Spawn(n int) []int64 {
+	if n < 0 {
+		panic(fmt.Sprintf("invalid argument to Spawn: %d", n))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seeds := make([]int64, 0, n)
+	for len(seeds) < n {
+		// Keep 63 bits so the seed is non-negative, and skip 0 which would request a random seed
+		if seed := int64(s.next() >> 1); seed != 0 {
+			seeds = append(seeds, seed)
+		}
+	}
+	return seeds
+}
+
+// next advances the SplitMix64 state and returns its output.
+func (s *SeedSequence) next() uint64 {
+	s.state += 0x9e3779b97f4a7c15
+	z := s.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
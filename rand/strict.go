@@ -0,0 +1,9 @@
+//go:build strictrng
+
+package rand
+
+// The strictrng build tag disables the default RNG for the whole program, requiring
+// every space to be constructed with an explicit RNG.
+func init() {
+	DisableDefaultRNG()
+}
@@ -0,0 +1,185 @@
+package gym
+
+import (
+	"context"
+	"fmt"
+)
+
+// EpisodeResult holds the outcome of an episode run by RunEpisode.
+type EpisodeResult struct {
+	Return     float64 // Sum of the rewards of the episode
+	Length     int     // Number of steps of the episode
+	Terminated bool    // Whether the episode ended in a terminal state
+	Truncated  bool    // Whether the episode was truncated by the environment or by maxSteps
+}
+
+// RunEpisode resets an environment and steps it with a policy until the episode ends.
+//
+// The environment is reset without a seed, so seed it beforehand (e.g. with WithSeed) for reproducible
+// episodes. An episode still running after maxSteps steps is reported as truncated.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - env: The environment to run
+//   - policy: Selects the action for an observation, or nil to sample actions from the action space
+//   - maxSteps: The maximum number of steps of the episode (must be positive)
+//
+// Returns:
+//   - The return, length and end condition of the episode
+//   - An error if maxSteps is not positive or the environment or action sampling fails
+func RunEpisode[Obs any, Act any](ctx context.Context, env Env[Obs, Act], policy func(Obs) Act, maxSteps int) (EpisodeResult, error) {
+	if maxSteps <= 0 {
+		return EpisodeResult{}, fmt.Errorf("max steps must be positive, got %d", maxSteps)
+	}
+
+	obs, _, err := env.Reset(ctx, nil, nil)
+	if err != nil {
+		return EpisodeResult{}, fmt.Errorf("failed to reset environment: %w", err)
+	}
+
+	var result EpisodeResult
+	for result.Length < maxSteps {
+		action, err := selectAction(env, policy, obs)
+		if err != nil {
+			return result, err
+		}
+
+		var reward float64
+		obs, reward, result.Terminated, result.Truncated, _, err = env.Step(ctx, action)
+		if err != nil {
+			return result, fmt.Errorf("failed to step environment: %w", err)
+		}
+		result.Return += reward
+		result.Length++
+
+		if result.Terminated || result.Truncated {
+			return result, nil
+		}
+	}
+
+	result.Truncated = true
+	return result, nil
+}
+
+// selectAction returns the action of policy for obs, or a sample of the action space if policy is nil.
+func selectAction[Obs any, Act any](env Env[Obs, Act], policy func(Obs) Act, obs Obs) (Act, error) {
+	if policy != nil {
+		return policy(obs), nil
+	}
+
+	action, err := env.ActionSpace().Sample(nil, nil)
+	if err != nil {
+		return action, fmt.Errorf("failed to sample action: %w", err)
+	}
+	return action, nil
+}
+
+// Rollout holds the transitions collected by CollectRollout as parallel slices, indexed by step.
+//
+// Step i took Actions[i] in Observations[i] and received Rewards[i]. If the episode ended at step i, as given
+// by Terminations[i] or Truncations[i], Observations[i+1] is the initial observation of the next episode.
+type Rollout[Obs any, Act any] struct {
+	Observations    []Obs     // Observation in which each action was taken
+	Actions         []Act     // Action taken at each step
+	Rewards         []float64 // Reward received at each step
+	Terminations    []bool    // Whether the episode terminated at each step
+	Truncations     []bool    // Whether the episode was truncated at each step
+	NextObservation Obs       // Observation following the last step, for bootstrapping
+}
+
+// Len returns the number of steps of the rollout.
+func (r *Rollout[Obs, Act]) Len() int {
+	return len(r.Rewards)
+}
+
+// Done reports whether the episode ended at step i, by termination or truncation.
+func (r *Rollout[Obs, Act]) Done(i int) bool {
+	return r.Terminations[i] || r.Truncations[i]
+}
+
+// DiscountedReturns computes the discounted return of every step.
+//
+// The return of step i is Rewards[i] + gamma * return of step i+1, where the sum restarts at episode
+// boundaries. Rewards after the last step are unknown and count as zero.
+//
+// Parameters:
+//   - gamma: The discount factor (must be in [0, 1])
+//
+// Returns:
+//   - The discounted return of each step
+//   - An error if gamma is not in [0, 1]
+func (r *Rollout[Obs, Act]) DiscountedReturns(gamma float64) ([]float64, error) {
+	if !(gamma >= 0 && gamma <= 1) {
+		return nil, fmt.Errorf("gamma must be in [0, 1], got %f", gamma)
+	}
+
+	returns := make([]float64, r.Len())
+	next := 0.0
+	for i := r.Len() - 1; i >= 0; i-- {
+		if r.Done(i) {
+			next = 0
+		}
+		next = r.Rewards[i] + gamma*next
+		returns[i] = next
+	}
+	return returns, nil
+}
+
+// CollectRollout resets an environment and steps it with a policy for a fixed number of steps.
+//
+// Episodes that terminate or truncate are reset, and collection continues with the next episode. The
+// environment is reset without a seed, so seed it beforehand (e.g. with WithSeed) for reproducible rollouts.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - env: The environment to run
+//   - policy: Selects the action for an observation, or nil to sample actions from the action space
+//   - numSteps: The number of steps to collect (must be positive)
+//
+// Returns:
+//   - The collected rollout, with numSteps entries in each slice
+//   - An error if numSteps is not positive or the environment or action sampling fails
+func CollectRollout[Obs any, Act any](ctx context.Context, env Env[Obs, Act], policy func(Obs) Act, numSteps int) (*Rollout[Obs, Act], error) {
+	if numSteps <= 0 {
+		return nil, fmt.Errorf("number of steps must be positive, got %d", numSteps)
+	}
+
+	obs, _, err := env.Reset(ctx, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reset environment: %w", err)
+	}
+
+	rollout := &Rollout[Obs, Act]{
+		Observations: make([]Obs, 0, numSteps),
+		Actions:      make([]Act, 0, numSteps),
+		Rewards:      make([]float64, 0, numSteps),
+		Terminations: make([]bool, 0, numSteps),
+		Truncations:  make([]bool, 0, numSteps),
+	}
+	for range numSteps {
+		action, err := selectAction(env, policy, obs)
+		if err != nil {
+			return nil, err
+		}
+
+		next, reward, terminated, truncated, _, err := env.Step(ctx, action)
+		if err != nil {
+			return nil, fmt.Errorf("failed to step environment: %w", err)
+		}
+
+		rollout.Observations = append(rollout.Observations, obs)
+		rollout.Actions = append(rollout.Actions, action)
+		rollout.Rewards = append(rollout.Rewards, reward)
+		rollout.Terminations = append(rollout.Terminations, terminated)
+		rollout.Truncations = append(rollout.Truncations, truncated)
+
+		obs = next
+		if terminated || truncated {
+			if obs, _, err = env.Reset(ctx, nil, nil); err != nil {
+				return nil, fmt.Errorf("failed to reset environment: %w", err)
+			}
+		}
+	}
+	rollout.NextObservation = obs
+	return rollout, nil
+}
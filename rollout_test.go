@@ -0,0 +1,83 @@
+package gym_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gocnn/gym"
+)
+
+func TestRunEpisodeRandomPolicy(t *testing.T) {
+	env, err := gym.Make[[]float64, int]("CartPole-v1", nil, gym.WithSeed(3))
+	if err != nil {
+		t.Fatalf("Make failed: %v", err)
+	}
+	defer env.Close()
+
+	ctx := context.Background()
+	for _, maxSteps := range []int{1, 5, 20, 500} {
+		for range 5 {
+			result, err := gym.RunEpisode(ctx, env, nil, maxSteps)
+			if err != nil {
+				t.Fatalf("RunEpisode failed: %v", err)
+			}
+			if result.Length < 1 || result.Length > maxSteps {
+				t.Errorf("episode with at most %d steps has length %d", maxSteps, result.Length)
+			}
+			if result.Terminated == result.Truncated {
+				t.Errorf("episode of length %d has terminated %v and truncated %v, want exactly one", result.Length, result.Terminated, result.Truncated)
+			}
+			if result.Length < maxSteps && !result.Terminated {
+				t.Errorf("episode ended after %d of %d steps without terminating", result.Length, maxSteps)
+			}
+			// CartPole rewards every step with 1
+			if result.Return != float64(result.Length) {
+				t.Errorf("episode of length %d has return %f", result.Length, result.Return)
+			}
+		}
+	}
+
+	if _, err := gym.RunEpisode(ctx, env, nil, 0); err == nil {
+		t.Error("RunEpisode with max steps 0 succeeded, expected an error")
+	}
+}
+
+func TestCollectRolloutMarksEpisodeBoundaries(t *testing.T) {
+	// Episodes of 3 steps, observing 0.5 except on Reset, which observes a random value
+	env := newStubEnv(t)
+	env.episodeLength = 3
+	env.randomReset = true
+
+	rollout, err := gym.CollectRollout(context.Background(), gym.Env[[]float64, int](env), nil, 10)
+	if err != nil {
+		t.Fatalf("CollectRollout failed: %v", err)
+	}
+
+	if rollout.Len() != 10 {
+		t.Errorf("Len() = %d, want 10", rollout.Len())
+	}
+	for name, n := range map[string]int{
+		"Observations": len(rollout.Observations),
+		"Actions":      len(rollout.Actions),
+		"Rewards":      len(rollout.Rewards),
+		"Terminations": len(rollout.Terminations),
+		"Truncations":  len(rollout.Truncations),
+	} {
+		if n != 10 {
+			t.Errorf("%s has %d entries, want 10", name, n)
+		}
+	}
+
+	for i := range rollout.Len() {
+		if done := i%3 == 2; rollout.Terminations[i] != done || rollout.Truncations[i] || rollout.Done(i) != done {
+			t.Errorf("step %d has terminated %v and truncated %v, want %v and false", i, rollout.Terminations[i], rollout.Truncations[i], done)
+		}
+		// The step after a boundary starts from the observation of a new Reset
+		if reset := i%3 == 0; (rollout.Observations[i][0] != 0.5) != reset {
+			t.Errorf("step %d started from %v, want a Reset observation %v", i, rollout.Observations[i], reset)
+		}
+	}
+	if rollout.NextObservation[0] != 0.5 {
+		t.Errorf("NextObservation = %v, want the step observation [0.5]", rollout.NextObservation)
+	}
+}
@@ -0,0 +1,190 @@
+// Package server exposes environments to agents running in other processes.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gocnn/gym"
+)
+
+// Request is a message sent by the agent to a PipeServer.
+//
+// Cmd is one of "reset", "step" or "close". Seed and Options are used by "reset", which keeps the RNG state
+// when Seed is omitted, and Action, encoded as produced by the action space's ToJSONable, is used by "step".
+type Request struct {
+	Cmd     string          `json:"cmd"`
+	Seed    *int64          `json:"seed,omitempty"`
+	Options gym.Info        `json:"options,omitempty"`
+	Action  json.RawMessage `json:"action,omitempty"`
+}
+
+// ResetResponse is the reply to a "reset" request.
+type ResetResponse struct {
+	Observation any      `json:"observation"`
+	Info        gym.Info `json:"info"`
+}
+
+// StepResponse is the reply to a "step" request.
+type StepResponse struct {
+	Observation any      `json:"observation"`
+	Reward      float64  `json:"reward"`
+	Terminated  bool     `json:"terminated"`
+	Truncated   bool     `json:"truncated"`
+	Info        gym.Info `json:"info"`
+}
+
+// CloseResponse is the reply to a "close" request.
+type CloseResponse struct {
+	Closed bool `json:"closed"`
+}
+
+// ErrorResponse is the reply to a request that could not be served.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// PipeServer serves an environment over a newline-delimited JSON protocol.
+//
+// The agent writes one Request per line and the server writes back one response per line,
+// which makes it possible to drive an environment from a subprocess over stdin/stdout without
+// opening a network port. Observations and actions are encoded with the spaces' JSONable helpers.
+type PipeServer[Obs any, Act any] struct {
+	env gym.Env[Obs, Act]
+	dec *json.Decoder
+	enc *json.Encoder
+}
+
+// NewPipeServer creates a new PipeServer.
+//
+// Parameters:
+//   - env: The environment to serve
+//   - r: The reader the requests are read from, e.g. os.Stdin
+//   - w: The writer the responses are written to, e.g. os.Stdout
+//
+// Returns:
+//   - A new PipeServer
+func NewPipeServer[Obs any, Act any](env gym.Env[Obs, Act], r io.Reader, w io.Writer) *PipeServer[Obs, Act] {
+	return &PipeServer[Obs, Act]{
+		env: env,
+		dec: json.NewDecoder(r),
+		enc: json.NewEncoder(w),
+	}
+}
+
+// Serve reads and answers requests until a "close" request is served, the reader is exhausted or ctx is cancelled.
+//
+// Errors raised by the environment or by malformed requests are reported to the agent as an ErrorResponse
+// and do not stop the server. The environment is closed when Serve returns.
+//
+// Parameters:
+//   - ctx: Context passed to the environment's Step and Reset
+//
+// Returns:
+//   - An error if reading or writing fails, or ctx is cancelled
+func (s *PipeServer[Obs, Act]) Serve(ctx context.Context) error {
+	defer s.env.Close()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var req Request
+		if err := s.dec.Decode(&req); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to read request: %w", err)
+		}
+
+		resp, err := s.handle(ctx, req)
+		if err != nil {
+			resp = ErrorResponse{Error: err.Error()}
+		}
+		if err := s.enc.Encode(resp); err != nil {
+			return fmt.Errorf("failed to write response: %w", err)
+		}
+
+		if _, ok := resp.(CloseResponse); ok {
+			return nil
+		}
+	}
+}
+
+// handle serves a single request and returns its response.
+func (s *PipeServer[Obs, Act]) handle(ctx context.Context, req Request) (any, error) {
+	switch req.Cmd {
+	case "reset":
+		obs, info, err := s.env.Reset(ctx, req.Seed, req.Options)
+		if err != nil {
+			return nil, err
+		}
+		observation, err := s.encodeObservation(obs)
+		if err != nil {
+			return nil, err
+		}
+		return ResetResponse{Observation: observation, Info: info}, nil
+
+	case "step":
+		action, err := s.decodeAction(req.Action)
+		if err != nil {
+			return nil, err
+		}
+		obs, reward, terminated, truncated, info, err := s.env.Step(ctx, action)
+		if err != nil {
+			return nil, err
+		}
+		observation, err := s.encodeObservation(obs)
+		if err != nil {
+			return nil, err
+		}
+		return StepResponse{
+			Observation: observation,
+			Reward:      reward,
+			Terminated:  terminated,
+			Truncated:   truncated,
+			Info:        info,
+		}, nil
+
+	case "close":
+		if err := s.env.Close(); err != nil {
+			return nil, err
+		}
+		return CloseResponse{Closed: true}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown command: %q", req.Cmd)
+	}
+}
+
+// decodeAction converts a JSON-encoded action into an element of the action space.
+func (s *PipeServer[Obs, Act]) decodeAction(raw json.RawMessage) (Act, error) {
+	var zero Act
+	if len(raw) == 0 {
+		return zero, fmt.Errorf("step request has no action")
+	}
+
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return zero, fmt.Errorf("failed to decode action: %w", err)
+	}
+
+	actions, err := s.env.ActionSpace().FromJSONable([]any{value})
+	if err != nil {
+		return zero, fmt.Errorf("failed to decode action: %w", err)
+	}
+	return actions[0], nil
+}
+
+// encodeObservation converts an observation into a JSON-encodable value.
+func (s *PipeServer[Obs, Act]) encodeObservation(obs Obs) (any, error) {
+	observations, err := s.env.ObservationSpace().ToJSONable([]Obs{obs})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode observation: %w", err)
+	}
+	return observations[0], nil
+}
@@ -0,0 +1,45 @@
+package space
+
+import "fmt"
+
+// BatchSpace creates the space of n stacked elements of a space.
+//
+// A Box is batched into a Box with an extra leading dimension of size n, a Discrete into a MultiDiscrete
+// with n entries, and a MultiBinary into a MultiBinary with an extra leading dimension. Batched elements
+// of Box and MultiBinary spaces are stored flattened in row-major order, i.e. as the concatenation of
+// the n elements. A batched Box keeps the data type of s, and the batched space samples from the same RNG as s.
+//
+// Parameters:
+//   - s: The space to batch
+//   - n: The number of elements of a batch (must be positive)
+//
+// Returns:
+//   - The batched space
+//   - An error if n is not positive or s cannot be batched
+func BatchSpace(s any, n int) (any, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("n has to be positive, got %d", n)
+	}
+
+	switch sp := s.(type) {
+	case *Box:
+		var low, high []float64
+		for range n {
+			low = append(low, sp.low...)
+			high = append(high, sp.high...)
+		}
+		return NewBoxWithDTypeWithRNG(sp.rng, sp.dtype, low, high, append([]int{n}, sp.shape...))
+	case *Discrete:
+		nvec := make([]int, n)
+		start := make([]int, n)
+		for i := range nvec {
+			nvec[i] = int(sp.n)
+			start[i] = int(sp.start)
+		}
+		return NewMultiDiscreteWithRNG(sp.rng, nvec, start)
+	case *MultiBinary:
+		return NewMultiBinaryShapeWithRNG(sp.rng, append([]int{n}, sp.shape...)...)
+	default:
+		return nil, fmt.Errorf("space %v of type %T cannot be batched", s, s)
+	}
+}
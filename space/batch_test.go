@@ -0,0 +1,93 @@
+package space_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gocnn/gym/space"
+)
+
+func TestBatchSpaceBox(t *testing.T) {
+	box, err := space.NewBoxWithDTypeWithRNG(newRNG(t), "float32", []float64{-1, 0}, []float64{1, 2})
+	if err != nil {
+		t.Fatalf("NewBoxWithDTypeWithRNG failed: %v", err)
+	}
+
+	batched, err := space.BatchSpace(box, 3)
+	if err != nil {
+		t.Fatalf("BatchSpace failed: %v", err)
+	}
+	got, ok := batched.(*space.Box)
+	if !ok {
+		t.Fatalf("BatchSpace(Box) returned %T, want *space.Box", batched)
+	}
+	if !slices.Equal(got.Shape(), []int{3, 2}) {
+		t.Errorf("Shape() = %v, want [3 2]", got.Shape())
+	}
+	if got.DType() != "float32" {
+		t.Errorf("DType() = %q, want float32", got.DType())
+	}
+	if want := []float64{-1, 0, -1, 0, -1, 0}; !slices.Equal(got.Low(), want) {
+		t.Errorf("Low() = %v, want %v", got.Low(), want)
+	}
+	if want := []float64{1, 2, 1, 2, 1, 2}; !slices.Equal(got.High(), want) {
+		t.Errorf("High() = %v, want %v", got.High(), want)
+	}
+}
+
+func TestBatchSpaceDiscrete(t *testing.T) {
+	d, err := space.NewDiscreteWithRNG(newRNG(t), 4, 2)
+	if err != nil {
+		t.Fatalf("NewDiscreteWithRNG failed: %v", err)
+	}
+
+	batched, err := space.BatchSpace(d, 3)
+	if err != nil {
+		t.Fatalf("BatchSpace failed: %v", err)
+	}
+	got, ok := batched.(*space.MultiDiscrete)
+	if !ok {
+		t.Fatalf("BatchSpace(Discrete) returned %T, want *space.MultiDiscrete", batched)
+	}
+	if !slices.Equal(got.Shape(), []int{3}) {
+		t.Errorf("Shape() = %v, want [3]", got.Shape())
+	}
+	if want := []int{4, 4, 4}; !slices.Equal(got.Nvec(), want) {
+		t.Errorf("Nvec() = %v, want %v", got.Nvec(), want)
+	}
+	if want := []int{2, 2, 2}; !slices.Equal(got.Start(), want) {
+		t.Errorf("Start() = %v, want %v", got.Start(), want)
+	}
+}
+
+func TestBatchSpaceMultiBinary(t *testing.T) {
+	m, err := space.NewMultiBinaryShapeWithRNG(newRNG(t), 2, 2)
+	if err != nil {
+		t.Fatalf("NewMultiBinaryShapeWithRNG failed: %v", err)
+	}
+
+	batched, err := space.BatchSpace(m, 3)
+	if err != nil {
+		t.Fatalf("BatchSpace failed: %v", err)
+	}
+	got, ok := batched.(*space.MultiBinary)
+	if !ok {
+		t.Fatalf("BatchSpace(MultiBinary) returned %T, want *space.MultiBinary", batched)
+	}
+	if !slices.Equal(got.Shape(), []int{3, 2, 2}) {
+		t.Errorf("Shape() = %v, want [3 2 2]", got.Shape())
+	}
+}
+
+func TestBatchSpaceInvalid(t *testing.T) {
+	d, err := space.NewDiscreteWithRNG(newRNG(t), 4)
+	if err != nil {
+		t.Fatalf("NewDiscreteWithRNG failed: %v", err)
+	}
+	if _, err := space.BatchSpace(d, 0); err == nil {
+		t.Error("BatchSpace with n = 0 succeeded, expected an error")
+	}
+	if _, err := space.BatchSpace(newTuple(t), 2); err == nil {
+		t.Error("BatchSpace of a Tuple succeeded, expected an error")
+	}
+}
@@ -3,6 +3,8 @@ package space
 import (
 	"fmt"
 	"math"
+	"slices"
+	"strings"
 
 	"github.com/gocnn/gym/rand"
 )
@@ -25,6 +27,7 @@ type Box struct {
 	shape        []int     // Shape of the space
 	boundedBelow []bool    // Whether each dimension is bounded below
 	boundedAbove []bool    // Whether each dimension is bounded above
+	dtype        string    // Data type of the elements, "float64" or "float32"
 	rng          *rand.RNG
 }
 
@@ -43,8 +46,31 @@ type Box struct {
 //
 // Returns:
 //   - A new Box space
-//   - An error if the parameters are invalid
+//   - An error if the parameters are invalid or the default RNG is disabled
 func NewBox(low, high interface{}, shape ...[]int) (*Box, error) {
+	rng, err := newRNG()
+	if err != nil {
+		return nil, err
+	}
+	return NewBoxWithRNG(rng, low, high, shape...)
+}
+
+// NewBoxWithRNG creates a new Box space that samples from the given RNG.
+//
+// Parameters:
+//   - rng: The random number generator used for sampling
+//   - low: Lower bounds of the intervals. Can be a single value or slice
+//   - high: Upper bounds of the intervals. Can be a single value or slice
+//   - shape: Optional shape specification. If not provided, inferred from low/high
+//
+// Returns:
+//   - A new Box space
+//   - An error if rng is nil or the parameters are invalid
+func NewBoxWithRNG(rng *rand.RNG, low, high interface{}, shape ...[]int) (*Box, error) {
+	if rng == nil {
+		return nil, fmt.Errorf("rng must not be nil")
+	}
+
 	var lowVec, highVec []float64
 	var boxShape []int
 
@@ -95,6 +121,13 @@ func NewBox(low, high interface{}, shape ...[]int) (*Box, error) {
 		return nil, fmt.Errorf("high must be float64 or []float64, got %T", high)
 	}
 
+	// Validate that the shape matches the number of bounds
+	if size, err := shapeSize(boxShape); err != nil {
+		return nil, err
+	} else if size != len(lowVec) {
+		return nil, fmt.Errorf("shape %v has %d elements, but %d bounds were given", boxShape, size, len(lowVec))
+	}
+
 	// Validate that low <= high
 	for i := range lowVec {
 		if lowVec[i] > highVec[i] {
@@ -110,18 +143,87 @@ func NewBox(low, high interface{}, shape ...[]int) (*Box, error) {
 		boundedAbove[i] = !math.IsInf(highVec[i], 1)
 	}
 
-	rng := rand.GetDefaultRNG()
-
 	return &Box{
 		low:          lowVec,
 		high:         highVec,
-		shape:        boxShape,
+		shape:        slices.Clone(boxShape),
 		boundedBelow: boundedBelow,
 		boundedAbove: boundedAbove,
+		dtype:        "float64",
 		rng:          rng,
 	}, nil
 }
 
+// NewBoxWithDType creates a new Box space whose elements have the given data type.
+//
+// Elements are always stored as float64, but the elements of a "float32" Box are rounded to float32
+// precision: the bounds are rounded on construction, samples and FromJSONable round their values, and
+// ToJSONable encodes them as float32, like the observations of Python environments.
+//
+// Parameters:
+//   - dtype: The data type of the elements, "float64" or "float32"
+//   - low: Lower bounds of the intervals. Can be a single value or slice
+//   - high: Upper bounds of the intervals. Can be a single value or slice
+//   - shape: Optional shape specification. If not provided, inferred from low/high
+//
+// Returns:
+//   - A new Box space
+//   - An error if dtype is unsupported, the parameters are invalid or the default RNG is disabled
+func NewBoxWithDType(dtype string, low, high interface{}, shape ...[]int) (*Box, error) {
+	rng, err := newRNG()
+	if err != nil {
+		return nil, err
+	}
+	return NewBoxWithDTypeWithRNG(rng, dtype, low, high, shape...)
+}
+
+// NewBoxWithDTypeWithRNG creates a new Box space whose elements have the given data type and that samples
+// from the given RNG.
+//
+// Parameters:
+//   - rng: The random number generator used for sampling
+//   - dtype: The data type of the elements, "float64" or "float32"
+//   - low: Lower bounds of the intervals. Can be a single value or slice
+//   - high: Upper bounds of the intervals. Can be a single value or slice
+//   - shape: Optional shape specification. If not provided, inferred from low/high
+//
+// Returns:
+//   - A new Box space
+//   - An error if rng is nil, dtype is unsupported or the parameters are invalid
+func NewBoxWithDTypeWithRNG(rng *rand.RNG, dtype string, low, high interface{}, shape ...[]int) (*Box, error) {
+	if dtype != "float64" && dtype != "float32" {
+		return nil, fmt.Errorf("dtype must be \"float64\" or \"float32\", got %q", dtype)
+	}
+
+	b, err := NewBoxWithRNG(rng, low, high, shape...)
+	if err != nil {
+		return nil, err
+	}
+	b.setDType(dtype)
+	return b, nil
+}
+
+// setDType sets the data type of the Box, rounding its bounds to the precision of the data type.
+func (b *Box) setDType(dtype string) {
+	b.dtype = dtype
+	b.round(b.low)
+	b.round(b.high)
+	for i := range b.low {
+		b.boundedBelow[i] = !math.IsInf(b.low[i], -1)
+		b.boundedAbove[i] = !math.IsInf(b.high[i], 1)
+	}
+}
+
+// round rounds the values of x to the precision of the data type of the Box.
+func (b *Box) round(x []float64) {
+	if b.dtype != "float32" {
+		return
+	}
+	for i, val := range x {
+		x[i] = float64(float32(val))
+	}
+}
+
 // Sample generates a single random sample inside the Box.
 //
 // In creating a sample of the box, each coordinate is sampled (independently) from a distribution
@@ -132,43 +234,146 @@ func NewBox(low, high interface{}, shape ...[]int) (*Box, error) {
 // * (-∞, b] : shifted negative exponential distribution
 // * (-∞, ∞) : normal distribution
 //
+// A mask clips the intervals before the distribution is chosen, and a clipped interval reduced
+// to a single point always yields that point.
+//
 // Parameters:
-//   - mask: A mask for sampling values (currently not implemented)
+//   - mask: An optional [2][]float64{lo, hi} clipping the sampling interval of each dimension to
+//     [max(low[i], lo[i]), min(high[i], hi[i])]; use -Inf and +Inf to leave a dimension unclipped
 //   - probability: A probability mask for sampling values (currently not implemented)
 //
 // Returns:
 //   - A sampled value from the Box
-//   - An error if sampling fails
+//   - An error if the mask is invalid or lies outside of the bounds of the Box
 func (b *Box) Sample(mask any, probability any) ([]float64, error) {
-	if mask != nil || probability != nil {
-		return nil, fmt.Errorf("mask and probability sampling not yet implemented")
+	sample := make([]float64, len(b.low))
+	if err := b.SampleInto(sample, mask, probability); err != nil {
+		return nil, err
 	}
+	return sample, nil
+}
 
-	sample := make([]float64, len(b.low))
+// SampleInto generates a single random sample inside the Box, writing it into dst without allocating.
+//
+// The sample is drawn exactly as by Sample.
+//
+// Parameters:
+//   - dst: The destination of the sample, with one element per dimension of the Box
+//   - mask: An optional [2][]float64{lo, hi} clipping the sampling interval, as for Sample
+//   - probability: A probability mask for sampling values (currently not implemented)
+//
+// Returns:
+//   - An error if dst has the wrong length, or the mask is invalid or lies outside of the bounds of the Box
+func (b *Box) SampleInto(dst []float64, mask any, probability any) error {
+	if probability != nil {
+		return fmt.Errorf("probability sampling not yet implemented")
+	}
+
+	if len(dst) != len(b.low) {
+		return fmt.Errorf("dst must have length %d, got %d", len(b.low), len(dst))
+	}
+
+	low, high := b.low, b.high
+	if mask != nil {
+		var err error
+		low, high, err = b.clipBounds(mask)
+		if err != nil {
+			return err
+		}
+	}
+
+	sampleInterval(b.rng, dst, low, high)
+	b.round(dst)
+	return nil
+}
 
+// SampleN generates n random samples from this space.
+//
+// The samples are the same as those of n successive calls to Sample without a mask, but the RNG is locked
+// only once for the whole batch.
+//
+// Parameters:
+//   - n: The number of samples (must be non-negative)
+//
+// Returns:
+//   - A slice of n samples from the Box
+//   - An error if n is negative
+func (b *Box) SampleN(n int) ([][]float64, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("n must be non-negative, got %d", n)
+	}
+
+	samples := make([][]float64, n)
+	b.rng.WithLock(func(rng *rand.LockedRNG) {
+		for i := range samples {
+			samples[i] = make([]float64, len(b.low))
+			sampleInterval(rng, samples[i], b.low, b.high)
+			b.round(samples[i])
+		}
+	})
+	return samples, nil
+}
+
+// floatSource is the part of an RNG used to sample Box elements, implemented by *rand.RNG and *rand.LockedRNG.
+type floatSource interface {
+	Float64() float64
+	NormFloat64() float64
+	ExpFloat64() float64
+}
+
+// sampleInterval fills sample with a value of each interval [low[i], high[i]].
+func sampleInterval(rng floatSource, sample, low, high []float64) {
 	for i := range sample {
-		unbounded := !b.boundedBelow[i] && !b.boundedAbove[i]
-		uppBounded := !b.boundedBelow[i] && b.boundedAbove[i]
-		lowBounded := b.boundedBelow[i] && !b.boundedAbove[i]
-		bounded := b.boundedBelow[i] && b.boundedAbove[i]
+		boundedBelow := !math.IsInf(low[i], -1)
+		boundedAbove := !math.IsInf(high[i], 1)
 
 		switch {
-		case unbounded:
+		case !boundedBelow && !boundedAbove:
 			// Normal distribution for unbounded intervals
-			sample[i] = b.rng.NormFloat64()
-		case lowBounded:
+			sample[i] = rng.NormFloat64()
+		case boundedBelow && !boundedAbove:
 			// Exponential distribution shifted by low bound
-			sample[i] = b.rng.ExpFloat64() + b.low[i]
-		case uppBounded:
+			sample[i] = rng.ExpFloat64() + low[i]
+		case !boundedBelow && boundedAbove:
 			// Negative exponential distribution shifted by high bound
-			sample[i] = b.high[i] - b.rng.ExpFloat64()
-		case bounded:
+			sample[i] = high[i] - rng.ExpFloat64()
+		case low[i] == high[i]:
+			// Degenerate interval containing a single point
+			sample[i] = low[i]
+		default:
 			// Uniform distribution for bounded intervals
-			sample[i] = b.low[i] + b.rng.Float64()*(b.high[i]-b.low[i])
+			sample[i] = low[i] + rng.Float64()*(high[i]-low[i])
 		}
 	}
+}
 
-	return sample, nil
+// clipBounds returns the bounds of the space clipped to the sub-box given by a sampling mask.
+func (b *Box) clipBounds(mask any) ([]float64, []float64, error) {
+	m, ok := mask.([2][]float64)
+	if !ok {
+		return nil, nil, fmt.Errorf("mask must be [2][]float64{lo, hi}, got %T", mask)
+	}
+
+	lo, hi := m[0], m[1]
+	if len(lo) != len(b.low) || len(hi) != len(b.high) {
+		return nil, nil, fmt.Errorf("mask bounds must have length %d, got %d and %d", len(b.low), len(lo), len(hi))
+	}
+
+	low := make([]float64, len(b.low))
+	high := make([]float64, len(b.high))
+	for i := range low {
+		if math.IsNaN(lo[i]) || math.IsNaN(hi[i]) || lo[i] > hi[i] {
+			return nil, nil, fmt.Errorf("invalid mask interval [%v, %v] at index %d", lo[i], hi[i], i)
+		}
+
+		low[i] = math.Max(b.low[i], lo[i])
+		high[i] = math.Min(b.high[i], hi[i])
+		if low[i] > high[i] {
+			return nil, nil, fmt.Errorf("mask interval [%v, %v] at index %d is outside of the bounds [%v, %v]",
+				lo[i], hi[i], i, b.low[i], b.high[i])
+		}
+	}
+	return low, high, nil
 }
 
 // Seed sets the pseudorandom number generator seed of this space.
@@ -183,6 +388,24 @@ func (b *Box) Seed(seed int64) (int64, error) {
 	return b.rng.Seed(seed)
 }
 
+// Clone returns a copy of this space with its own RNG in the same state as the RNG of this space.
+//
+// The copy draws the same samples as this space would, without advancing or being affected by it.
+//
+// Returns:
+//   - An independent copy of this space
+func (b *Box) Clone() *Box {
+	return &Box{
+		low:          slices.Clone(b.low),
+		high:         slices.Clone(b.high),
+		shape:        slices.Clone(b.shape),
+		boundedBelow: slices.Clone(b.boundedBelow),
+		boundedAbove: slices.Clone(b.boundedAbove),
+		dtype:        b.dtype,
+		rng:          b.rng.Clone(),
+	}
+}
+
 // Contains returns true if x is a valid member of this space.
 //
 // Parameters:
@@ -203,8 +426,191 @@ func (b *Box) Contains(x []float64) bool {
 	return true
 }
 
+// ContainsBatch reports the membership of each element of a batch, as Contains does for a single element.
+//
+// Parameters:
+//   - xs: The elements to check for membership
+//
+// Returns:
+//   - A slice with, at each index, whether the element of xs at that index is in the Box
+func (b *Box) ContainsBatch(xs [][]float64) []bool {
+	result := make([]bool, len(xs))
+	for i, x := range xs {
+		result[i] = b.Contains(x)
+	}
+	return result
+}
+
+// ContainsTol returns true if x is a member of this space up to an absolute tolerance.
+//
+// Each component may lie up to atol outside its bounds, which absorbs floating-point error from
+// computations such as normalization round-trips. Contains remains the exact check.
+//
+// Parameters:
+//   - x: The element to check for membership
+//   - atol: The absolute tolerance (must be non-negative)
+//
+// Returns:
+//   - true if x has the right length and every component is within atol of its bounds, false otherwise
+func (b *Box) ContainsTol(x []float64, atol float64) bool {
+	if len(x) != len(b.low) || atol < 0 {
+		return false
+	}
+
+	for i, val := range x {
+		if val < b.low[i]-atol || val > b.high[i]+atol {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsDetailed returns whether x is a valid member of this space, together with the violating dimensions.
+//
+// Parameters:
+//   - x: The element to check for membership
+//
+// Returns:
+//   - true if x is within the bounds of this space, false otherwise
+//   - The indices of the dimensions of x that lie outside their bounds, or nil if x has the wrong length
+func (b *Box) ContainsDetailed(x []float64) (bool, []int) {
+	if len(x) != len(b.low) {
+		return false, nil
+	}
+
+	var violations []int
+	for i, val := range x {
+		if val < b.low[i] || val > b.high[i] {
+			violations = append(violations, i)
+		}
+	}
+	return len(violations) == 0, violations
+}
+
+// Validate checks that x is a valid member of this space.
+//
+// Parameters:
+//   - x: The element to check for membership
+//
+// Returns:
+//   - nil if x is within the bounds of this space
+//   - An *OutOfBoundsError describing every violating dimension, or an error if x has the wrong length
+func (b *Box) Validate(x []float64) error {
+	if len(x) != len(b.low) {
+		return fmt.Errorf("expected element of length %d, got %d", len(b.low), len(x))
+	}
+
+	ok, violations := b.ContainsDetailed(x)
+	if ok {
+		return nil
+	}
+
+	err := &OutOfBoundsError{Indices: violations}
+	for _, i := range violations {
+		err.Values = append(err.Values, x[i])
+		err.Low = append(err.Low, b.low[i])
+		err.High = append(err.High, b.high[i])
+	}
+	return err
+}
+
+// OutOfBoundsError reports the dimensions of an element that lie outside the bounds of a Box.
+type OutOfBoundsError struct {
+	Indices []int     // Indices of the violating dimensions
+	Values  []float64 // Values of the violating dimensions
+	Low     []float64 // Lower bounds of the violating dimensions
+	High    []float64 // Upper bounds of the violating dimensions
+}
+
+// Error returns a description of every violating dimension and by how much it exceeds its bounds.
+func (e *OutOfBoundsError) Error() string {
+	parts := make([]string, len(e.Indices))
+	for k, i := range e.Indices {
+		if e.Values[k] < e.Low[k] {
+			parts[k] = fmt.Sprintf("x[%d]=%g is below low=%g by %g", i, e.Values[k], e.Low[k], e.Low[k]-e.Values[k])
+		} else {
+			parts[k] = fmt.Sprintf("x[%d]=%g is above high=%g by %g", i, e.Values[k], e.High[k], e.Values[k]-e.High[k])
+		}
+	}
+	return "out of bounds: " + strings.Join(parts, ", ")
+}
+
+// Clip returns a copy of x with each component clamped to the bounds of this space.
+//
+// Infinite bounds leave their components untouched. Components beyond the length of the space are left
+// unchanged, so that a later Contains check can still reject x.
+//
+// Parameters:
+//   - x: The vector to clip
+//
+// Returns:
+//   - A new clipped vector
+func (b *Box) Clip(x []float64) []float64 {
+	clipped := slices.Clone(x)
+	b.ClipInPlace(clipped)
+	return clipped
+}
+
+// ClipInPlace clamps each component of x to the bounds of this space, modifying x.
+//
+// Parameters:
+//   - x: The vector to clip
+func (b *Box) ClipInPlace(x []float64) {
+	for i, val := range x {
+		if i < len(b.low) {
+			x[i] = math.Max(b.low[i], math.Min(val, b.high[i]))
+		}
+	}
+}
+
+// shapeSize returns the number of elements of a shape.
+func shapeSize(shape []int) (int, error) {
+	if len(shape) == 0 {
+		return 0, fmt.Errorf("shape must not be empty")
+	}
+
+	size := 1
+	for _, dim := range shape {
+		if dim <= 0 {
+			return 0, fmt.Errorf("shape dimensions have to be positive, got %v", shape)
+		}
+		size *= dim
+	}
+	return size, nil
+}
+
+// Reshape returns a Box with the same bounds arranged in a new shape.
+//
+// Elements are stored flattened in row-major order, so reshaping keeps every bound of the flattened
+// element in place. The new Box samples from the same RNG as this one.
+//
+// Parameters:
+//   - newShape: The new shape, with the same number of elements as the current shape
+//
+// Returns:
+//   - A new Box space with the given shape
+//   - An error if the number of elements differs
+func (b *Box) Reshape(newShape []int) (*Box, error) {
+	size, err := shapeSize(newShape)
+	if err != nil {
+		return nil, err
+	}
+	if size != len(b.low) {
+		return nil, fmt.Errorf("cannot reshape Box of shape %v with %d elements to shape %v", b.shape, len(b.low), newShape)
+	}
+	reshaped, err := NewBoxWithRNG(b.rng, b.low, b.high, newShape)
+	if err != nil {
+		return nil, err
+	}
+	reshaped.setDType(b.dtype)
+	return reshaped, nil
+}
+
 // Shape returns the shape of the space elements.
 //
+// Multi-dimensional elements, e.g. images of shape [H, W, C], are stored flattened in row-major order,
+// so a sample has as many values as the product of the shape.
+//
 // Returns:
 //   - A slice of integers representing the shape
 func (b *Box) Shape() []int {
@@ -216,9 +622,9 @@ func (b *Box) Shape() []int {
 // DType returns the data type of the space elements.
 //
 // Returns:
-//   - "float64" as the data type string
+//   - "float64", or "float32" for a Box created with NewBoxWithDType or NewBoxWithDTypeWithRNG and "float32"
 func (b *Box) DType() string {
-	return "float64"
+	return b.dtype
 }
 
 // IsFlattenable returns true if this space can be flattened to a Box space.
@@ -229,8 +635,18 @@ func (b *Box) IsFlattenable() bool {
 	return true
 }
 
+// FlatDim returns the number of elements of a flattened sample of this space.
+//
+// Returns:
+//   - The total number of elements of the Box
+func (b *Box) FlatDim() int {
+	return len(b.low)
+}
+
 // ToJSONable converts a batch of samples from this space to a JSONable data type.
 //
+// Samples of a "float32" Box are converted to []float32, so that they are encoded with float32 precision.
+//
 // Parameters:
 //   - samples: A slice of samples from this space
 //
@@ -240,6 +656,14 @@ func (b *Box) IsFlattenable() bool {
 func (b *Box) ToJSONable(samples [][]float64) ([]any, error) {
 	result := make([]any, len(samples))
 	for i, sample := range samples {
+		if b.dtype == "float32" {
+			converted := make([]float32, len(sample))
+			for j, val := range sample {
+				converted[j] = float32(val)
+			}
+			result[i] = converted
+			continue
+		}
 		result[i] = sample
 	}
 	return result, nil
@@ -247,6 +671,8 @@ func (b *Box) ToJSONable(samples [][]float64) ([]any, error) {
 
 // FromJSONable converts a JSONable data type to a batch of samples from this space.
 //
+// The values of a "float32" Box are rounded to float32 precision.
+//
 // Parameters:
 //   - json: A slice of any type that was previously created by ToJSONable
 //
@@ -258,7 +684,13 @@ func (b *Box) FromJSONable(json []any) ([][]float64, error) {
 	for i, val := range json {
 		switch v := val.(type) {
 		case []float64:
-			result[i] = v
+			result[i] = slices.Clone(v)
+		case []float32:
+			floatSlice := make([]float64, len(v))
+			for j, elem := range v {
+				floatSlice[j] = float64(elem)
+			}
+			result[i] = floatSlice
 		case []interface{}:
 			floatSlice := make([]float64, len(v))
 			for j, elem := range v {
@@ -273,8 +705,9 @@ func (b *Box) FromJSONable(json []any) ([][]float64, error) {
 			}
 			result[i] = floatSlice
 		default:
-			return nil, fmt.Errorf("expected []float64 or []interface{}, got %T", val)
+			return nil, fmt.Errorf("expected []float64, []float32 or []interface{}, got %T", val)
 		}
+		b.round(result[i])
 	}
 	return result, nil
 }
@@ -284,7 +717,7 @@ func (b *Box) FromJSONable(json []any) ([][]float64, error) {
 // Returns:
 //   - A string representation showing bounds, shape and dtype
 func (b *Box) String() string {
-	return fmt.Sprintf("Box(low=%v, high=%v, shape=%v, dtype=float64)", b.low, b.high, b.shape)
+	return fmt.Sprintf("Box(low=%v, high=%v, shape=%v, dtype=%s)", b.low, b.high, b.shape, b.dtype)
 }
 
 // IsBounded checks whether the box is bounded in some sense.
@@ -342,3 +775,45 @@ func (b *Box) High() []float64 {
 	copy(result, b.high)
 	return result
 }
+
+// ConcatBox concatenates several 1-D Box spaces into a single 1-D Box space.
+//
+// The bounds of the resulting space are the bounds of the given boxes appended in order,
+// so infinite bounds keep their boundedness. The result has the "float32" data type if every box does and
+// "float64" otherwise, and samples from an RNG derived from a clone of the RNG of the first box, so it does
+// not depend on the default RNG nor advance the RNG of the first box.
+//
+// Parameters:
+//   - boxes: The Box spaces to concatenate, each with a 1-D shape
+//
+// Returns:
+//   - A new Box space whose dimension is the sum of the dimensions of the given boxes
+//   - An error if no box is given or any box is not 1-D
+func ConcatBox(boxes ...*Box) (*Box, error) {
+	if len(boxes) == 0 {
+		return nil, fmt.Errorf("at least one box is required")
+	}
+
+	var low, high []float64
+	dtype := "float32"
+	for i, b := range boxes {
+		if b == nil {
+			return nil, fmt.Errorf("box %d must not be nil", i)
+		}
+		if len(b.shape) != 1 {
+			return nil, fmt.Errorf("box %d must be 1-D, got shape %v", i, b.shape)
+		}
+		low = append(low, b.low...)
+		high = append(high, b.high...)
+		if b.dtype != "float32" {
+			dtype = "float64"
+		}
+	}
+
+	concat, err := NewBoxWithRNG(boxes[0].rng.Clone().Derive(), low, high)
+	if err != nil {
+		return nil, err
+	}
+	concat.setDType(dtype)
+	return concat, nil
+}
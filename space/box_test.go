@@ -0,0 +1,342 @@
+package space_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/gocnn/gym/space"
+)
+
+func TestConcatBox(t *testing.T) {
+	// ConcatBox must not use the default RNG
+	disableDefaultRNG(t)
+
+	a, err := space.NewBoxWithDTypeWithRNG(newRNG(t), "float32", []float64{-1, 0}, []float64{1, 0.1})
+	if err != nil {
+		t.Fatalf("NewBoxWithDTypeWithRNG failed: %v", err)
+	}
+	b, err := space.NewBoxWithDTypeWithRNG(newRNG(t), "float32", 0.0, 5.0, []int{1})
+	if err != nil {
+		t.Fatalf("NewBoxWithDTypeWithRNG failed: %v", err)
+	}
+	c, err := space.NewBoxWithRNG(newRNG(t), 0.0, 0.1, []int{1})
+	if err != nil {
+		t.Fatalf("NewBoxWithRNG failed: %v", err)
+	}
+
+	concat, err := space.ConcatBox(a, b)
+	if err != nil {
+		t.Fatalf("ConcatBox failed with the default RNG disabled: %v", err)
+	}
+	if concat.DType() != "float32" {
+		t.Errorf("DType() of float32 boxes concatenated = %q, want float32", concat.DType())
+	}
+	if want := append(a.Low(), b.Low()...); !slices.Equal(concat.Low(), want) {
+		t.Errorf("Low() = %v, want %v", concat.Low(), want)
+	}
+	if want := append(a.High(), b.High()...); !slices.Equal(concat.High(), want) {
+		t.Errorf("High() = %v, want %v", concat.High(), want)
+	}
+	if !slices.Equal(concat.Shape(), []int{3}) {
+		t.Errorf("Shape() = %v, want [3]", concat.Shape())
+	}
+	if _, err := concat.Sample(nil, nil); err != nil {
+		t.Errorf("Sample failed: %v", err)
+	}
+
+	// Concatenating with a float64 box gives float64, keeping the bounds of c exact
+	mixed, err := space.ConcatBox(a, c)
+	if err != nil {
+		t.Fatalf("ConcatBox failed: %v", err)
+	}
+	if mixed.DType() != "float64" || mixed.High()[2] != 0.1 {
+		t.Errorf("mixed concatenation has dtype %q and high %v, want float64 and 0.1 last", mixed.DType(), mixed.High())
+	}
+}
+
+func TestBoxFloat32(t *testing.T) {
+	disableDefaultRNG(t)
+
+	box, err := space.NewBoxWithDTypeWithRNG(newRNG(t), "float32", []float64{-1, 0}, []float64{1, 0.1})
+	if err != nil {
+		t.Fatalf("NewBoxWithDTypeWithRNG failed with the default RNG disabled: %v", err)
+	}
+	if box.DType() != "float32" {
+		t.Errorf("DType() = %q, want float32", box.DType())
+	}
+	if high := box.High()[1]; high != float64(float32(0.1)) {
+		t.Errorf("High()[1] = %v, want 0.1 rounded to float32 precision", high)
+	}
+
+	samples, err := box.SampleN(10)
+	if err != nil {
+		t.Fatalf("SampleN failed: %v", err)
+	}
+	samples = append(samples, []float64{0.3, 0.1}) // Not representable as float32
+	json, err := box.ToJSONable(samples)
+	if err != nil {
+		t.Fatalf("ToJSONable failed: %v", err)
+	}
+	restored, err := box.FromJSONable(json)
+	if err != nil {
+		t.Fatalf("FromJSONable failed: %v", err)
+	}
+	for i := range samples {
+		for j, x := range samples[i] {
+			if got, want := restored[i][j], float64(float32(x)); got != want {
+				t.Errorf("round trip of %v gave %v, want %v", x, got, want)
+			}
+		}
+	}
+
+	if _, err := space.NewBoxWithDTypeWithRNG(newRNG(t), "int8", 0.0, 1.0, []int{1}); err == nil {
+		t.Error("NewBoxWithDTypeWithRNG succeeded with dtype int8, expected an error")
+	}
+	if _, err := space.NewBoxWithDTypeWithRNG(nil, "float32", 0.0, 1.0, []int{1}); err == nil {
+		t.Error("NewBoxWithDTypeWithRNG succeeded with a nil RNG, expected an error")
+	}
+}
+
+// newMixedBox creates a Box with bounded, half-bounded and unbounded dimensions, covering every way of
+// sampling an interval.
+func newMixedBox(tb testing.TB) *space.Box {
+	tb.Helper()
+
+	box, err := space.NewBoxWithRNG(newRNG(tb), []float64{-1, 0, math.Inf(-1), math.Inf(-1)}, []float64{1, math.Inf(1), 5, math.Inf(1)})
+	if err != nil {
+		tb.Fatalf("NewBoxWithRNG failed: %v", err)
+	}
+	return box
+}
+
+func TestBoxSampleNMatchesSample(t *testing.T) {
+	box := newMixedBox(t)
+	clone := box.Clone()
+
+	samples, err := box.SampleN(20)
+	if err != nil {
+		t.Fatalf("SampleN failed: %v", err)
+	}
+	if len(samples) != 20 {
+		t.Fatalf("SampleN(20) returned %d samples", len(samples))
+	}
+	for i, got := range samples {
+		want, err := clone.Sample(nil, nil)
+		if err != nil {
+			t.Fatalf("Sample failed: %v", err)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("sample %d of SampleN = %v, Sample gave %v", i, got, want)
+		}
+	}
+
+	if _, err := box.SampleN(-1); err == nil {
+		t.Error("SampleN(-1) succeeded, expected an error")
+	}
+}
+
+func BenchmarkBoxSample(b *testing.B) {
+	box := newMixedBox(b)
+	for b.Loop() {
+		for range 64 {
+			if _, err := box.Sample(nil, nil); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkBoxSampleN(b *testing.B) {
+	box := newMixedBox(b)
+	for b.Loop() {
+		if _, err := box.SampleN(64); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestBoxSampleIntoMatchesSample(t *testing.T) {
+	box := newMixedBox(t)
+	clone := box.Clone()
+
+	dst := make([]float64, 4)
+	for i := range 20 {
+		if err := box.SampleInto(dst, nil, nil); err != nil {
+			t.Fatalf("SampleInto failed: %v", err)
+		}
+		want, err := clone.Sample(nil, nil)
+		if err != nil {
+			t.Fatalf("Sample failed: %v", err)
+		}
+		if !slices.Equal(dst, want) {
+			t.Errorf("sample %d of SampleInto = %v, Sample gave %v", i, dst, want)
+		}
+	}
+
+	// A mask clips the interval in the same way
+	mask := [2][]float64{{0, 1, 2, -1}, {0.5, 2, 3, 1}}
+	if err := box.SampleInto(dst, mask, nil); err != nil {
+		t.Fatalf("SampleInto with a mask failed: %v", err)
+	}
+	want, err := clone.Sample(mask, nil)
+	if err != nil {
+		t.Fatalf("Sample with a mask failed: %v", err)
+	}
+	if !slices.Equal(dst, want) {
+		t.Errorf("masked SampleInto = %v, Sample gave %v", dst, want)
+	}
+
+	if err := box.SampleInto(make([]float64, 3), nil, nil); err == nil {
+		t.Error("SampleInto with a short dst succeeded, expected an error")
+	}
+}
+
+func TestBoxMaskedSample(t *testing.T) {
+	box := newMixedBox(t)
+
+	// Clip a bounded, a half-bounded and an unbounded dimension, and reduce one to a single point
+	mask := [2][]float64{{0, 2, 3, -1}, {0.5, 2, math.Inf(1), 1}}
+	low := []float64{0, 2, 3, -1}
+	high := []float64{0.5, 2, 5, 1}
+	for range 1000 {
+		x, err := box.Sample(mask, nil)
+		if err != nil {
+			t.Fatalf("Sample with a mask failed: %v", err)
+		}
+		for i := range x {
+			if x[i] < low[i] || x[i] > high[i] {
+				t.Fatalf("masked sample %v has element %d outside of [%v, %v]", x, i, low[i], high[i])
+			}
+		}
+		if x[1] != 2 {
+			t.Fatalf("masked sample %v did not return the single point 2 at index 1", x)
+		}
+	}
+
+	invalid := []any{
+		[]float64{0, 1},                          // Wrong type
+		[2][]float64{{0, 0, 0}, {1, 1, 1}},       // Wrong length
+		[2][]float64{{1, 0, 0, 0}, {0, 1, 1, 1}}, // Empty interval
+		[2][]float64{{math.NaN(), 0, 0, 0}, {1, 1, 1, 1}},
+		[2][]float64{{2, 0, 0, 0}, {3, 1, 1, 1}}, // Outside of the bounds
+	}
+	for _, mask := range invalid {
+		if x, err := box.Sample(mask, nil); err == nil {
+			t.Errorf("Sample with mask %v returned %v, expected an error", mask, x)
+		}
+	}
+}
+
+func BenchmarkBoxSampleInto(b *testing.B) {
+	box := newMixedBox(b)
+	dst := make([]float64, 4)
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := box.SampleInto(dst, nil, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestBoxClip(t *testing.T) {
+	box := newMixedBox(t)
+
+	x := []float64{-3, -2, 10, 1e9}
+	want := []float64{-1, 0, 5, 1e9}
+	if got := box.Clip(x); !slices.Equal(got, want) {
+		t.Errorf("Clip(%v) = %v, want %v", x, got, want)
+	}
+	if !slices.Equal(x, []float64{-3, -2, 10, 1e9}) {
+		t.Errorf("Clip modified its argument to %v", x)
+	}
+
+	// Components within the bounds and beyond the length of the space are kept
+	long := []float64{0.5, 3, -7, -1e9, 42}
+	if got := box.Clip(long); !slices.Equal(got, long) {
+		t.Errorf("Clip(%v) = %v, want it unchanged", long, got)
+	}
+	if box.Contains(box.Clip(long)) {
+		t.Errorf("clipped element %v of the wrong length is contained in %v", long, box)
+	}
+
+	box.ClipInPlace(x)
+	if !slices.Equal(x, want) {
+		t.Errorf("ClipInPlace gave %v, want %v", x, want)
+	}
+	if !box.Contains(x) {
+		t.Errorf("clipped element %v is not contained in %v", x, box)
+	}
+}
+
+func TestBoxContainsTol(t *testing.T) {
+	box := newMixedBox(t)
+
+	tests := []struct {
+		x    []float64
+		atol float64
+		want bool
+	}{
+		{[]float64{0, 1, 2, 3}, 0, true},
+		{[]float64{1 + 1e-12, 1, 2, 3}, 1e-9, true},
+		{[]float64{1 + 1e-12, 1, 2, 3}, 0, false},
+		{[]float64{0, -1e-10, 5 + 1e-10, 3}, 1e-9, true},
+		{[]float64{-1.1, 1, 2, 3}, 1e-9, false},
+		{[]float64{0, 1, 2, 3}, -1, false},
+		{[]float64{0, 1, 2}, 1, false},
+	}
+	for _, tt := range tests {
+		if got := box.ContainsTol(tt.x, tt.atol); got != tt.want {
+			t.Errorf("ContainsTol(%v, %g) = %t, want %t", tt.x, tt.atol, got, tt.want)
+		}
+	}
+}
+
+func TestBoxReshape(t *testing.T) {
+	low := []float64{0, 1, 2, 3, 4, 5}
+	high := []float64{10, 11, 12, 13, 14, 15}
+	box, err := space.NewBoxWithDTypeWithRNG(newRNG(t), "float32", low, high)
+	if err != nil {
+		t.Fatalf("NewBoxWithDTypeWithRNG failed: %v", err)
+	}
+
+	reshaped, err := box.Reshape([]int{2, 3})
+	if err != nil {
+		t.Fatalf("Reshape failed: %v", err)
+	}
+	if !slices.Equal(reshaped.Shape(), []int{2, 3}) {
+		t.Errorf("Shape() = %v, want [2 3]", reshaped.Shape())
+	}
+	if !slices.Equal(reshaped.Low(), low) || !slices.Equal(reshaped.High(), high) {
+		t.Errorf("reshaped bounds = %v, %v, want %v, %v", reshaped.Low(), reshaped.High(), low, high)
+	}
+	if reshaped.DType() != "float32" {
+		t.Errorf("DType() = %q, want float32", reshaped.DType())
+	}
+
+	for _, shape := range [][]int{{4}, {2, 2}, {}, {-2, -3}, {6, 0}} {
+		if _, err := box.Reshape(shape); err == nil {
+			t.Errorf("Reshape(%v) succeeded, expected an error", shape)
+		}
+	}
+}
+
+func TestBoxContainsBatch(t *testing.T) {
+	box := newMixedBox(t)
+
+	xs := [][]float64{
+		{0, 1, 2, 3},
+		{2, 1, 2, 3},
+		{-1, 0, 5, -1e9},
+		{0, -1, 2, 3},
+		{0, 1, 2},
+		nil,
+	}
+	want := []bool{true, false, true, false, false, false}
+	if got := box.ContainsBatch(xs); !slices.Equal(got, want) {
+		t.Errorf("ContainsBatch(%v) = %v, want %v", xs, got, want)
+	}
+	if got := box.ContainsBatch(nil); len(got) != 0 {
+		t.Errorf("ContainsBatch(nil) = %v, want an empty slice", got)
+	}
+}
@@ -0,0 +1,109 @@
+package space
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// DiscreteToMultiBinary creates the MultiBinary space that encodes the elements of a Discrete space.
+//
+// Each element of the Discrete space is encoded by the binary representation of its 0-based index
+// (x - start), using ceil(log2(n)) bits with the most significant bit first. When n is not a power of two,
+// some binary codes of the resulting space do not correspond to any element and are rejected by BinaryToDiscrete.
+// The resulting space samples from an RNG derived from a clone of the RNG of d, so it does not depend on the
+// default RNG nor advance the RNG of d.
+//
+// Parameters:
+//   - d: The Discrete space to encode
+//
+// Returns:
+//   - A MultiBinary space with enough bits to encode every element of d
+//   - An error if d is nil
+func DiscreteToMultiBinary(d *Discrete) (*MultiBinary, error) {
+	if d == nil {
+		return nil, fmt.Errorf("discrete space must not be nil")
+	}
+	return NewMultiBinaryWithRNG(d.rng.Clone().Derive(), discreteBits(d))
+}
+
+// MultiBinaryToDiscrete creates the Discrete space of all codes representable by a MultiBinary space.
+//
+// This is the inverse of DiscreteToMultiBinary for spaces whose size is a power of two. The resulting space
+// samples from an RNG derived from a clone of the RNG of m.
+//
+// Parameters:
+//   - m: The MultiBinary space to decode
+//
+// Returns:
+//   - A Discrete space with 2^n elements starting at 0
+//   - An error if m is nil or has too many bits to be represented
+func MultiBinaryToDiscrete(m *MultiBinary) (*Discrete, error) {
+	if m == nil {
+		return nil, fmt.Errorf("multi-binary space must not be nil")
+	}
+	if m.n >= bits.UintSize-1 {
+		return nil, fmt.Errorf("multi-binary space with %d bits is too large to convert", m.n)
+	}
+	return NewDiscreteWithRNG(m.rng.Clone().Derive(), 1<<m.n)
+}
+
+// DiscreteToBinary encodes an element of a Discrete space as a binary code.
+//
+// Parameters:
+//   - d: The Discrete space the element belongs to
+//   - x: The element to encode
+//
+// Returns:
+//   - The binary code of x, with ceil(log2(n)) entries and the most significant bit first
+//   - An error if x is not a member of d
+func DiscreteToBinary(d *Discrete, x int) ([]int8, error) {
+	if !d.Contains(x) {
+		return nil, fmt.Errorf("%d is not a member of %s", x, d)
+	}
+
+	index := x - d.Start()
+	code := make([]int8, discreteBits(d))
+	for i := len(code) - 1; i >= 0; i-- {
+		code[i] = int8(index & 1)
+		index >>= 1
+	}
+	return code, nil
+}
+
+// BinaryToDiscrete decodes a binary code into an element of a Discrete space.
+//
+// This is the inverse of DiscreteToBinary.
+//
+// Parameters:
+//   - d: The Discrete space to decode into
+//   - code: The binary code, with ceil(log2(n)) entries and the most significant bit first
+//
+// Returns:
+//   - The element of d represented by code
+//   - An error if code has the wrong length, contains non-binary entries or is out of range for d
+func BinaryToDiscrete(d *Discrete, code []int8) (int, error) {
+	if len(code) != discreteBits(d) {
+		return 0, fmt.Errorf("expected code of length %d, got %d", discreteBits(d), len(code))
+	}
+
+	index := 0
+	for i, bit := range code {
+		if bit != 0 && bit != 1 {
+			return 0, fmt.Errorf("code[%d] must be 0 or 1, got %d", i, bit)
+		}
+		index = index<<1 | int(bit)
+	}
+
+	if index >= d.N() {
+		return 0, fmt.Errorf("code %v is out of range for %s", code, d)
+	}
+	return d.Start() + index, nil
+}
+
+// discreteBits returns the number of bits needed to encode every element of d, which is at least 1.
+func discreteBits(d *Discrete) int {
+	if d.N() <= 1 {
+		return 1
+	}
+	return bits.Len(uint(d.N() - 1))
+}
@@ -0,0 +1,74 @@
+package space_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gocnn/gym/space"
+)
+
+func TestDiscreteToMultiBinary(t *testing.T) {
+	d, err := space.NewDiscreteWithRNG(newRNG(t), 5, 3)
+	if err != nil {
+		t.Fatalf("NewDiscreteWithRNG failed: %v", err)
+	}
+	disableDefaultRNG(t)
+
+	m, err := space.DiscreteToMultiBinary(d)
+	if err != nil {
+		t.Fatalf("DiscreteToMultiBinary failed with the default RNG disabled: %v", err)
+	}
+	if !slices.Equal(m.Shape(), []int{3}) {
+		t.Errorf("Shape() = %v, want [3] bits for 5 elements", m.Shape())
+	}
+
+	for x := 3; x < 8; x++ {
+		code, err := space.DiscreteToBinary(d, x)
+		if err != nil {
+			t.Fatalf("DiscreteToBinary(%d) failed: %v", x, err)
+		}
+		if !m.Contains(code) {
+			t.Errorf("code %v of %d is not contained in %v", code, x, m)
+		}
+		got, err := space.BinaryToDiscrete(d, code)
+		if err != nil {
+			t.Fatalf("BinaryToDiscrete(%v) failed: %v", code, err)
+		}
+		if got != x {
+			t.Errorf("BinaryToDiscrete(DiscreteToBinary(%d)) = %d", x, got)
+		}
+	}
+
+	if _, err := space.BinaryToDiscrete(d, []int8{1, 1, 1}); err == nil {
+		t.Error("BinaryToDiscrete succeeded for a code out of range, expected an error")
+	}
+	if _, err := m.Sample(nil, nil); err != nil {
+		t.Errorf("Sample failed: %v", err)
+	}
+}
+
+func TestMultiBinaryToDiscrete(t *testing.T) {
+	m, err := space.NewMultiBinaryWithRNG(newRNG(t), 3)
+	if err != nil {
+		t.Fatalf("NewMultiBinaryWithRNG failed: %v", err)
+	}
+	disableDefaultRNG(t)
+
+	d, err := space.MultiBinaryToDiscrete(m)
+	if err != nil {
+		t.Fatalf("MultiBinaryToDiscrete failed with the default RNG disabled: %v", err)
+	}
+	if d.N() != 8 || d.Start() != 0 {
+		t.Errorf("MultiBinaryToDiscrete gave %v, want 8 elements starting at 0", d)
+	}
+	if _, err := d.Sample(nil, nil); err != nil {
+		t.Errorf("Sample failed: %v", err)
+	}
+
+	if _, err := space.MultiBinaryToDiscrete(nil); err == nil {
+		t.Error("MultiBinaryToDiscrete succeeded for a nil space, expected an error")
+	}
+	if _, err := space.DiscreteToMultiBinary(nil); err == nil {
+		t.Error("DiscreteToMultiBinary succeeded for a nil space, expected an error")
+	}
+}
@@ -0,0 +1,300 @@
+package space
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/gocnn/gym/rand"
+)
+
+// Dict represents a dictionary of heterogeneous spaces, keyed by name.
+//
+// Elements of this space are maps with one element of each subspace under its key.
+// Like Tuple, subspaces have different element types, so elements are stored as any and the
+// element-typed methods are called through reflection. Keys are kept in sorted order, which
+// determines the order in which subspaces are seeded, sampled and printed.
+//
+// Example:
+//   - Dict(position: Box(-1, 1, shape=[2]), velocity: Discrete(3)) contains elements such as
+//     {"position": [0.5, -0.2], "velocity": 1}
+type Dict struct {
+	keys   []string
+	spaces map[string]any
+}
+
+// NewDict creates a new Dict space.
+//
+// Parameters:
+//   - spaces: The subspaces by key, each implementing Space[T] for some element type T
+//
+// Returns:
+//   - A new Dict space
+//   - An error if any subspace does not implement the space methods
+func NewDict(spaces map[string]any) (*Dict, error) {
+	keys := make([]string, 0, len(spaces))
+	for key, s := range spaces {
+		if _, ok := s.(subspace); !ok {
+			return nil, fmt.Errorf("subspace %q of type %T is not a space", key, s)
+		}
+		for _, name := range tupleMethods {
+			if !reflect.ValueOf(s).MethodByName(name).IsValid() {
+				return nil, fmt.Errorf("subspace %q of type %T has no %s method", key, s, name)
+			}
+		}
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	copied := make(map[string]any, len(spaces))
+	for key, s := range spaces {
+		copied[key] = s
+	}
+	return &Dict{
+		keys:   keys,
+		spaces: copied,
+	}, nil
+}
+
+// Sample generates a single random sample from this space.
+//
+// The sample is a map with a sample from each subspace under its key.
+//
+// Parameters:
+//   - mask: An optional map[string]any with a mask for some or all subspaces
+//   - probability: An optional map[string]any with a probability mask for some or all subspaces
+//
+// Returns:
+//   - A sampled element of the space
+//   - An error if a mask does not match the subspaces or a subspace fails to sample
+func (d *Dict) Sample(mask any, probability any) (map[string]any, error) {
+	masks, err := d.splitMask("mask", mask)
+	if err != nil {
+		return nil, err
+	}
+	probabilities, err := d.splitMask("probability", probability)
+	if err != nil {
+		return nil, err
+	}
+
+	sample := make(map[string]any, len(d.keys))
+	for _, key := range d.keys {
+		method := reflect.ValueOf(d.spaces[key]).MethodByName("Sample")
+		out := method.Call([]reflect.Value{
+			valueOrZero(masks[key], method.Type().In(0)),
+			valueOrZero(probabilities[key], method.Type().In(1)),
+		})
+		if err, _ := out[1].Interface().(error); err != nil {
+			return nil, fmt.Errorf("failed to sample subspace %q: %w", key, err)
+		}
+		sample[key] = out[0].Interface()
+	}
+	return sample, nil
+}
+
+// splitMask returns the per-subspace masks of a mask given for the whole dictionary.
+func (d *Dict) splitMask(name string, mask any) (map[string]any, error) {
+	if mask == nil {
+		return nil, nil
+	}
+
+	masks, ok := mask.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a map[string]any keyed by subspace, got %T", name, mask)
+	}
+	for key := range masks {
+		if _, ok := d.spaces[key]; !ok {
+			return nil, fmt.Errorf("%s has unknown key %q", name, key)
+		}
+	}
+	return masks, nil
+}
+
+// Seed sets the pseudorandom number generator seed of every subspace.
+//
+// The subspaces are seeded, in key order, with distinct seeds spawned from the given seed by a
+// rand.SeedSequence.
+//
+// Parameters:
+//   - seed: The seed value for the space
+//
+// Returns:
+//   - The effective seed value used
+//   - An error if seeding fails
+func (d *Dict) Seed(seed int64) (int64, error) {
+	seq, effectiveSeed, err := rand.NewSeedSequence(seed)
+	if err != nil {
+		return 0, err
+	}
+
+	subseeds := seq.Spawn(len(d.keys))
+	for i, key := range d.keys {
+		if _, err := d.spaces[key].(subspace).Seed(subseeds[i]); err != nil {
+			return 0, fmt.Errorf("failed to seed subspace %q: %w", key, err)
+		}
+	}
+	return effectiveSeed, nil
+}
+
+// Contains returns true if x is a valid member of this space.
+//
+// Parameters:
+//   - x: The element to check for membership
+//
+// Returns:
+//   - true if x has exactly the keys of the space and each element has its subspace's type and is
+//     contained in it
+func (d *Dict) Contains(x map[string]any) bool {
+	if len(x) != len(d.keys) {
+		return false
+	}
+
+	for _, key := range d.keys {
+		elem, ok := x[key]
+		if !ok {
+			return false
+		}
+		method := reflect.ValueOf(d.spaces[key]).MethodByName("Contains")
+		if elem == nil || !reflect.TypeOf(elem).AssignableTo(method.Type().In(0)) {
+			return false
+		}
+		if !method.Call([]reflect.Value{reflect.ValueOf(elem)})[0].Bool() {
+			return false
+		}
+	}
+	return true
+}
+
+// Shape returns the shape of the space elements.
+//
+// Dict spaces don't have a well-defined shape, so this returns nil.
+//
+// Returns:
+//   - nil (dictionary elements are heterogeneous)
+func (d *Dict) Shape() []int {
+	return nil
+}
+
+// DType returns the data type of the space elements.
+//
+// Returns:
+//   - An empty string since dictionary elements are heterogeneous
+func (d *Dict) DType() string {
+	return ""
+}
+
+// IsFlattenable returns true if this space can be flattened to a Box space.
+//
+// Flattening is not supported for Dict spaces yet.
+//
+// Returns:
+//   - false
+func (d *Dict) IsFlattenable() bool {
+	return false
+}
+
+// ToJSONable converts a batch of samples from this space to a JSONable data type.
+//
+// Each sample is converted to a map with the JSONable form of each element.
+//
+// Parameters:
+//   - samples: A slice of samples from this space
+//
+// Returns:
+//   - A slice of any type that can be marshaled to JSON
+//   - An error if a sample does not match the subspaces or conversion fails
+func (d *Dict) ToJSONable(samples []map[string]any) ([]any, error) {
+	result := make([]any, len(samples))
+	for i, sample := range samples {
+		if len(sample) != len(d.keys) {
+			return nil, fmt.Errorf("sample %d has %d elements, expected %d", i, len(sample), len(d.keys))
+		}
+
+		converted := make(map[string]any, len(d.keys))
+		for _, key := range d.keys {
+			method := reflect.ValueOf(d.spaces[key]).MethodByName("ToJSONable")
+			batch := reflect.MakeSlice(method.Type().In(0), 1, 1)
+			elem, ok := sample[key]
+			if !ok || elem == nil || !reflect.TypeOf(elem).AssignableTo(batch.Type().Elem()) {
+				return nil, fmt.Errorf("element %q of sample %d has type %T, expected %s", key, i, elem, batch.Type().Elem())
+			}
+			batch.Index(0).Set(reflect.ValueOf(elem))
+
+			out := method.Call([]reflect.Value{batch})
+			if err, _ := out[1].Interface().(error); err != nil {
+				return nil, fmt.Errorf("failed to convert element %q of sample %d: %w", key, i, err)
+			}
+			converted[key] = out[0].Interface().([]any)[0]
+		}
+		result[i] = converted
+	}
+	return result, nil
+}
+
+// FromJSONable converts a JSONable data type to a batch of samples from this space.
+//
+// Parameters:
+//   - json: A slice of any type that was previously created by ToJSONable
+//
+// Returns:
+//   - A slice of samples of type map[string]any
+//   - An error if conversion fails or the data is invalid for this space
+func (d *Dict) FromJSONable(json []any) ([]map[string]any, error) {
+	result := make([]map[string]any, len(json))
+	for i, val := range json {
+		elems, ok := val.(map[string]any)
+		if !ok || len(elems) != len(d.keys) {
+			return nil, fmt.Errorf("expected map[string]any with %d keys, got %T", len(d.keys), val)
+		}
+
+		sample := make(map[string]any, len(d.keys))
+		for _, key := range d.keys {
+			elem, ok := elems[key]
+			if !ok {
+				return nil, fmt.Errorf("sample %d is missing key %q", i, key)
+			}
+			method := reflect.ValueOf(d.spaces[key]).MethodByName("FromJSONable")
+			out := method.Call([]reflect.Value{reflect.ValueOf([]any{elem})})
+			if err, _ := out[1].Interface().(error); err != nil {
+				return nil, fmt.Errorf("failed to convert element %q of sample %d: %w", key, i, err)
+			}
+			sample[key] = out[0].Index(0).Interface()
+		}
+		result[i] = sample
+	}
+	return result, nil
+}
+
+// String returns a string representation of this space.
+//
+// Returns:
+//   - A string representation in the format "Dict(key1: space1, key2: space2, ...)"
+func (d *Dict) String() string {
+	parts := make([]string, len(d.keys))
+	for i, key := range d.keys {
+		parts[i] = fmt.Sprintf("%s: %v", key, d.spaces[key])
+	}
+	return fmt.Sprintf("Dict(%s)", strings.Join(parts, ", "))
+}
+
+// Keys returns the keys of the subspaces in sorted order.
+//
+// Returns:
+//   - A copy of the sorted keys
+func (d *Dict) Keys() []string {
+	return slices.Clone(d.keys)
+}
+
+// Get returns the subspace under a key.
+//
+// Parameters:
+//   - key: The key of the subspace
+//
+// Returns:
+//   - The subspace, which implements Space[T] for its element type T
+//   - false if the space has no such key
+func (d *Dict) Get(key string) (any, bool) {
+	s, ok := d.spaces[key]
+	return s, ok
+}
@@ -30,8 +30,30 @@ type Discrete struct {
 //
 // Returns:
 //   - A new Discrete space
-//   - An error if n is not positive
+//   - An error if n is not positive or the default RNG is disabled
 func NewDiscrete(n int, start ...int) (*Discrete, error) {
+	rng, err := newRNG()
+	if err != nil {
+		return nil, err
+	}
+	return NewDiscreteWithRNG(rng, n, start...)
+}
+
+// NewDiscreteWithRNG creates a new Discrete space that samples from the given RNG.
+//
+// Parameters:
+//   - rng: The random number generator used for sampling
+//   - n: The number of elements of this space (must be positive)
+//   - start: The smallest element of this space (optional, defaults to 0)
+//
+// Returns:
+//   - A new Discrete space
+//   - An error if rng is nil or n is not positive
+func NewDiscreteWithRNG(rng *rand.RNG, n int, start ...int) (*Discrete, error) {
+	if rng == nil {
+		return nil, fmt.Errorf("rng must not be nil")
+	}
+
 	if n <= 0 {
 		return nil, fmt.Errorf("n (counts) have to be positive, got %d", n)
 	}
@@ -41,8 +63,6 @@ func NewDiscrete(n int, start ...int) (*Discrete, error) {
 		startVal = start[0]
 	}
 
-	rng := rand.GetDefaultRNG()
-
 	return &Discrete{
 		n:     int64(n),
 		start: int64(startVal),
@@ -56,16 +76,32 @@ func NewDiscrete(n int, start ...int) (*Discrete, error) {
 // or it will be chosen according to a specified probability distribution if the probability mask is provided.
 //
 // Parameters:
-//   - mask: An optional mask for if an action can be selected (currently not implemented)
+//   - mask: An optional []int8 or []bool of length n, where 1 (or true) marks a valid element;
+//     the sample is drawn uniformly among valid elements, and at least one element must be valid
 //   - probability: An optional probability mask (currently not implemented)
 //
 // Returns:
 //   - A sampled integer from the space
-//   - An error if sampling fails
+//   - An error if the mask is invalid, marks no element as valid, or sampling fails
 func (d *Discrete) Sample(mask any, probability any) (int, error) {
-	// TODO: Implement mask and probability sampling
-	if mask != nil || probability != nil {
-		return 0, fmt.Errorf("mask and probability sampling not yet implemented")
+	if mask != nil && probability != nil {
+		return 0, fmt.Errorf("only one of mask or probability can be provided")
+	}
+
+	// TODO: Implement probability sampling
+	if probability != nil {
+		return 0, fmt.Errorf("probability sampling not yet implemented")
+	}
+
+	if mask != nil {
+		valid, err := d.validIndices(mask)
+		if err != nil {
+			return 0, err
+		}
+		if len(valid) == 0 {
+			return 0, fmt.Errorf("mask must mark at least one element as valid")
+		}
+		return int(d.start) + valid[d.rng.IntN(len(valid))], nil
 	}
 
 	// Uniform sampling
@@ -73,6 +109,63 @@ func (d *Discrete) Sample(mask any, probability any) (int, error) {
 	return int(d.start + sample), nil
 }
 
+// SampleN generates n random samples from this space.
+//
+// The samples are the same as those of n successive calls to Sample without a mask, but the RNG is locked
+// only once for the whole batch.
+//
+// Parameters:
+//   - n: The number of samples (must be non-negative)
+//
+// Returns:
+//   - A slice of n sampled integers
+//   - An error if n is negative
+func (d *Discrete) SampleN(n int) ([]int, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("n must be non-negative, got %d", n)
+	}
+
+	samples := make([]int, n)
+	d.rng.WithLock(func(rng *rand.LockedRNG) {
+		for i := range samples {
+			samples[i] = int(d.start + rng.Int64N(d.n))
+		}
+	})
+	return samples, nil
+}
+
+// validIndices returns the offsets from start of the elements marked valid by a sampling mask.
+func (d *Discrete) validIndices(mask any) ([]int, error) {
+	var valid []int
+	switch m := mask.(type) {
+	case []int8:
+		if int64(len(m)) != d.n {
+			return nil, fmt.Errorf("mask length must be %d, got %d", d.n, len(m))
+		}
+		for i, val := range m {
+			switch val {
+			case 0:
+			case 1:
+				valid = append(valid, i)
+			default:
+				return nil, fmt.Errorf("mask values must be 0 or 1, got %d at index %d", val, i)
+			}
+		}
+	case []bool:
+		if int64(len(m)) != d.n {
+			return nil, fmt.Errorf("mask length must be %d, got %d", d.n, len(m))
+		}
+		for i, val := range m {
+			if val {
+				valid = append(valid, i)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("mask must be []int8 or []bool, got %T", mask)
+	}
+	return valid, nil
+}
+
 // Seed sets the pseudorandom number generator seed of this space.
 //
 // Parameters:
@@ -85,6 +178,16 @@ func (d *Discrete) Seed(seed int64) (int64, error) {
 	return d.rng.Seed(seed)
 }
 
+// Clone returns a copy of this space with its own RNG in the same state as the RNG of this space.
+//
+// The copy draws the same samples as this space would, without advancing or being affected by it.
+//
+// Returns:
+//   - An independent copy of this space
+func (d *Discrete) Clone() *Discrete {
+	return &Discrete{n: d.n, start: d.start, rng: d.rng.Clone()}
+}
+
 // Contains returns true if x is a valid member of this space.
 //
 // Parameters:
@@ -96,6 +199,21 @@ func (d *Discrete) Contains(x int) bool {
 	return x >= int(d.start) && x < int(d.start+d.n)
 }
 
+// ContainsBatch reports the membership of each element of a batch, as Contains does for a single element.
+//
+// Parameters:
+//   - xs: The elements to check for membership
+//
+// Returns:
+//   - A slice with, at each index, whether the element of xs at that index is in the range [start, start + n)
+func (d *Discrete) ContainsBatch(xs []int) []bool {
+	result := make([]bool, len(xs))
+	for i, x := range xs {
+		result[i] = d.Contains(x)
+	}
+	return result
+}
+
 // Shape returns the shape of the space elements.
 //
 // Discrete spaces don't have a well-defined shape, so this returns nil.
@@ -122,6 +240,16 @@ func (d *Discrete) IsFlattenable() bool {
 	return true
 }
 
+// FlatDim returns the number of elements of a flattened sample of this space.
+//
+// Discrete elements are flattened to a one-hot encoding.
+//
+// Returns:
+//   - The number of elements (n)
+func (d *Discrete) FlatDim() int {
+	return int(d.n)
+}
+
 // ToJSONable converts a batch of samples from this space to a JSONable data type.
 //
 // Parameters:
@@ -189,3 +317,30 @@ func (d *Discrete) N() int {
 func (d *Discrete) Start() int {
 	return int(d.start)
 }
+
+// All returns every element of this space in increasing order.
+//
+// Returns:
+//   - The elements [start, start+1, ..., start+n-1]
+func (d *Discrete) All() []int {
+	all := make([]int, d.n)
+	for i := range all {
+		all[i] = int(d.start) + i
+	}
+	return all
+}
+
+// Index returns the 0-based index of an element of this space, e.g. to index a Q-table.
+//
+// Parameters:
+//   - x: The element
+//
+// Returns:
+//   - The index x - start
+//   - An error if x is not in this space
+func (d *Discrete) Index(x int) (int, error) {
+	if !d.Contains(x) {
+		return 0, fmt.Errorf("%d is not in %s", x, d)
+	}
+	return x - int(d.start), nil
+}
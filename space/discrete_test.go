@@ -0,0 +1,156 @@
+package space_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gocnn/gym/space"
+)
+
+// newDiscrete creates a Discrete(10) space starting at -3 with an explicit RNG.
+func newDiscrete(tb testing.TB) *space.Discrete {
+	tb.Helper()
+
+	d, err := space.NewDiscreteWithRNG(newRNG(tb), 10, -3)
+	if err != nil {
+		tb.Fatalf("NewDiscreteWithRNG failed: %v", err)
+	}
+	return d
+}
+
+func TestDiscreteMaskedSample(t *testing.T) {
+	d := newDiscrete(t)
+
+	// Only -2 and 5 are valid
+	mask := make([]int8, 10)
+	mask[1], mask[8] = 1, 1
+	counts := map[int]int{}
+	for range 1000 {
+		x, err := d.Sample(mask, nil)
+		if err != nil {
+			t.Fatalf("Sample with a mask failed: %v", err)
+		}
+		counts[x]++
+	}
+	if len(counts) != 2 || counts[-2] == 0 || counts[5] == 0 {
+		t.Errorf("masked samples were drawn with counts %v, want both -2 and 5 only", counts)
+	}
+
+	all := make([]bool, 10)
+	for i := range all {
+		all[i] = true
+	}
+	seen := map[int]bool{}
+	for range 1000 {
+		x, err := d.Sample(all, nil)
+		if err != nil {
+			t.Fatalf("Sample with an all-valid mask failed: %v", err)
+		}
+		if !d.Contains(x) {
+			t.Fatalf("sample %d is not contained in %v", x, d)
+		}
+		seen[x] = true
+	}
+	if len(seen) != 10 {
+		t.Errorf("all-valid mask sampled %d distinct elements, want 10", len(seen))
+	}
+
+	invalid := []any{
+		make([]int8, 10),                     // No valid element
+		make([]bool, 10),                     // No valid element
+		[]int8{0, 2, 0, 0, 0, 0, 0, 0, 0, 1}, // Not binary
+		[]int8{1, 1},                         // Wrong length
+		[]int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1},  // Wrong type
+	}
+	for _, mask := range invalid {
+		if x, err := d.Sample(mask, nil); err == nil {
+			t.Errorf("Sample with mask %v returned %d, expected an error", mask, x)
+		}
+	}
+}
+
+func TestDiscreteSampleNMatchesSample(t *testing.T) {
+	d := newDiscrete(t)
+	clone := d.Clone()
+
+	samples, err := d.SampleN(50)
+	if err != nil {
+		t.Fatalf("SampleN failed: %v", err)
+	}
+	if len(samples) != 50 {
+		t.Fatalf("SampleN(50) returned %d samples", len(samples))
+	}
+	for i, got := range samples {
+		want, err := clone.Sample(nil, nil)
+		if err != nil {
+			t.Fatalf("Sample failed: %v", err)
+		}
+		if got != want {
+			t.Errorf("sample %d of SampleN = %d, Sample gave %d", i, got, want)
+		}
+	}
+
+	if _, err := d.SampleN(-1); err == nil {
+		t.Error("SampleN(-1) succeeded, expected an error")
+	}
+}
+
+func BenchmarkDiscreteSample(b *testing.B) {
+	d := newDiscrete(b)
+	for b.Loop() {
+		for range 64 {
+			if _, err := d.Sample(nil, nil); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkDiscreteSampleN(b *testing.B) {
+	d := newDiscrete(b)
+	for b.Loop() {
+		if _, err := d.SampleN(64); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestDiscreteAllAndIndex(t *testing.T) {
+	d, err := space.NewDiscreteWithRNG(newRNG(t), 4, -1)
+	if err != nil {
+		t.Fatalf("NewDiscreteWithRNG failed: %v", err)
+	}
+
+	all := d.All()
+	if want := []int{-1, 0, 1, 2}; !slices.Equal(all, want) {
+		t.Errorf("All() = %v, want %v", all, want)
+	}
+	for i, x := range all {
+		index, err := d.Index(x)
+		if err != nil {
+			t.Fatalf("Index(%d) failed: %v", x, err)
+		}
+		if index != i {
+			t.Errorf("Index(%d) = %d, want %d", x, index, i)
+		}
+	}
+
+	for _, x := range []int{-2, 3, 100} {
+		if index, err := d.Index(x); err == nil {
+			t.Errorf("Index(%d) = %d, expected an error", x, index)
+		}
+	}
+}
+
+func TestDiscreteContainsBatch(t *testing.T) {
+	d := newDiscrete(t)
+
+	xs := []int{-3, -4, 0, 6, 7, 100}
+	want := []bool{true, false, true, true, false, false}
+	if got := d.ContainsBatch(xs); !slices.Equal(got, want) {
+		t.Errorf("ContainsBatch(%v) = %v, want %v", xs, got, want)
+	}
+	if got := d.ContainsBatch(nil); len(got) != 0 {
+		t.Errorf("ContainsBatch(nil) = %v, want an empty slice", got)
+	}
+}
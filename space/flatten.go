@@ -0,0 +1,241 @@
+package space
+
+import (
+	"fmt"
+
+	"github.com/gocnn/gym"
+)
+
+// Flatten converts an element of a space to a flat []float64.
+//
+// Box elements are copied unchanged, Discrete elements are one-hot encoded, MultiDiscrete elements are
+// encoded as concatenated one-hot vectors, MultiBinary elements are converted entry-wise, and Tuple
+// elements are the concatenation of their flattened entries.
+//
+// Parameters:
+//   - s: The space x belongs to
+//   - x: The element to flatten
+//
+// Returns:
+//   - The flattened element, of length FlatDim(s)
+//   - An error if s is not flattenable or x is not a member of s
+func Flatten[T any](s gym.Space[T], x T) ([]float64, error) {
+	if !s.Contains(x) {
+		return nil, fmt.Errorf("%v is not a member of %v", x, s)
+	}
+	return flatten(s, x)
+}
+
+// Unflatten converts a flat []float64 back to an element of a space.
+//
+// This is the inverse of Flatten.
+//
+// Parameters:
+//   - s: The space of the element
+//   - x: The flattened element, of length FlatDim(s)
+//
+// Returns:
+//   - The unflattened element
+//   - An error if s is not flattenable or x is not a valid flattened element
+func Unflatten[T any](s gym.Space[T], x []float64) (T, error) {
+	var zero T
+
+	dim, err := FlatDim(s)
+	if err != nil {
+		return zero, err
+	}
+	if len(x) != dim {
+		return zero, fmt.Errorf("flattened element must have length %d, got %d", dim, len(x))
+	}
+
+	result, err := unflatten(s, x)
+	if err != nil {
+		return zero, err
+	}
+	return result.(T), nil
+}
+
+// FlatDim returns the number of elements of a flattened sample of a space.
+//
+// Parameters:
+//   - s: The space
+//
+// Returns:
+//   - The length of flattened elements of s
+//   - An error if s is not flattenable
+func FlatDim(s any) (int, error) {
+	f, ok := s.(interface {
+		IsFlattenable() bool
+		FlatDim() int
+	})
+	if !ok || !f.IsFlattenable() {
+		return 0, fmt.Errorf("space %v of type %T is not flattenable", s, s)
+	}
+	return f.FlatDim(), nil
+}
+
+// FlattenSpace returns the Box space of the flattened elements of a space.
+//
+// Box spaces are flattened to 1-D boxes with the same bounds and data type, Tuple spaces to the concatenation of
+// their flattened subspaces, and the discrete spaces to boxes bounded by [0, 1]. The flattened space samples from
+// an RNG derived from a clone of the RNG of s, so it does not depend on the default RNG nor advance the RNG of s.
+//
+// Parameters:
+//   - s: The space to flatten
+//
+// Returns:
+//   - A 1-D Box containing every flattened element of s
+//   - An error if s is not flattenable
+func FlattenSpace(s any) (*Box, error) {
+	switch sp := s.(type) {
+	case *Box:
+		return NewBoxWithDTypeWithRNG(sp.rng.Clone().Derive(), sp.dtype, sp.Low(), sp.High())
+	case *Tuple:
+		boxes := make([]*Box, sp.Len())
+		for i, sub := range sp.spaces {
+			box, err := FlattenSpace(sub)
+			if err != nil {
+				return nil, err
+			}
+			boxes[i] = box
+		}
+		return ConcatBox(boxes...)
+	case *Discrete:
+		return NewBoxWithRNG(sp.rng.Clone().Derive(), 0.0, 1.0, []int{sp.FlatDim()})
+	case *MultiDiscrete:
+		return NewBoxWithRNG(sp.rng.Clone().Derive(), 0.0, 1.0, []int{sp.FlatDim()})
+	case *MultiBinary:
+		return NewBoxWithRNG(sp.rng.Clone().Derive(), 0.0, 1.0, []int{sp.FlatDim()})
+	default:
+		return nil, fmt.Errorf("space %v of type %T is not flattenable", s, s)
+	}
+}
+
+// flatten converts an element of a space to a flat []float64 without checking membership.
+func flatten(s any, x any) ([]float64, error) {
+	switch sp := s.(type) {
+	case *Box:
+		v, ok := x.([]float64)
+		if !ok {
+			return nil, fmt.Errorf("expected []float64 for %v, got %T", sp, x)
+		}
+		return append([]float64(nil), v...), nil
+	case *Discrete:
+		v, ok := x.(int)
+		if !ok {
+			return nil, fmt.Errorf("expected int for %v, got %T", sp, x)
+		}
+		onehot := make([]float64, sp.n)
+		onehot[int64(v)-sp.start] = 1
+		return onehot, nil
+	case *MultiDiscrete:
+		v, ok := x.([]int)
+		if !ok {
+			return nil, fmt.Errorf("expected []int for %v, got %T", sp, x)
+		}
+		onehot := make([]float64, 0, sp.FlatDim())
+		for i, n := range sp.nvec {
+			entry := make([]float64, n)
+			entry[v[i]-sp.start[i]] = 1
+			onehot = append(onehot, entry...)
+		}
+		return onehot, nil
+	case *MultiBinary:
+		v, ok := x.([]int8)
+		if !ok {
+			return nil, fmt.Errorf("expected []int8 for %v, got %T", sp, x)
+		}
+		flat := make([]float64, len(v))
+		for i, bit := range v {
+			flat[i] = float64(bit)
+		}
+		return flat, nil
+	case *Tuple:
+		v, ok := x.([]any)
+		if !ok {
+			return nil, fmt.Errorf("expected []any for %v, got %T", sp, x)
+		}
+		var flat []float64
+		for i, sub := range sp.spaces {
+			entry, err := flatten(sub, v[i])
+			if err != nil {
+				return nil, fmt.Errorf("failed to flatten element %d: %w", i, err)
+			}
+			flat = append(flat, entry...)
+		}
+		return flat, nil
+	default:
+		return nil, fmt.Errorf("space %v of type %T is not flattenable", s, s)
+	}
+}
+
+// unflatten converts a flat []float64 of the right length back to an element of a space.
+func unflatten(s any, x []float64) (any, error) {
+	switch sp := s.(type) {
+	case *Box:
+		return append([]float64(nil), x...), nil
+	case *Discrete:
+		index, err := oneHotIndex(x)
+		if err != nil {
+			return nil, err
+		}
+		return int(sp.start) + index, nil
+	case *MultiDiscrete:
+		result := make([]int, len(sp.nvec))
+		offset := 0
+		for i, n := range sp.nvec {
+			index, err := oneHotIndex(x[offset : offset+n])
+			if err != nil {
+				return nil, fmt.Errorf("entry %d: %w", i, err)
+			}
+			result[i] = sp.start[i] + index
+			offset += n
+		}
+		return result, nil
+	case *MultiBinary:
+		result := make([]int8, len(x))
+		for i, val := range x {
+			if val != 0 && val != 1 {
+				return nil, fmt.Errorf("binary entries must be 0 or 1, got %v at index %d", val, i)
+			}
+			result[i] = int8(val)
+		}
+		return result, nil
+	case *Tuple:
+		result := make([]any, sp.Len())
+		offset := 0
+		for i, sub := range sp.spaces {
+			dim, err := FlatDim(sub)
+			if err != nil {
+				return nil, err
+			}
+			entry, err := unflatten(sub, x[offset:offset+dim])
+			if err != nil {
+				return nil, fmt.Errorf("failed to unflatten element %d: %w", i, err)
+			}
+			result[i] = entry
+			offset += dim
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("space %v of type %T is not flattenable", s, s)
+	}
+}
+
+// oneHotIndex returns the index of the single 1 in a one-hot vector.
+func oneHotIndex(x []float64) (int, error) {
+	index := -1
+	for i, val := range x {
+		switch {
+		case val == 0:
+		case val == 1 && index < 0:
+			index = i
+		default:
+			return 0, fmt.Errorf("expected a one-hot vector, got %v", x)
+		}
+	}
+	if index < 0 {
+		return 0, fmt.Errorf("expected a one-hot vector, got %v", x)
+	}
+	return index, nil
+}
@@ -0,0 +1,128 @@
+package space_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/space"
+)
+
+// checkFlattenRoundTrip checks that samples of s flatten into FlattenSpace(s) and unflatten back to themselves.
+func checkFlattenRoundTrip[T any](t *testing.T, s gym.Space[T], dim int) {
+	t.Helper()
+
+	flatSpace, err := space.FlattenSpace(s)
+	if err != nil {
+		t.Fatalf("FlattenSpace failed: %v", err)
+	}
+	if got, err := space.FlatDim(s); err != nil || got != dim {
+		t.Fatalf("FlatDim() = %d, %v, want %d", got, err, dim)
+	}
+	if got := flatSpace.Shape(); !reflect.DeepEqual(got, []int{dim}) {
+		t.Errorf("flattened space has shape %v, want [%d]", got, dim)
+	}
+
+	for range 20 {
+		x, err := s.Sample(nil, nil)
+		if err != nil {
+			t.Fatalf("Sample failed: %v", err)
+		}
+		flat, err := space.Flatten(s, x)
+		if err != nil {
+			t.Fatalf("Flatten(%v) failed: %v", x, err)
+		}
+		if len(flat) != dim {
+			t.Fatalf("Flatten(%v) has length %d, want %d", x, len(flat), dim)
+		}
+		if !flatSpace.Contains(flat) {
+			t.Errorf("Flatten(%v) = %v is not contained in %v", x, flat, flatSpace)
+		}
+
+		got, err := space.Unflatten(s, flat)
+		if err != nil {
+			t.Fatalf("Unflatten(%v) failed: %v", flat, err)
+		}
+		if !reflect.DeepEqual(got, x) {
+			t.Errorf("Unflatten(Flatten(%v)) = %v", x, got)
+		}
+	}
+}
+
+func TestFlattenRoundTrip(t *testing.T) {
+	t.Run("Box", func(t *testing.T) {
+		box, err := space.NewBoxWithRNG(newRNG(t), -1.0, 1.0, []int{2, 3})
+		if err != nil {
+			t.Fatalf("NewBoxWithRNG failed: %v", err)
+		}
+		checkFlattenRoundTrip(t, box, 6)
+	})
+	t.Run("Discrete", func(t *testing.T) {
+		d, err := space.NewDiscreteWithRNG(newRNG(t), 4, -1)
+		if err != nil {
+			t.Fatalf("NewDiscreteWithRNG failed: %v", err)
+		}
+		checkFlattenRoundTrip(t, d, 4)
+	})
+	t.Run("MultiDiscrete", func(t *testing.T) {
+		m, err := space.NewMultiDiscreteWithRNG(newRNG(t), []int{2, 3}, []int{0, 5})
+		if err != nil {
+			t.Fatalf("NewMultiDiscreteWithRNG failed: %v", err)
+		}
+		checkFlattenRoundTrip(t, m, 5)
+	})
+	t.Run("MultiBinary", func(t *testing.T) {
+		m, err := space.NewMultiBinaryWithRNG(newRNG(t), 4)
+		if err != nil {
+			t.Fatalf("NewMultiBinaryWithRNG failed: %v", err)
+		}
+		checkFlattenRoundTrip(t, m, 4)
+	})
+	t.Run("Tuple", func(t *testing.T) {
+		checkFlattenRoundTrip(t, newTuple(t), 5)
+	})
+}
+
+func TestFlattenSpace(t *testing.T) {
+	box, err := space.NewBoxWithDTypeWithRNG(newRNG(t), "float32", -1.0, 1.0, []int{2, 2})
+	if err != nil {
+		t.Fatalf("NewBoxWithDTypeWithRNG failed: %v", err)
+	}
+	d, err := space.NewDiscreteWithRNG(newRNG(t), 3)
+	if err != nil {
+		t.Fatalf("NewDiscreteWithRNG failed: %v", err)
+	}
+	text, err := space.NewTextWithRNG(newRNG(t), 4, "ab")
+	if err != nil {
+		t.Fatalf("NewTextWithRNG failed: %v", err)
+	}
+	disableDefaultRNG(t)
+
+	// Flattening keeps the data type and does not need the default RNG
+	flat, err := space.FlattenSpace(box)
+	if err != nil {
+		t.Fatalf("FlattenSpace failed with the default RNG disabled: %v", err)
+	}
+	if flat.DType() != "float32" {
+		t.Errorf("flattened Box has dtype %q, want float32", flat.DType())
+	}
+	if _, err := flat.Sample(nil, nil); err != nil {
+		t.Errorf("Sample of the flattened Box failed: %v", err)
+	}
+
+	for _, s := range []any{d, newTuple(t)} {
+		if _, err := space.FlattenSpace(s); err != nil {
+			t.Errorf("FlattenSpace(%v) failed with the default RNG disabled: %v", s, err)
+		}
+	}
+
+	if _, err := space.FlattenSpace(text); err == nil {
+		t.Error("FlattenSpace succeeded for a Text space, expected an error")
+	}
+	if _, err := space.Unflatten(d, []float64{1, 1, 0}); err == nil {
+		t.Error("Unflatten succeeded for a vector that is not one-hot, expected an error")
+	}
+	if _, err := space.Unflatten(d, []float64{1, 0}); err == nil {
+		t.Error("Unflatten succeeded for a vector of the wrong length, expected an error")
+	}
+}
@@ -0,0 +1,351 @@
+package space
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/gocnn/gym/rand"
+)
+
+// jsonFloat is a float64 that encodes infinite values as the strings "inf" and "-inf".
+type jsonFloat float64
+
+// MarshalJSON encodes the value as a number, or as "inf" or "-inf" if it is infinite.
+func (f jsonFloat) MarshalJSON() ([]byte, error) {
+	switch v := float64(f); {
+	case math.IsInf(v, 1):
+		return []byte(`"inf"`), nil
+	case math.IsInf(v, -1):
+		return []byte(`"-inf"`), nil
+	case math.IsNaN(v):
+		return nil, fmt.Errorf("cannot encode NaN")
+	default:
+		return json.Marshal(v)
+	}
+}
+
+// UnmarshalJSON decodes a number or one of the strings "inf" and "-inf".
+func (f *jsonFloat) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		switch s {
+		case "inf":
+			*f = jsonFloat(math.Inf(1))
+		case "-inf":
+			*f = jsonFloat(math.Inf(-1))
+		default:
+			return fmt.Errorf("invalid bound %q, expected a number, \"inf\" or \"-inf\"", s)
+		}
+		return nil
+	}
+
+	var v float64
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*f = jsonFloat(v)
+	return nil
+}
+
+// jsonType is the part of the encoding shared by all spaces.
+type jsonType struct {
+	Type string `json:"type"`
+}
+
+// boxJSON and the following types are the JSON encodings of the concrete spaces.
+type boxJSON struct {
+	Type  string      `json:"type"`
+	Low   []jsonFloat `json:"low"`
+	High  []jsonFloat `json:"high"`
+	Shape []int       `json:"shape"`
+	DType string      `json:"dtype"`
+}
+
+type discreteJSON struct {
+	Type  string `json:"type"`
+	N     int    `json:"n"`
+	Start int    `json:"start"`
+}
+
+type multiBinaryJSON struct {
+	Type  string `json:"type"`
+	Shape []int  `json:"shape"`
+}
+
+type multiDiscreteJSON struct {
+	Type  string `json:"type"`
+	NVec  []int  `json:"nvec"`
+	Start []int  `json:"start"`
+}
+
+type textJSON struct {
+	Type      string `json:"type"`
+	MaxLength int    `json:"max_length"`
+	Charset   string `json:"charset"`
+}
+
+type tupleJSON struct {
+	Type   string            `json:"type"`
+	Spaces []json.RawMessage `json:"spaces"`
+}
+
+// ParseJSON decodes a space encoded by the MarshalJSON method of a concrete space.
+//
+// Spaces are encoded as JSON objects whose "type" field names the concrete space, e.g.
+//
+//	{"type":"Box","low":[-1,"-inf"],"high":[1,"inf"],"shape":[2],"dtype":"float64"}
+//	{"type":"Discrete","n":3,"start":0}
+//
+// JSON has no infinite numbers, so infinite bounds of a Box are encoded as the strings "inf" and "-inf".
+// Decoded spaces get their own RNG, as if constructed with NewBox, NewDiscrete, etc.
+//
+// Parameters:
+//   - data: The JSON encoding of the space
+//
+// Returns:
+//   - The decoded space, e.g. a *Box or a *Discrete
+//   - An error if data is not valid JSON, the type is unknown or the space is invalid
+func ParseJSON(data []byte) (any, error) {
+	var t jsonType
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("failed to decode space: %w", err)
+	}
+
+	var s json.Unmarshaler
+	switch t.Type {
+	case "Box":
+		s = &Box{}
+	case "Discrete":
+		s = &Discrete{}
+	case "MultiBinary":
+		s = &MultiBinary{}
+	case "MultiDiscrete":
+		s = &MultiDiscrete{}
+	case "Text":
+		s = &Text{}
+	case "Tuple":
+		s = &Tuple{}
+	default:
+		return nil, fmt.Errorf("unknown space type %q", t.Type)
+	}
+
+	if err := s.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// unmarshalSpace decodes data into v after checking that its type field is want.
+func unmarshalSpace(data []byte, want string, v any) error {
+	var t jsonType
+	if err := json.Unmarshal(data, &t); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", want, err)
+	}
+	if t.Type != want {
+		return fmt.Errorf("cannot decode space of type %q as %s", t.Type, want)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", want, err)
+	}
+	return nil
+}
+
+// MarshalJSON encodes the space as {"type":"Box","low":...,"high":...,"shape":...,"dtype":...}.
+func (b *Box) MarshalJSON() ([]byte, error) {
+	low := make([]jsonFloat, len(b.low))
+	high := make([]jsonFloat, len(b.high))
+	for i := range b.low {
+		low[i], high[i] = jsonFloat(b.low[i]), jsonFloat(b.high[i])
+	}
+	return json.Marshal(boxJSON{Type: "Box", Low: low, High: high, Shape: b.shape, DType: b.dtype})
+}
+
+// UnmarshalJSON decodes a space encoded by MarshalJSON, with dtype defaulting to "float64".
+func (b *Box) UnmarshalJSON(data []byte) error {
+	var v boxJSON
+	if err := unmarshalSpace(data, "Box", &v); err != nil {
+		return err
+	}
+
+	low := make([]float64, len(v.Low))
+	for i, x := range v.Low {
+		low[i] = float64(x)
+	}
+	high := make([]float64, len(v.High))
+	for i, x := range v.High {
+		high[i] = float64(x)
+	}
+
+	rng, err := rngOrNew(b.rng)
+	if err != nil {
+		return err
+	}
+	decoded, err := NewBoxWithRNG(rng, low, high, v.Shape)
+	if err != nil {
+		return err
+	}
+	if v.DType != "" {
+		if v.DType != "float64" && v.DType != "float32" {
+			return fmt.Errorf("dtype must be \"float64\" or \"float32\", got %q", v.DType)
+		}
+		decoded.setDType(v.DType)
+	}
+
+	*b = *decoded
+	return nil
+}
+
+// MarshalJSON encodes the space as {"type":"Discrete","n":...,"start":...}.
+func (d *Discrete) MarshalJSON() ([]byte, error) {
+	return json.Marshal(discreteJSON{Type: "Discrete", N: int(d.n), Start: int(d.start)})
+}
+
+// UnmarshalJSON decodes a space encoded by MarshalJSON.
+func (d *Discrete) UnmarshalJSON(data []byte) error {
+	var v discreteJSON
+	if err := unmarshalSpace(data, "Discrete", &v); err != nil {
+		return err
+	}
+
+	rng, err := rngOrNew(d.rng)
+	if err != nil {
+		return err
+	}
+	decoded, err := NewDiscreteWithRNG(rng, v.N, v.Start)
+	if err != nil {
+		return err
+	}
+
+	*d = *decoded
+	return nil
+}
+
+// MarshalJSON encodes the space as {"type":"MultiBinary","shape":...}.
+func (m *MultiBinary) MarshalJSON() ([]byte, error) {
+	return json.Marshal(multiBinaryJSON{Type: "MultiBinary", Shape: m.shape})
+}
+
+// UnmarshalJSON decodes a space encoded by MarshalJSON.
+func (m *MultiBinary) UnmarshalJSON(data []byte) error {
+	var v multiBinaryJSON
+	if err := unmarshalSpace(data, "MultiBinary", &v); err != nil {
+		return err
+	}
+
+	rng, err := rngOrNew(m.rng)
+	if err != nil {
+		return err
+	}
+	decoded, err := NewMultiBinaryShapeWithRNG(rng, v.Shape...)
+	if err != nil {
+		return err
+	}
+
+	*m = *decoded
+	return nil
+}
+
+// MarshalJSON encodes the space as {"type":"MultiDiscrete","nvec":...,"start":...}.
+func (m *MultiDiscrete) MarshalJSON() ([]byte, error) {
+	return json.Marshal(multiDiscreteJSON{Type: "MultiDiscrete", NVec: m.nvec, Start: m.start})
+}
+
+// UnmarshalJSON decodes a space encoded by MarshalJSON, with start defaulting to zeros.
+func (m *MultiDiscrete) UnmarshalJSON(data []byte) error {
+	var v multiDiscreteJSON
+	if err := unmarshalSpace(data, "MultiDiscrete", &v); err != nil {
+		return err
+	}
+
+	rng, err := rngOrNew(m.rng)
+	if err != nil {
+		return err
+	}
+	var start [][]int
+	if v.Start != nil {
+		start = append(start, v.Start)
+	}
+	decoded, err := NewMultiDiscreteWithRNG(rng, v.NVec, start...)
+	if err != nil {
+		return err
+	}
+
+	*m = *decoded
+	return nil
+}
+
+// MarshalJSON encodes the space as {"type":"Text","max_length":...,"charset":...}.
+func (t *Text) MarshalJSON() ([]byte, error) {
+	return json.Marshal(textJSON{Type: "Text", MaxLength: t.maxLength, Charset: string(t.charset)})
+}
+
+// UnmarshalJSON decodes a space encoded by MarshalJSON, with charset defaulting to lowercase a-z and space.
+func (t *Text) UnmarshalJSON(data []byte) error {
+	var v textJSON
+	if err := unmarshalSpace(data, "Text", &v); err != nil {
+		return err
+	}
+
+	rng, err := rngOrNew(t.rng)
+	if err != nil {
+		return err
+	}
+	decoded, err := NewTextWithRNG(rng, v.MaxLength, v.Charset)
+	if err != nil {
+		return err
+	}
+
+	*t = *decoded
+	return nil
+}
+
+// MarshalJSON encodes the space as {"type":"Tuple","spaces":[...]}, which requires every subspace to
+// implement json.Marshaler.
+func (t *Tuple) MarshalJSON() ([]byte, error) {
+	spaces := make([]json.RawMessage, len(t.spaces))
+	for i, s := range t.spaces {
+		m, ok := s.(json.Marshaler)
+		if !ok {
+			return nil, fmt.Errorf("subspace %d of type %T cannot be encoded as JSON", i, s)
+		}
+		data, err := m.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode subspace %d: %w", i, err)
+		}
+		spaces[i] = data
+	}
+	return json.Marshal(tupleJSON{Type: "Tuple", Spaces: spaces})
+}
+
+// UnmarshalJSON decodes a space encoded by MarshalJSON, decoding the subspaces with ParseJSON.
+func (t *Tuple) UnmarshalJSON(data []byte) error {
+	var v tupleJSON
+	if err := unmarshalSpace(data, "Tuple", &v); err != nil {
+		return err
+	}
+
+	spaces := make([]any, len(v.Spaces))
+	for i, raw := range v.Spaces {
+		s, err := ParseJSON(raw)
+		if err != nil {
+			return fmt.Errorf("failed to decode subspace %d: %w", i, err)
+		}
+		spaces[i] = s
+	}
+	decoded, err := NewTuple(spaces...)
+	if err != nil {
+		return err
+	}
+
+	*t = *decoded
+	return nil
+}
+
+// rngOrNew returns rng, or the RNG of a newly constructed space if rng is nil.
+func rngOrNew(rng *rand.RNG) (*rand.RNG, error) {
+	if rng != nil {
+		return rng, nil
+	}
+	return newRNG()
+}
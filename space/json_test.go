@@ -0,0 +1,134 @@
+package space_test
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gocnn/gym/rand"
+	"github.com/gocnn/gym/space"
+)
+
+func TestBoxJSONInfiniteBounds(t *testing.T) {
+	box, err := space.NewBoxWithDTypeWithRNG(newRNG(t), "float32",
+		[]float64{-1, math.Inf(-1), 0, math.Inf(-1)}, []float64{1, 5, math.Inf(1), math.Inf(1)}, []int{2, 2})
+	if err != nil {
+		t.Fatalf("NewBoxWithDTypeWithRNG failed: %v", err)
+	}
+
+	data, err := json.Marshal(box)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	want := `{"type":"Box","low":[-1,"-inf",0,"-inf"],"high":[1,5,"inf","inf"],"shape":[2,2],"dtype":"float32"}`
+	if string(data) != want {
+		t.Errorf("Marshal(%v) = %s, want %s", box, data, want)
+	}
+
+	// Decoding into a space with an RNG keeps that RNG, so the default RNG is not needed
+	decoded, err := space.NewBoxWithRNG(newRNG(t), 0.0, 1.0, []int{1})
+	if err != nil {
+		t.Fatalf("NewBoxWithRNG failed: %v", err)
+	}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !slices.Equal(decoded.Low(), box.Low()) || !slices.Equal(decoded.High(), box.High()) {
+		t.Errorf("decoded bounds = %v, %v, want %v, %v", decoded.Low(), decoded.High(), box.Low(), box.High())
+	}
+	if !slices.Equal(decoded.Shape(), []int{2, 2}) || decoded.DType() != "float32" {
+		t.Errorf("decoded shape and dtype = %v, %q, want [2 2], float32", decoded.Shape(), decoded.DType())
+	}
+	for _, manner := range []string{"below", "above"} {
+		got, err := decoded.IsBounded(manner)
+		if err != nil {
+			t.Fatalf("IsBounded failed: %v", err)
+		}
+		if want, _ := box.IsBounded(manner); got != want {
+			t.Errorf("decoded IsBounded(%q) = %t, want %t", manner, got, want)
+		}
+	}
+
+	for _, invalid := range []string{
+		`{"type":"Box","low":["-infinity"],"high":[1],"shape":[1]}`,
+		`{"type":"Box","low":[2],"high":[1],"shape":[1]}`,
+		`{"type":"Box","low":[0],"high":[1],"shape":[1],"dtype":"int8"}`,
+		`{"type":"Discrete","n":3,"start":0}`,
+	} {
+		if err := json.Unmarshal([]byte(invalid), decoded); err == nil {
+			t.Errorf("Unmarshal(%s) into a Box succeeded, expected an error", invalid)
+		}
+	}
+}
+
+func TestDiscreteJSONRoundTrip(t *testing.T) {
+	d := newDiscrete(t)
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if want := `{"type":"Discrete","n":10,"start":-3}`; string(data) != want {
+		t.Errorf("Marshal(%v) = %s, want %s", d, data, want)
+	}
+
+	decoded, err := space.NewDiscreteWithRNG(newRNG(t), 2)
+	if err != nil {
+		t.Fatalf("NewDiscreteWithRNG failed: %v", err)
+	}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !slices.Equal(decoded.All(), d.All()) {
+		t.Errorf("decoded space has elements %v, want %v", decoded.All(), d.All())
+	}
+
+	if err := json.Unmarshal([]byte(`{"type":"Discrete","n":0,"start":0}`), decoded); err == nil {
+		t.Error("Unmarshal of Discrete(0) succeeded, expected an error")
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	if rand.DefaultRNGDisabled() {
+		t.Skip("default RNG is disabled")
+	}
+
+	for _, data := range []string{
+		`{"type":"Box","low":["-inf",0],"high":["inf",1],"shape":[2],"dtype":"float64"}`,
+		`{"type":"Discrete","n":3,"start":1}`,
+		`{"type":"MultiBinary","shape":[2,3]}`,
+		`{"type":"MultiDiscrete","nvec":[2,3],"start":[0,1]}`,
+		`{"type":"Text","max_length":4,"charset":"ab"}`,
+		`{"type":"Tuple","spaces":[{"type":"Discrete","n":3,"start":1},{"type":"Text","max_length":4,"charset":"ab"}]}`,
+	} {
+		s, err := space.ParseJSON([]byte(data))
+		if err != nil {
+			t.Fatalf("ParseJSON(%s) failed: %v", data, err)
+		}
+		encoded, err := json.Marshal(s)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		var got, want any
+		if err := json.Unmarshal(encoded, &got); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+		if err := json.Unmarshal([]byte(data), &want); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ParseJSON(%s) encodes back to %s", data, encoded)
+		}
+	}
+
+	for _, invalid := range []string{`{"type":"Graph"}`, `{"type":1}`, `[]`} {
+		if _, err := space.ParseJSON([]byte(invalid)); err == nil {
+			t.Errorf("ParseJSON(%s) succeeded, expected an error", invalid)
+		} else if strings.Contains(invalid, "Graph") && !strings.Contains(err.Error(), "Graph") {
+			t.Errorf("ParseJSON(%s) error = %q, want it to name the unknown type", invalid, err)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package space
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gocnn/gym/rand"
+)
+
+// MultiBinary represents an n-shape binary space.
+//
+// Elements of this space are binary vectors of length n, where each entry is either 0 or 1.
+// Multi-dimensional spaces store their elements flattened in row-major order.
+//
+// Example:
+//   - MultiBinary(5) contains vectors such as [0, 1, 0, 1, 1]
+//   - MultiBinary([2, 2]) contains vectors such as [0, 1, 1, 0] representing [[0, 1], [1, 0]]
+type MultiBinary struct {
+	n     int   // The number of binary entries of each element
+	shape []int // The shape of each element
+	rng   *rand.RNG
+}
+
+// NewMultiBinary creates a new MultiBinary space.
+//
+// Parameters:
+//   - n: The number of binary entries of each element (must be positive)
+//
+// Returns:
+//   - A new MultiBinary space
+//   - An error if n is not positive or the default RNG is disabled
+func NewMultiBinary(n int) (*MultiBinary, error) {
+	rng, err := newRNG()
+	if err != nil {
+		return nil, err
+	}
+	return NewMultiBinaryWithRNG(rng, n)
+}
+
+// NewMultiBinaryWithRNG creates a new MultiBinary space that samples from the given RNG.
+//
+// Parameters:
+//   - rng: The random number generator used for sampling
+//   - n: The number of binary entries of each element (must be positive)
+//
+// Returns:
+//   - A new MultiBinary space
+//   - An error if rng is nil or n is not positive
+func NewMultiBinaryWithRNG(rng *rand.RNG, n int) (*MultiBinary, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("n has to be positive, got %d", n)
+	}
+	return NewMultiBinaryShapeWithRNG(rng, n)
+}
+
+// NewMultiBinaryShape creates a new multi-dimensional MultiBinary space.
+//
+// Parameters:
+//   - shape: The shape of each element (all dimensions must be positive)
+//
+// Returns:
+//   - A new MultiBinary space
+//   - An error if shape is invalid or the default RNG is disabled
+func NewMultiBinaryShape(shape ...int) (*MultiBinary, error) {
+	rng, err := newRNG()
+	if err != nil {
+		return nil, err
+	}
+	return NewMultiBinaryShapeWithRNG(rng, shape...)
+}
+
+// NewMultiBinaryShapeWithRNG creates a new multi-dimensional MultiBinary space that samples from the given RNG.
+//
+// Parameters:
+//   - rng: The random number generator used for sampling
+//   - shape: The shape of each element (all dimensions must be positive)
+//
+// Returns:
+//   - A new MultiBinary space
+//   - An error if rng is nil or shape is invalid
+func NewMultiBinaryShapeWithRNG(rng *rand.RNG, shape ...int) (*MultiBinary, error) {
+	if rng == nil {
+		return nil, fmt.Errorf("rng must not be nil")
+	}
+
+	if len(shape) == 0 {
+		return nil, fmt.Errorf("shape must not be empty")
+	}
+
+	n := 1
+	for _, dim := range shape {
+		if dim <= 0 {
+			return nil, fmt.Errorf("shape dimensions have to be positive, got %v", shape)
+		}
+		n *= dim
+	}
+
+	return &MultiBinary{
+		n:     n,
+		shape: slices.Clone(shape),
+		rng:   rng,
+	}, nil
+}
+
+// Sample generates a single random sample from this space.
+//
+// Each entry is drawn independently from a fair coin flip.
+//
+// Parameters:
+//   - mask: A mask for sampling values (currently not implemented)
+//   - probability: A probability mask for sampling values (currently not implemented)
+//
+// Returns:
+//   - A sampled binary vector from the space
+//   - An error if sampling fails
+func (m *MultiBinary) Sample(mask any, probability any) ([]int8, error) {
+	if mask != nil || probability != nil {
+		return nil, fmt.Errorf("mask and probability sampling not yet implemented")
+	}
+
+	sample := make([]int8, m.n)
+	for i := range sample {
+		sample[i] = int8(m.rng.IntN(2))
+	}
+	return sample, nil
+}
+
+// Seed sets the pseudorandom number generator seed of this space.
+//
+// Parameters:
+//   - seed: The seed value for the space
+//
+// Returns:
+//   - The effective seed value used
+//   - An error if seeding fails
+func (m *MultiBinary) Seed(seed int64) (int64, error) {
+	return m.rng.Seed(seed)
+}
+
+// Contains returns true if x is a valid member of this space.
+//
+// Parameters:
+//   - x: The element to check for membership
+//
+// Returns:
+//   - true if x has length n (the product of the shape) and every entry is 0 or 1, false otherwise
+func (m *MultiBinary) Contains(x []int8) bool {
+	if len(x) != m.n {
+		return false
+	}
+
+	for _, val := range x {
+		if val != 0 && val != 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// Shape returns the shape of the space elements.
+//
+// Returns:
+//   - A copy of the shape of each element
+func (m *MultiBinary) Shape() []int {
+	return slices.Clone(m.shape)
+}
+
+// DType returns the data type of the space elements.
+//
+// Returns:
+//   - "int8" as the data type string
+func (m *MultiBinary) DType() string {
+	return "int8"
+}
+
+// IsFlattenable returns true if this space can be flattened to a Box space.
+//
+// Returns:
+//   - true (multi-binary spaces can be flattened)
+func (m *MultiBinary) IsFlattenable() bool {
+	return true
+}
+
+// FlatDim returns the number of elements of a flattened sample of this space.
+//
+// Returns:
+//   - The number of binary entries (n)
+func (m *MultiBinary) FlatDim() int {
+	return m.n
+}
+
+// ToJSONable converts a batch of samples from this space to a JSONable data type.
+//
+// Parameters:
+//   - samples: A slice of samples from this space
+//
+// Returns:
+//   - A slice of any type that can be marshaled to JSON
+//   - An error if conversion fails
+func (m *MultiBinary) ToJSONable(samples [][]int8) ([]any, error) {
+	result := make([]any, len(samples))
+	for i, sample := range samples {
+		ints := make([]int, len(sample))
+		for j, val := range sample {
+			ints[j] = int(val)
+		}
+		result[i] = ints
+	}
+	return result, nil
+}
+
+// FromJSONable converts a JSONable data type to a batch of samples from this space.
+//
+// Parameters:
+//   - json: A slice of any type that was previously created by ToJSONable
+//
+// Returns:
+//   - A slice of samples of type []int8
+//   - An error if conversion fails or the data is invalid for this space
+func (m *MultiBinary) FromJSONable(json []any) ([][]int8, error) {
+	result := make([][]int8, len(json))
+	for i, val := range json {
+		switch v := val.(type) {
+		case []int8:
+			result[i] = v
+		case []int:
+			bits := make([]int8, len(v))
+			for j, elem := range v {
+				bits[j] = int8(elem)
+			}
+			result[i] = bits
+		case []interface{}:
+			bits := make([]int8, len(v))
+			for j, elem := range v {
+				switch e := elem.(type) {
+				case float64:
+					bits[j] = int8(e)
+				case int:
+					bits[j] = int8(e)
+				default:
+					return nil, fmt.Errorf("expected float64 or int, got %T", elem)
+				}
+			}
+			result[i] = bits
+		default:
+			return nil, fmt.Errorf("expected []int8, []int or []interface{}, got %T", val)
+		}
+	}
+	return result, nil
+}
+
+// String returns a string representation of this space.
+//
+// Returns:
+//   - A string representation in the format "MultiBinary(n)" or "MultiBinary(shape)"
+func (m *MultiBinary) String() string {
+	if len(m.shape) > 1 {
+		return fmt.Sprintf("MultiBinary(%v)", m.shape)
+	}
+	return fmt.Sprintf("MultiBinary(%d)", m.n)
+}
+
+// N returns the number of binary entries of each element, which is the product of the shape.
+//
+// Returns:
+//   - The number of binary entries (n)
+func (m *MultiBinary) N() int {
+	return m.n
+}
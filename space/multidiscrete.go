@@ -0,0 +1,252 @@
+package space
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gocnn/gym/rand"
+)
+
+// MultiDiscrete represents the cartesian product of arbitrary Discrete spaces.
+//
+// It is useful to represent game controllers or keyboards where each key can be represented as a discrete action space.
+// Entry i of an element lies in {start[i], start[i]+1, ..., start[i]+nvec[i]-1}.
+//
+// Example:
+//   - MultiDiscrete([5, 2, 2]) represents an arrow key pad (5 states) and two buttons (2 states each)
+type MultiDiscrete struct {
+	nvec  []int // The number of elements of each discrete entry
+	start []int // The smallest element of each discrete entry
+	rng   *rand.RNG
+}
+
+// NewMultiDiscrete creates a new MultiDiscrete space.
+//
+// Parameters:
+//   - nvec: The number of elements of each discrete entry (all must be positive)
+//   - start: The smallest element of each discrete entry (optional, defaults to zeros)
+//
+// Returns:
+//   - A new MultiDiscrete space
+//   - An error if nvec is invalid, start does not match nvec or the default RNG is disabled
+func NewMultiDiscrete(nvec []int, start ...[]int) (*MultiDiscrete, error) {
+	rng, err := newRNG()
+	if err != nil {
+		return nil, err
+	}
+	return NewMultiDiscreteWithRNG(rng, nvec, start...)
+}
+
+// NewMultiDiscreteWithRNG creates a new MultiDiscrete space that samples from the given RNG.
+//
+// Parameters:
+//   - rng: The random number generator used for sampling
+//   - nvec: The number of elements of each discrete entry (all must be positive)
+//   - start: The smallest element of each discrete entry (optional, defaults to zeros)
+//
+// Returns:
+//   - A new MultiDiscrete space
+//   - An error if rng is nil, nvec is invalid or start does not match nvec
+func NewMultiDiscreteWithRNG(rng *rand.RNG, nvec []int, start ...[]int) (*MultiDiscrete, error) {
+	if rng == nil {
+		return nil, fmt.Errorf("rng must not be nil")
+	}
+
+	if len(nvec) == 0 {
+		return nil, fmt.Errorf("nvec must not be empty")
+	}
+
+	for i, n := range nvec {
+		if n <= 0 {
+			return nil, fmt.Errorf("nvec (counts) have to be positive, got %d at index %d", n, i)
+		}
+	}
+
+	startVal := make([]int, len(nvec))
+	if len(start) > 0 {
+		if len(start[0]) != len(nvec) {
+			return nil, fmt.Errorf("start must have the same length as nvec, got %d and %d", len(start[0]), len(nvec))
+		}
+		copy(startVal, start[0])
+	}
+
+	return &MultiDiscrete{
+		nvec:  slices.Clone(nvec),
+		start: startVal,
+		rng:   rng,
+	}, nil
+}
+
+// Sample generates a single random sample from this space.
+//
+// Each entry is drawn uniformly at random from its discrete range.
+//
+// Parameters:
+//   - mask: A mask for sampling values (currently not implemented)
+//   - probability: A probability mask for sampling values (currently not implemented)
+//
+// Returns:
+//   - A sampled vector from the space
+//   - An error if sampling fails
+func (m *MultiDiscrete) Sample(mask any, probability any) ([]int, error) {
+	if mask != nil || probability != nil {
+		return nil, fmt.Errorf("mask and probability sampling not yet implemented")
+	}
+
+	sample := make([]int, len(m.nvec))
+	for i, n := range m.nvec {
+		sample[i] = m.start[i] + m.rng.IntN(n)
+	}
+	return sample, nil
+}
+
+// Seed sets the pseudorandom number generator seed of this space.
+//
+// Parameters:
+//   - seed: The seed value for the space
+//
+// Returns:
+//   - The effective seed value used
+//   - An error if seeding fails
+func (m *MultiDiscrete) Seed(seed int64) (int64, error) {
+	return m.rng.Seed(seed)
+}
+
+// Contains returns true if x is a valid member of this space.
+//
+// Parameters:
+//   - x: The element to check for membership
+//
+// Returns:
+//   - true if x has the length of nvec and every entry is in [start[i], start[i] + nvec[i]), false otherwise
+func (m *MultiDiscrete) Contains(x []int) bool {
+	if len(x) != len(m.nvec) {
+		return false
+	}
+
+	for i, val := range x {
+		if val < m.start[i] || val >= m.start[i]+m.nvec[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Shape returns the shape of the space elements.
+//
+// Returns:
+//   - A slice containing the number of discrete entries
+func (m *MultiDiscrete) Shape() []int {
+	return []int{len(m.nvec)}
+}
+
+// DType returns the data type of the space elements.
+//
+// Returns:
+//   - "int64" as the data type string
+func (m *MultiDiscrete) DType() string {
+	return "int64"
+}
+
+// IsFlattenable returns true if this space can be flattened to a Box space.
+//
+// Returns:
+//   - true (multi-discrete spaces can be flattened)
+func (m *MultiDiscrete) IsFlattenable() bool {
+	return true
+}
+
+// FlatDim returns the number of elements of a flattened sample of this space.
+//
+// Each entry is flattened to a one-hot encoding.
+//
+// Returns:
+//   - The sum of nvec
+func (m *MultiDiscrete) FlatDim() int {
+	dim := 0
+	for _, n := range m.nvec {
+		dim += n
+	}
+	return dim
+}
+
+// ToJSONable converts a batch of samples from this space to a JSONable data type.
+//
+// Parameters:
+//   - samples: A slice of samples from this space
+//
+// Returns:
+//   - A slice of any type that can be marshaled to JSON
+//   - An error if conversion fails
+func (m *MultiDiscrete) ToJSONable(samples [][]int) ([]any, error) {
+	result := make([]any, len(samples))
+	for i, sample := range samples {
+		result[i] = slices.Clone(sample)
+	}
+	return result, nil
+}
+
+// FromJSONable converts a JSONable data type to a batch of samples from this space.
+//
+// Parameters:
+//   - json: A slice of any type that was previously created by ToJSONable
+//
+// Returns:
+//   - A slice of samples of type []int
+//   - An error if conversion fails or the data is invalid for this space
+func (m *MultiDiscrete) FromJSONable(json []any) ([][]int, error) {
+	result := make([][]int, len(json))
+	for i, val := range json {
+		switch v := val.(type) {
+		case []int:
+			result[i] = slices.Clone(v)
+		case []interface{}:
+			ints := make([]int, len(v))
+			for j, elem := range v {
+				switch e := elem.(type) {
+				case float64:
+					ints[j] = int(e)
+				case int:
+					ints[j] = e
+				case int64:
+					ints[j] = int(e)
+				default:
+					return nil, fmt.Errorf("expected int-like value, got %T", elem)
+				}
+			}
+			result[i] = ints
+		default:
+			return nil, fmt.Errorf("expected []int or []interface{}, got %T", val)
+		}
+	}
+	return result, nil
+}
+
+// String returns a string representation of this space.
+//
+// Returns:
+//   - A string representation in the format "MultiDiscrete(nvec)" or "MultiDiscrete(nvec, start=s)"
+func (m *MultiDiscrete) String() string {
+	for _, s := range m.start {
+		if s != 0 {
+			return fmt.Sprintf("MultiDiscrete(%v, start=%v)", m.nvec, m.start)
+		}
+	}
+	return fmt.Sprintf("MultiDiscrete(%v)", m.nvec)
+}
+
+// Nvec returns the number of elements of each discrete entry.
+//
+// Returns:
+//   - A copy of nvec
+func (m *MultiDiscrete) Nvec() []int {
+	return slices.Clone(m.nvec)
+}
+
+// Start returns the smallest element of each discrete entry.
+//
+// Returns:
+//   - A copy of start
+func (m *MultiDiscrete) Start() []int {
+	return slices.Clone(m.start)
+}
@@ -0,0 +1,22 @@
+package space
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/gocnn/gym/rand"
+)
+
+// newRNG returns the RNG of a space constructed without an explicit RNG.
+//
+// Every space gets its own RNG, so seeding one space never affects the samples of another. The RNG is seeded
+// from the default RNG, which keeps the spaces of a process distinct and lets DisableDefaultRNG flush them out.
+func newRNG() (*rand.RNG, error) {
+	if rand.DefaultRNGDisabled() {
+		return nil, fmt.Errorf("default RNG is disabled, construct the space with an explicit RNG")
+	}
+
+	// Seeds are positive since a zero seed means a time-based seed
+	rng, _, err := rand.NewRNG(rand.GetDefaultRNG().Int64N(math.MaxInt64) + 1)
+	return rng, err
+}
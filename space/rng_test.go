@@ -0,0 +1,53 @@
+package space_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gocnn/gym/rand"
+	"github.com/gocnn/gym/space"
+)
+
+// TestDiscreteDefaultRNGsAreIndependent checks that spaces constructed without an explicit RNG each get their
+// own: sampling or seeding one does not change the samples of another.
+func TestDiscreteDefaultRNGsAreIndependent(t *testing.T) {
+	if rand.DefaultRNGDisabled() {
+		t.Skip("default RNG is disabled")
+	}
+
+	a, err := space.NewDiscrete(100)
+	if err != nil {
+		t.Fatalf("NewDiscrete failed: %v", err)
+	}
+	b, err := space.NewDiscrete(100)
+	if err != nil {
+		t.Fatalf("NewDiscrete failed: %v", err)
+	}
+
+	sample := func(s *space.Discrete) []int {
+		t.Helper()
+
+		samples := make([]int, 20)
+		for i := range samples {
+			x, err := s.Sample(nil, nil)
+			if err != nil {
+				t.Fatalf("Sample failed: %v", err)
+			}
+			samples[i] = x
+		}
+		return samples
+	}
+
+	want := sample(b.Clone())
+	sample(a)
+	if _, err := a.Seed(5); err != nil {
+		t.Fatalf("Seed failed: %v", err)
+	}
+	if got := sample(b); !slices.Equal(got, want) {
+		t.Errorf("sampling and seeding a changed the samples of b to %v, want %v", got, want)
+	}
+
+	if want := sample(a); slices.Equal(sample(b), want) {
+		t.Errorf("a and b sampled the same sequence %v", want)
+	}
+}
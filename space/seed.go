@@ -0,0 +1,43 @@
+package space
+
+import (
+	"fmt"
+
+	"github.com/gocnn/gym"
+)
+
+// SeedAndSample seeds a space and draws a sequence of samples from it.
+//
+// Two spaces constructed with the same arguments and passed the same seed return identical sequences, as
+// long as they do not share their RNG with other spaces that are sampled in between.
+//
+// Parameters:
+//   - s: The space to sample from
+//   - seed: The seed for the space (must be positive, as 0 selects a time-based seed)
+//   - n: The number of samples to draw (must be non-negative)
+//
+// Returns:
+//   - The n samples, drawn without masks
+//   - An error if seed or n is invalid, or seeding or sampling fails
+func SeedAndSample[T any](s gym.Space[T], seed int64, n int) ([]T, error) {
+	if seed <= 0 {
+		return nil, fmt.Errorf("seed must be positive, got %d", seed)
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("n must be non-negative, got %d", n)
+	}
+
+	if _, err := s.Seed(seed); err != nil {
+		return nil, fmt.Errorf("failed to seed space: %w", err)
+	}
+
+	samples := make([]T, n)
+	for i := range samples {
+		sample, err := s.Sample(nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to draw sample %d: %w", i, err)
+		}
+		samples[i] = sample
+	}
+	return samples, nil
+}
@@ -0,0 +1,125 @@
+package space_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/rand"
+	"github.com/gocnn/gym/space"
+)
+
+// newRNG creates an RNG for a space under test, failing the test on error.
+func newRNG(tb testing.TB) *rand.RNG {
+	tb.Helper()
+
+	rng, _, err := rand.NewRNG(1)
+	if err != nil {
+		tb.Fatalf("NewRNG failed: %v", err)
+	}
+	return rng
+}
+
+// disableDefaultRNG disables the default RNG until the end of the test, so that constructing a space from it
+// fails as in strictrng builds.
+func disableDefaultRNG(t *testing.T) {
+	t.Helper()
+
+	disabled := rand.DefaultRNGDisabled()
+	rand.DisableDefaultRNG()
+	t.Cleanup(func() {
+		if !disabled {
+			rand.EnableDefaultRNG()
+		}
+	})
+}
+
+// checkSeedAndSample checks that two spaces created by newSpace sample identical sequences from the same seed,
+// different sequences from different seeds, and only elements they contain.
+func checkSeedAndSample[T any](t *testing.T, newSpace func() (gym.Space[T], error)) {
+	t.Helper()
+
+	sample := func(seed int64) []T {
+		t.Helper()
+
+		s, err := newSpace()
+		if err != nil {
+			t.Fatalf("failed to create space: %v", err)
+		}
+		samples, err := space.SeedAndSample(s, seed, 50)
+		if err != nil {
+			t.Fatalf("SeedAndSample failed: %v", err)
+		}
+		for i, x := range samples {
+			if !s.Contains(x) {
+				t.Fatalf("sample %d %v is not contained in the space", i, x)
+			}
+		}
+		return samples
+	}
+
+	first := sample(7)
+	if len(first) != 50 {
+		t.Fatalf("SeedAndSample returned %d samples, want 50", len(first))
+	}
+	if second := sample(7); !reflect.DeepEqual(first, second) {
+		t.Errorf("seed 7 gave %v, then %v", first, second)
+	}
+	if other := sample(8); reflect.DeepEqual(first, other) {
+		t.Errorf("seeds 7 and 8 gave the same samples %v", first)
+	}
+}
+
+func TestSeedAndSample(t *testing.T) {
+	t.Run("Discrete", func(t *testing.T) {
+		checkSeedAndSample(t, func() (gym.Space[int], error) {
+			return space.NewDiscreteWithRNG(newRNG(t), 5, -2)
+		})
+	})
+	t.Run("Box", func(t *testing.T) {
+		checkSeedAndSample(t, func() (gym.Space[[]float64], error) {
+			return space.NewBoxWithRNG(newRNG(t), -1.0, 1.0, []int{3})
+		})
+	})
+	t.Run("MultiBinary", func(t *testing.T) {
+		checkSeedAndSample(t, func() (gym.Space[[]int8], error) {
+			return space.NewMultiBinaryWithRNG(newRNG(t), 4)
+		})
+	})
+	t.Run("MultiDiscrete", func(t *testing.T) {
+		checkSeedAndSample(t, func() (gym.Space[[]int], error) {
+			return space.NewMultiDiscreteWithRNG(newRNG(t), []int{2, 3, 4})
+		})
+	})
+	t.Run("Text", func(t *testing.T) {
+		checkSeedAndSample(t, func() (gym.Space[string], error) {
+			return space.NewTextWithRNG(newRNG(t), 6, "abc")
+		})
+	})
+	t.Run("Tuple", func(t *testing.T) {
+		checkSeedAndSample(t, func() (gym.Space[[]any], error) {
+			discrete, err := space.NewDiscreteWithRNG(newRNG(t), 3)
+			if err != nil {
+				return nil, err
+			}
+			box, err := space.NewBoxWithRNG(newRNG(t), 0.0, 1.0, []int{2})
+			if err != nil {
+				return nil, err
+			}
+			return space.NewTuple(discrete, box)
+		})
+	})
+}
+
+func TestSeedAndSampleInvalidArguments(t *testing.T) {
+	s, err := space.NewDiscreteWithRNG(newRNG(t), 2)
+	if err != nil {
+		t.Fatalf("NewDiscreteWithRNG failed: %v", err)
+	}
+	if _, err := space.SeedAndSample[int](s, 0, 1); err == nil {
+		t.Error("SeedAndSample with seed 0 succeeded, expected an error")
+	}
+	if _, err := space.SeedAndSample[int](s, 1, -1); err == nil {
+		t.Error("SeedAndSample with n -1 succeeded, expected an error")
+	}
+}
@@ -0,0 +1,222 @@
+package space
+
+import (
+	"fmt"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/gocnn/gym/rand"
+)
+
+// defaultCharset is the charset of Text spaces constructed with an empty charset.
+const defaultCharset = "abcdefghijklmnopqrstuvwxyz "
+
+// Text represents a space of strings made of the characters of a charset.
+//
+// Elements of this space are strings of at most maxLength characters (runes), each of which is in the charset.
+//
+// Example:
+//   - Text(5) contains strings such as "hi", "a b c" and ""
+//   - Text(3, "01") contains strings such as "010" and "1"
+type Text struct {
+	maxLength int    // The maximum number of characters of each element
+	charset   []rune // The distinct characters allowed in each element, in order
+	rng       *rand.RNG
+}
+
+// NewText creates a new Text space.
+//
+// Parameters:
+//   - maxLength: The maximum number of characters of each element (must be positive)
+//   - charset: The characters allowed in each element, or "" for lowercase a-z and space
+//
+// Returns:
+//   - A new Text space
+//   - An error if maxLength is not positive, charset is not valid UTF-8 or the default RNG is disabled
+func NewText(maxLength int, charset string) (*Text, error) {
+	rng, err := newRNG()
+	if err != nil {
+		return nil, err
+	}
+	return NewTextWithRNG(rng, maxLength, charset)
+}
+
+// NewTextWithRNG creates a new Text space that samples from the given RNG.
+//
+// Parameters:
+//   - rng: The random number generator used for sampling
+//   - maxLength: The maximum number of characters of each element (must be positive)
+//   - charset: The characters allowed in each element, or "" for lowercase a-z and space
+//
+// Returns:
+//   - A new Text space
+//   - An error if rng is nil, maxLength is not positive or charset is not valid UTF-8
+func NewTextWithRNG(rng *rand.RNG, maxLength int, charset string) (*Text, error) {
+	if rng == nil {
+		return nil, fmt.Errorf("rng must not be nil")
+	}
+
+	if maxLength <= 0 {
+		return nil, fmt.Errorf("maxLength has to be positive, got %d", maxLength)
+	}
+
+	if charset == "" {
+		charset = defaultCharset
+	}
+	if !utf8.ValidString(charset) {
+		return nil, fmt.Errorf("charset must be valid UTF-8")
+	}
+
+	// Duplicate characters would make sampling non-uniform
+	var runes []rune
+	for _, r := range charset {
+		if !slices.Contains(runes, r) {
+			runes = append(runes, r)
+		}
+	}
+
+	return &Text{
+		maxLength: maxLength,
+		charset:   runes,
+		rng:       rng,
+	}, nil
+}
+
+// Sample generates a single random sample from this space.
+//
+// The length is drawn uniformly from [0, maxLength] and each character uniformly from the charset.
+//
+// Parameters:
+//   - mask: A mask for sampling values (currently not implemented)
+//   - probability: A probability mask for sampling values (currently not implemented)
+//
+// Returns:
+//   - A sampled string from the space
+//   - An error if sampling fails
+func (t *Text) Sample(mask any, probability any) (string, error) {
+	if mask != nil || probability != nil {
+		return "", fmt.Errorf("mask and probability sampling not yet implemented")
+	}
+
+	sample := make([]rune, t.rng.IntN(t.maxLength+1))
+	for i := range sample {
+		sample[i] = t.charset[t.rng.IntN(len(t.charset))]
+	}
+	return string(sample), nil
+}
+
+// Seed sets the pseudorandom number generator seed of this space.
+//
+// Parameters:
+//   - seed: The seed value for the space
+//
+// Returns:
+//   - The effective seed value used
+//   - An error if seeding fails
+func (t *Text) Seed(seed int64) (int64, error) {
+	return t.rng.Seed(seed)
+}
+
+// Contains returns true if x is a valid member of this space.
+//
+// Parameters:
+//   - x: The element to check for membership
+//
+// Returns:
+//   - true if x has at most maxLength characters and all of them are in the charset, false otherwise
+func (t *Text) Contains(x string) bool {
+	if !utf8.ValidString(x) || utf8.RuneCountInString(x) > t.maxLength {
+		return false
+	}
+
+	for _, r := range x {
+		if !slices.Contains(t.charset, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// Shape returns the shape of the space elements.
+//
+// Returns:
+//   - An empty slice, as strings have no shape
+func (t *Text) Shape() []int {
+	return []int{}
+}
+
+// DType returns the data type of the space elements.
+//
+// Returns:
+//   - "string" as the data type string
+func (t *Text) DType() string {
+	return "string"
+}
+
+// IsFlattenable returns true if this space can be flattened to a Box space.
+//
+// Returns:
+//   - false (text spaces have variable length elements)
+func (t *Text) IsFlattenable() bool {
+	return false
+}
+
+// ToJSONable converts a batch of samples from this space to a JSONable data type.
+//
+// Parameters:
+//   - samples: A slice of samples from this space
+//
+// Returns:
+//   - A slice of any type that can be marshaled to JSON
+//   - An error if conversion fails
+func (t *Text) ToJSONable(samples []string) ([]any, error) {
+	result := make([]any, len(samples))
+	for i, sample := range samples {
+		result[i] = sample
+	}
+	return result, nil
+}
+
+// FromJSONable converts a JSONable data type to a batch of samples from this space.
+//
+// Parameters:
+//   - json: A slice of any type that was previously created by ToJSONable
+//
+// Returns:
+//   - A slice of samples of type string
+//   - An error if conversion fails or the data is invalid for this space
+func (t *Text) FromJSONable(json []any) ([]string, error) {
+	result := make([]string, len(json))
+	for i, val := range json {
+		str, ok := val.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %T", val)
+		}
+		result[i] = str
+	}
+	return result, nil
+}
+
+// String returns a string representation of this space.
+//
+// Returns:
+//   - A string representation in the format "Text(maxLength, charset)"
+func (t *Text) String() string {
+	return fmt.Sprintf("Text(%d, %q)", t.maxLength, string(t.charset))
+}
+
+// MaxLength returns the maximum number of characters of each element.
+//
+// Returns:
+//   - The maximum length
+func (t *Text) MaxLength() int {
+	return t.maxLength
+}
+
+// Charset returns the distinct characters allowed in each element.
+//
+// Returns:
+//   - The charset as a string
+func (t *Text) Charset() string {
+	return string(t.charset)
+}
@@ -0,0 +1,84 @@
+package space_test
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/space"
+)
+
+var _ gym.Space[string] = (*space.Text)(nil)
+
+func TestTextSampleLength(t *testing.T) {
+	text, err := space.NewTextWithRNG(newRNG(t), 4, "")
+	if err != nil {
+		t.Fatalf("NewTextWithRNG failed: %v", err)
+	}
+	if text.Charset() != "abcdefghijklmnopqrstuvwxyz " {
+		t.Errorf("default charset = %q, want lowercase a-z and space", text.Charset())
+	}
+
+	// Every length from 0 to maxLength is drawn
+	lengths := make(map[int]bool)
+	for range 1000 {
+		x, err := text.Sample(nil, nil)
+		if err != nil {
+			t.Fatalf("Sample failed: %v", err)
+		}
+		if !text.Contains(x) {
+			t.Fatalf("sample %q is not contained in %v", x, text)
+		}
+		lengths[utf8.RuneCountInString(x)] = true
+	}
+	for n := 0; n <= 4; n++ {
+		if !lengths[n] {
+			t.Errorf("no sample of length %d in 1000 samples", n)
+		}
+	}
+	if len(lengths) != 5 {
+		t.Errorf("samples have lengths %v, want 0 to 4", lengths)
+	}
+
+	if _, err := text.Sample([]int8{1}, nil); err == nil {
+		t.Error("Sample with a mask succeeded, expected an error")
+	}
+}
+
+func TestTextContains(t *testing.T) {
+	text, err := space.NewTextWithRNG(newRNG(t), 3, "aéb")
+	if err != nil {
+		t.Fatalf("NewTextWithRNG failed: %v", err)
+	}
+
+	tests := []struct {
+		x    string
+		want bool
+	}{
+		{"", true},
+		{"a", true},
+		{"éba", true}, // 3 runes in 4 bytes
+		{"abab", false},
+		{"abc", false},
+		{"A", false},
+		{"a b", false},
+		{"\xff", false},
+	}
+	for _, tt := range tests {
+		if got := text.Contains(tt.x); got != tt.want {
+			t.Errorf("%v.Contains(%q) = %t, want %t", text, tt.x, got, tt.want)
+		}
+	}
+}
+
+func TestNewTextInvalid(t *testing.T) {
+	if _, err := space.NewTextWithRNG(newRNG(t), 0, "ab"); err == nil {
+		t.Error("NewTextWithRNG with maxLength 0 succeeded, expected an error")
+	}
+	if _, err := space.NewTextWithRNG(newRNG(t), 3, "a\xffb"); err == nil {
+		t.Error("NewTextWithRNG with an invalid UTF-8 charset succeeded, expected an error")
+	}
+	if _, err := space.NewTextWithRNG(nil, 3, "ab"); err == nil {
+		t.Error("NewTextWithRNG with a nil RNG succeeded, expected an error")
+	}
+}
@@ -0,0 +1,319 @@
+package space
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gocnn/gym/rand"
+)
+
+// subspace is the part of the space interface that does not depend on the element type.
+type subspace interface {
+	Seed(seed int64) (int64, error)
+	Shape() []int
+	DType() string
+	IsFlattenable() bool
+}
+
+// tupleMethods are the element-typed methods every subspace of a Tuple must have.
+var tupleMethods = []string{"Sample", "Contains", "ToJSONable", "FromJSONable"}
+
+// Tuple represents a tuple (more specifically: the cartesian product) of heterogeneous spaces.
+//
+// Elements of this space are slices with one element of each subspace, in order.
+// Because subspaces have different element types, they are stored as any and the element-typed
+// methods are called through reflection.
+//
+// Example:
+//   - Tuple(Discrete(2), Box(-1, 1, shape=[2])) contains elements such as [1, [0.5, -0.2]]
+type Tuple struct {
+	spaces []any
+}
+
+// NewTuple creates a new Tuple space.
+//
+// Parameters:
+//   - spaces: The subspaces, each implementing Space[T] for some element type T
+//
+// Returns:
+//   - A new Tuple space
+//   - An error if any subspace does not implement the space methods
+func NewTuple(spaces ...any) (*Tuple, error) {
+	for i, s := range spaces {
+		if _, ok := s.(subspace); !ok {
+			return nil, fmt.Errorf("subspace %d of type %T is not a space", i, s)
+		}
+		for _, name := range tupleMethods {
+			if !reflect.ValueOf(s).MethodByName(name).IsValid() {
+				return nil, fmt.Errorf("subspace %d of type %T has no %s method", i, s, name)
+			}
+		}
+	}
+
+	return &Tuple{
+		spaces: append([]any(nil), spaces...),
+	}, nil
+}
+
+// Sample generates a single random sample from this space.
+//
+// The sample is an ordered slice of samples from each subspace.
+//
+// Parameters:
+//   - mask: An optional []any with a mask for each subspace
+//   - probability: An optional []any with a probability mask for each subspace
+//
+// Returns:
+//   - A sampled element of the space
+//   - An error if a mask does not match the subspaces or a subspace fails to sample
+func (t *Tuple) Sample(mask any, probability any) ([]any, error) {
+	masks, err := t.splitMask("mask", mask)
+	if err != nil {
+		return nil, err
+	}
+	probabilities, err := t.splitMask("probability", probability)
+	if err != nil {
+		return nil, err
+	}
+
+	sample := make([]any, len(t.spaces))
+	for i, s := range t.spaces {
+		method := reflect.ValueOf(s).MethodByName("Sample")
+		out := method.Call([]reflect.Value{
+			valueOrZero(masks[i], method.Type().In(0)),
+			valueOrZero(probabilities[i], method.Type().In(1)),
+		})
+		if err, _ := out[1].Interface().(error); err != nil {
+			return nil, fmt.Errorf("failed to sample subspace %d: %w", i, err)
+		}
+		sample[i] = out[0].Interface()
+	}
+	return sample, nil
+}
+
+// splitMask returns the per-subspace masks of a mask given for the whole tuple.
+func (t *Tuple) splitMask(name string, mask any) ([]any, error) {
+	if mask == nil {
+		return make([]any, len(t.spaces)), nil
+	}
+
+	masks, ok := mask.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a []any with one entry per subspace, got %T", name, mask)
+	}
+	if len(masks) != len(t.spaces) {
+		return nil, fmt.Errorf("%s has %d entries, expected %d", name, len(masks), len(t.spaces))
+	}
+	return masks, nil
+}
+
+// valueOrZero returns the reflect value of x, or the zero value of typ if x is nil.
+func valueOrZero(x any, typ reflect.Type) reflect.Value {
+	if x == nil {
+		return reflect.Zero(typ)
+	}
+	return reflect.ValueOf(x)
+}
+
+// Seed sets the pseudorandom number generator seed of this space and of every subspace.
+//
+// The subspaces are seeded with distinct seeds spawned from the given seed by a rand.SeedSequence.
+//
+// Parameters:
+//   - seed: The seed value for the space
+//
+// Returns:
+//   - The effective seed value used
+//   - An error if seeding fails
+func (t *Tuple) Seed(seed int64) (int64, error) {
+	seq, effectiveSeed, err := rand.NewSeedSequence(seed)
+	if err != nil {
+		return 0, err
+	}
+
+	subseeds := seq.Spawn(len(t.spaces))
+	for i, s := range t.spaces {
+		if _, err := s.(subspace).Seed(subseeds[i]); err != nil {
+			return 0, fmt.Errorf("failed to seed subspace %d: %w", i, err)
+		}
+	}
+	return effectiveSeed, nil
+}
+
+// Contains returns true if x is a valid member of this space.
+//
+// Parameters:
+//   - x: The element to check for membership
+//
+// Returns:
+//   - true if x has one element per subspace and each element has the subspace's type and is contained in it
+func (t *Tuple) Contains(x []any) bool {
+	if len(x) != len(t.spaces) {
+		return false
+	}
+
+	for i, s := range t.spaces {
+		method := reflect.ValueOf(s).MethodByName("Contains")
+		if x[i] == nil || !reflect.TypeOf(x[i]).AssignableTo(method.Type().In(0)) {
+			return false
+		}
+		if !method.Call([]reflect.Value{reflect.ValueOf(x[i])})[0].Bool() {
+			return false
+		}
+	}
+	return true
+}
+
+// Shape returns the shape of the space elements.
+//
+// Tuple spaces don't have a well-defined shape, so this returns nil.
+//
+// Returns:
+//   - nil (tuple elements are heterogeneous)
+func (t *Tuple) Shape() []int {
+	return nil
+}
+
+// DType returns the data type of the space elements.
+//
+// Returns:
+//   - An empty string since tuple elements are heterogeneous
+func (t *Tuple) DType() string {
+	return ""
+}
+
+// IsFlattenable returns true if this space can be flattened to a Box space.
+//
+// Returns:
+//   - true if every subspace is flattenable, false otherwise
+func (t *Tuple) IsFlattenable() bool {
+	for _, s := range t.spaces {
+		if _, ok := s.(interface{ FlatDim() int }); !ok || !s.(subspace).IsFlattenable() {
+			return false
+		}
+	}
+	return true
+}
+
+// FlatDim returns the number of elements of a flattened sample of this space.
+//
+// The result is only meaningful if the space is flattenable.
+//
+// Returns:
+//   - The sum of the flattened dimensions of the subspaces
+func (t *Tuple) FlatDim() int {
+	dim := 0
+	for _, s := range t.spaces {
+		if f, ok := s.(interface{ FlatDim() int }); ok {
+			dim += f.FlatDim()
+		}
+	}
+	return dim
+}
+
+// ToJSONable converts a batch of samples from this space to a JSONable data type.
+//
+// Each sample is converted to a slice with the JSONable form of each element.
+//
+// Parameters:
+//   - samples: A slice of samples from this space
+//
+// Returns:
+//   - A slice of any type that can be marshaled to JSON
+//   - An error if a sample does not match the subspaces or conversion fails
+func (t *Tuple) ToJSONable(samples [][]any) ([]any, error) {
+	result := make([]any, len(samples))
+	for i, sample := range samples {
+		if len(sample) != len(t.spaces) {
+			return nil, fmt.Errorf("sample %d has %d elements, expected %d", i, len(sample), len(t.spaces))
+		}
+
+		converted := make([]any, len(t.spaces))
+		for j, s := range t.spaces {
+			method := reflect.ValueOf(s).MethodByName("ToJSONable")
+			batch := reflect.MakeSlice(method.Type().In(0), 1, 1)
+			if sample[j] == nil || !reflect.TypeOf(sample[j]).AssignableTo(batch.Type().Elem()) {
+				return nil, fmt.Errorf("element %d of sample %d has type %T, expected %s", j, i, sample[j], batch.Type().Elem())
+			}
+			batch.Index(0).Set(reflect.ValueOf(sample[j]))
+
+			out := method.Call([]reflect.Value{batch})
+			if err, _ := out[1].Interface().(error); err != nil {
+				return nil, fmt.Errorf("failed to convert element %d of sample %d: %w", j, i, err)
+			}
+			converted[j] = out[0].Interface().([]any)[0]
+		}
+		result[i] = converted
+	}
+	return result, nil
+}
+
+// FromJSONable converts a JSONable data type to a batch of samples from this space.
+//
+// Parameters:
+//   - json: A slice of any type that was previously created by ToJSONable
+//
+// Returns:
+//   - A slice of samples of type []any
+//   - An error if conversion fails or the data is invalid for this space
+func (t *Tuple) FromJSONable(json []any) ([][]any, error) {
+	result := make([][]any, len(json))
+	for i, val := range json {
+		elems, ok := val.([]any)
+		if !ok || len(elems) != len(t.spaces) {
+			return nil, fmt.Errorf("expected []any with %d elements, got %T", len(t.spaces), val)
+		}
+
+		sample := make([]any, len(t.spaces))
+		for j, s := range t.spaces {
+			method := reflect.ValueOf(s).MethodByName("FromJSONable")
+			out := method.Call([]reflect.Value{reflect.ValueOf([]any{elems[j]})})
+			if err, _ := out[1].Interface().(error); err != nil {
+				return nil, fmt.Errorf("failed to convert element %d of sample %d: %w", j, i, err)
+			}
+			sample[j] = out[0].Index(0).Interface()
+		}
+		result[i] = sample
+	}
+	return result, nil
+}
+
+// String returns a string representation of this space.
+//
+// Returns:
+//   - A string representation in the format "Tuple(space1, space2, ...)"
+func (t *Tuple) String() string {
+	parts := make([]string, len(t.spaces))
+	for i, s := range t.spaces {
+		parts[i] = fmt.Sprint(s)
+	}
+	return fmt.Sprintf("Tuple(%s)", strings.Join(parts, ", "))
+}
+
+// Len returns the number of subspaces.
+//
+// Returns:
+//   - The number of subspaces
+func (t *Tuple) Len() int {
+	return len(t.spaces)
+}
+
+// At returns the subspace at index i.
+//
+// Parameters:
+//   - i: The index of the subspace
+//
+// Returns:
+//   - The subspace, which implements Space[T] for its element type T
+func (t *Tuple) At(i int) any {
+	return t.spaces[i]
+}
+
+// Spaces returns the subspaces in order.
+//
+// Returns:
+//   - A copy of the slice of subspaces
+func (t *Tuple) Spaces() []any {
+	return append([]any(nil), t.spaces...)
+}
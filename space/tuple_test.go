@@ -0,0 +1,102 @@
+package space_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/space"
+)
+
+var _ gym.Space[[]any] = (*space.Tuple)(nil)
+
+// newTuple creates a Tuple of a Discrete(3) space and a Box in [-1, 1]^2.
+func newTuple(t *testing.T) *space.Tuple {
+	t.Helper()
+
+	d, err := space.NewDiscreteWithRNG(newRNG(t), 3)
+	if err != nil {
+		t.Fatalf("NewDiscreteWithRNG failed: %v", err)
+	}
+	b, err := space.NewBoxWithRNG(newRNG(t), []float64{-1, -1}, []float64{1, 1})
+	if err != nil {
+		t.Fatalf("NewBoxWithRNG failed: %v", err)
+	}
+	tuple, err := space.NewTuple(d, b)
+	if err != nil {
+		t.Fatalf("NewTuple failed: %v", err)
+	}
+	return tuple
+}
+
+func TestTupleJSONRoundTrip(t *testing.T) {
+	tuple := newTuple(t)
+	samples := make([][]any, 5)
+	for i := range samples {
+		sample, err := tuple.Sample(nil, nil)
+		if err != nil {
+			t.Fatalf("Sample failed: %v", err)
+		}
+		if !tuple.Contains(sample) {
+			t.Fatalf("sample %v is not contained in %v", sample, tuple)
+		}
+		samples[i] = sample
+	}
+
+	jsonable, err := tuple.ToJSONable(samples)
+	if err != nil {
+		t.Fatalf("ToJSONable failed: %v", err)
+	}
+	data, err := json.Marshal(jsonable)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var decoded []any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	restored, err := tuple.FromJSONable(decoded)
+	if err != nil {
+		t.Fatalf("FromJSONable failed: %v", err)
+	}
+	if !reflect.DeepEqual(restored, samples) {
+		t.Errorf("round trip through %s gave %v, want %v", data, restored, samples)
+	}
+}
+
+func TestTupleSeed(t *testing.T) {
+	checkSeedAndSample(t, func() (gym.Space[[]any], error) {
+		return newTuple(t), nil
+	})
+}
+
+func TestTupleContains(t *testing.T) {
+	tuple := newTuple(t)
+	tests := []struct {
+		name string
+		x    []any
+		want bool
+	}{
+		{"member", []any{2, []float64{0.5, -1}}, true},
+		{"out of bounds", []any{3, []float64{0, 0}}, false},
+		{"wrong type", []any{1.0, []float64{0, 0}}, false},
+		{"wrong length", []any{1}, false},
+		{"nil element", []any{nil, []float64{0, 0}}, false},
+	}
+	for _, tt := range tests {
+		if got := tuple.Contains(tt.x); got != tt.want {
+			t.Errorf("%s: Contains(%v) = %v, want %v", tt.name, tt.x, got, tt.want)
+		}
+	}
+}
+
+func TestNewTupleRejectsNonSpaces(t *testing.T) {
+	d, err := space.NewDiscreteWithRNG(newRNG(t), 3)
+	if err != nil {
+		t.Fatalf("NewDiscreteWithRNG failed: %v", err)
+	}
+	if _, err := space.NewTuple(d, 3); err == nil {
+		t.Error("NewTuple succeeded with an int subspace, expected an error")
+	}
+}
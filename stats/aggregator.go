@@ -0,0 +1,166 @@
+// Package stats provides rolling summaries of episode statistics.
+package stats
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+)
+
+// Aggregator summarizes the returns and lengths of the most recent episodes.
+//
+// It keeps a rolling window of episodes, dropping the oldest one when the window is full. The summaries
+// are computed over the episode returns, except for MeanLength. An Aggregator is safe for concurrent use,
+// e.g. by the workers of a vector environment.
+type Aggregator struct {
+	window  int       // Maximum number of episodes kept
+	returns []float64 // Episode returns, a ring buffer once full
+	lengths []int     // Episode lengths, aligned with returns
+	next    int       // Index of the oldest episode once the buffer is full
+	mutex   sync.RWMutex
+}
+
+// NewAggregator creates a new Aggregator.
+//
+// Parameters:
+//   - window: The number of most recent episodes summarized (must be positive)
+//
+// Returns:
+//   - A new Aggregator
+//   - An error if window is not positive
+func NewAggregator(window int) (*Aggregator, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("window must be positive, got %d", window)
+	}
+
+	return &Aggregator{
+		window:  window,
+		returns: make([]float64, 0, window),
+		lengths: make([]int, 0, window),
+	}, nil
+}
+
+// Add records a finished episode, dropping the oldest one if the window is full.
+//
+// Parameters:
+//   - episodeReturn: The return of the episode
+//   - length: The length of the episode in steps
+func (a *Aggregator) Add(episodeReturn float64, length int) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if len(a.returns) < a.window {
+		a.returns = append(a.returns, episodeReturn)
+		a.lengths = append(a.lengths, length)
+		return
+	}
+
+	a.returns[a.next] = episodeReturn
+	a.lengths[a.next] = length
+	a.next = (a.next + 1) % a.window
+}
+
+// Count returns the number of episodes in the window.
+func (a *Aggregator) Count() int {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	return len(a.returns)
+}
+
+// Mean returns the mean episode return, or NaN if no episode was added.
+func (a *Aggregator) Mean() float64 {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	return mean(a.returns)
+}
+
+// Std returns the population standard deviation of the episode returns, or NaN if no episode was added.
+func (a *Aggregator) Std() float64 {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	m := mean(a.returns)
+	sum := 0.0
+	for _, r := range a.returns {
+		sum += (r - m) * (r - m)
+	}
+	return math.Sqrt(sum / float64(len(a.returns)))
+}
+
+// Min returns the smallest episode return, or NaN if no episode was added.
+func (a *Aggregator) Min() float64 {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	if len(a.returns) == 0 {
+		return math.NaN()
+	}
+	return slices.Min(a.returns)
+}
+
+// Max returns the largest episode return, or NaN if no episode was added.
+func (a *Aggregator) Max() float64 {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	if len(a.returns) == 0 {
+		return math.NaN()
+	}
+	return slices.Max(a.returns)
+}
+
+// Percentile returns the p-th percentile of the episode returns.
+//
+// The percentile is linearly interpolated between the closest ranks, matching the default method of NumPy.
+//
+// Parameters:
+//   - p: The percentile, in [0, 100]
+//
+// Returns:
+//   - The percentile, or NaN if no episode was added or p is out of range
+func (a *Aggregator) Percentile(p float64) float64 {
+	a.mutex.RLock()
+	sorted := slices.Clone(a.returns)
+	a.mutex.RUnlock()
+
+	if len(sorted) == 0 || !(p >= 0 && p <= 100) {
+		return math.NaN()
+	}
+	slices.Sort(sorted)
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	if lower == len(sorted)-1 {
+		return sorted[lower]
+	}
+	frac := rank - float64(lower)
+	return sorted[lower] + frac*(sorted[lower+1]-sorted[lower])
+}
+
+// MeanLength returns the mean episode length, or NaN if no episode was added.
+func (a *Aggregator) MeanLength() float64 {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	if len(a.lengths) == 0 {
+		return math.NaN()
+	}
+	sum := 0
+	for _, l := range a.lengths {
+		sum += l
+	}
+	return float64(sum) / float64(len(a.lengths))
+}
+
+// mean returns the mean of values, or NaN if values is empty.
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
@@ -0,0 +1,89 @@
+package stats_test
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/gocnn/gym/stats"
+)
+
+// newAggregator creates an Aggregator keeping window episodes, failing the test on error.
+func newAggregator(t *testing.T, window int) *stats.Aggregator {
+	t.Helper()
+
+	a, err := stats.NewAggregator(window)
+	if err != nil {
+		t.Fatalf("NewAggregator failed: %v", err)
+	}
+	return a
+}
+
+func TestAggregatorPercentile(t *testing.T) {
+	a := newAggregator(t, 10)
+	for _, r := range []float64{7, 2, 10, 5, 1, 9, 4, 8, 3, 6} {
+		a.Add(r, 1)
+	}
+
+	// Expected values from numpy.percentile(range(1, 11), p)
+	for _, tc := range []struct{ p, want float64 }{
+		{0, 1},
+		{25, 3.25},
+		{50, 5.5},
+		{90, 9.1},
+		{100, 10},
+	} {
+		if got := a.Percentile(tc.p); math.Abs(got-tc.want) > 1e-12 {
+			t.Errorf("Percentile(%v) = %v, want %v", tc.p, got, tc.want)
+		}
+	}
+	for _, p := range []float64{-1, 101, math.NaN()} {
+		if got := a.Percentile(p); !math.IsNaN(got) {
+			t.Errorf("Percentile(%v) = %v, want NaN", p, got)
+		}
+	}
+
+	// Adding two more episodes drops 7 and 2, leaving 1, 3, 4, 5, 6, 8, 9, 10, 11, 12
+	a.Add(11, 1)
+	a.Add(12, 1)
+	if got := a.Percentile(50); got != 7 {
+		t.Errorf("Percentile(50) after the window rolled over = %v, want 7", got)
+	}
+	if got := a.Min(); got != 1 {
+		t.Errorf("Min() = %v, want 1", got)
+	}
+
+	if got := newAggregator(t, 3).Percentile(50); !math.IsNaN(got) {
+		t.Errorf("Percentile(50) of an empty Aggregator = %v, want NaN", got)
+	}
+}
+
+func TestAggregatorConcurrentAdd(t *testing.T) {
+	const workers, episodes = 8, 100
+
+	a := newAggregator(t, workers*episodes)
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range episodes {
+				a.Add(float64(w), i+1)
+				// Read while the other workers write
+				a.Percentile(50)
+				a.Std()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := a.Count(); got != workers*episodes {
+		t.Errorf("Count() = %d, want %d", got, workers*episodes)
+	}
+	if got, want := a.Mean(), float64(workers-1)/2; math.Abs(got-want) > 1e-12 {
+		t.Errorf("Mean() = %v, want %v", got, want)
+	}
+	if got, want := a.MeanLength(), float64(episodes+1)/2; math.Abs(got-want) > 1e-12 {
+		t.Errorf("MeanLength() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,394 @@
+package vector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/gocnn/gym"
+)
+
+// asyncCommand is a call sent to the worker goroutine of a sub-environment.
+type asyncCommand[Act any] struct {
+	reset  bool // Reset if true, Step otherwise
+	ctx    context.Context
+	seed   *int64
+	action Act
+}
+
+// asyncResult is the outcome of a call returned by the worker goroutine of a sub-environment.
+type asyncResult[Obs any] struct {
+	obs        Obs
+	reward     float64
+	terminated bool
+	truncated  bool
+	info       gym.Info
+	err        error
+}
+
+// asyncWorker runs a sub-environment in its own goroutine.
+type asyncWorker[Obs any, Act any] struct {
+	env      gym.Env[Obs, Act]
+	commands chan asyncCommand[Act]
+	results  chan asyncResult[Obs]
+	shared   []float64 // Region of the shared observation buffer of the sub-environment, nil if unused
+	closeErr error
+}
+
+// run executes commands until the command channel is closed, then closes the sub-environment.
+func (w *asyncWorker[Obs, Act]) run(wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	for cmd := range w.commands {
+		var res asyncResult[Obs]
+		if cmd.reset {
+			res.obs, res.info, res.err = resetEnv(cmd.ctx, w.env, cmd.seed)
+		} else {
+			res.obs, res.reward, res.terminated, res.truncated, res.info, res.err = stepAutoReset(cmd.ctx, w.env, cmd.action)
+		}
+		if w.shared != nil && res.err == nil {
+			res.err = w.share(&res)
+		}
+		w.results <- res
+	}
+	w.closeErr = w.env.Close()
+}
+
+// share copies the observation of res into the shared buffer and clears it from res.
+func (w *asyncWorker[Obs, Act]) share(res *asyncResult[Obs]) error {
+	obs := any(res.obs).([]float64)
+	if len(obs) != len(w.shared) {
+		return fmt.Errorf("observation has %d elements, expected %d", len(obs), len(w.shared))
+	}
+	copy(w.shared, obs)
+	var zero Obs
+	res.obs = zero
+	return nil
+}
+
+// AsyncVectorEnv runs multiple sub-environments in parallel, each in its own goroutine.
+//
+// It has the same batching and autoreset behavior as SyncVectorEnv. Each call waits for every
+// sub-environment or for the context to be done, whichever comes first. A call interrupted by its
+// context leaves the sub-environments in an inconsistent state, so the AsyncVectorEnv must be closed
+// afterwards. Close waits for every worker goroutine to exit.
+//
+// An AsyncVectorEnv is not safe for concurrent use by multiple goroutines.
+type AsyncVectorEnv[Obs any, Act any] struct {
+	workers []*asyncWorker[Obs, Act]
+	wg      sync.WaitGroup
+	closed  bool
+	broken  error // Error of the interrupted call, if any
+
+	shared    []float64 // Contiguous observations of every sub-environment in shared memory mode, nil otherwise
+	sharedObs []Obs     // Views of the observation of each sub-environment into shared
+
+	singleActionSpace      gym.Space[Act]
+	singleObservationSpace gym.Space[Obs]
+	actionSpace            gym.Space[[]Act]
+	observationSpace       gym.Space[[]Obs]
+}
+
+// AsyncOption configures an AsyncVectorEnv.
+type AsyncOption func(*asyncConfig)
+
+// asyncConfig holds the settings of AsyncOption.
+type asyncConfig struct {
+	sharedMemory bool // Whether observations are gathered in a shared buffer
+}
+
+// WithSharedMemory makes the workers write observations into a preallocated contiguous []float64 buffer
+// indexed by sub-environment instead of sending them over their result channels.
+//
+// It requires []float64 observations of a fixed size, given by the shape of the observation space. The
+// observations returned by Reset and Step are views into the buffer, which is also available from
+// ObservationBuffer: they are only valid until the next call and must be copied to be kept.
+func WithSharedMemory() AsyncOption {
+	return func(config *asyncConfig) {
+		config.sharedMemory = true
+	}
+}
+
+// NewAsyncVectorEnv creates a new AsyncVectorEnv and starts its worker goroutines.
+//
+// Parameters:
+//   - n: The number of sub-environments (must be positive)
+//   - factory: Creates the sub-environment with the given index
+//   - opts: Options such as WithSharedMemory
+//
+// Returns:
+//   - A new AsyncVectorEnv
+//   - An error if n is not positive, an option cannot be applied or a sub-environment could not be created
+func NewAsyncVectorEnv[Obs any, Act any](n int, factory func(index int) (gym.Env[Obs, Act], error), opts ...AsyncOption) (*AsyncVectorEnv[Obs, Act], error) {
+	var config asyncConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	envs, err := makeEnvs(n, factory)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &AsyncVectorEnv[Obs, Act]{
+		workers:                make([]*asyncWorker[Obs, Act], n),
+		singleActionSpace:      envs[0].ActionSpace(),
+		singleObservationSpace: envs[0].ObservationSpace(),
+		actionSpace:            newBatchSpace(envs[0].ActionSpace(), n),
+		observationSpace:       newBatchSpace(envs[0].ObservationSpace(), n),
+	}
+
+	dim := 0
+	if config.sharedMemory {
+		if dim, err = sharedDim(envs[0].ObservationSpace()); err != nil {
+			for _, env := range envs {
+				env.Close()
+			}
+			return nil, fmt.Errorf("failed to use shared memory: %w", err)
+		}
+		v.shared = make([]float64, n*dim)
+		v.sharedObs = make([]Obs, n)
+	}
+
+	for i, env := range envs {
+		// Buffered channels let workers finish an interrupted call without blocking
+		v.workers[i] = &asyncWorker[Obs, Act]{
+			env:      env,
+			commands: make(chan asyncCommand[Act], 1),
+			results:  make(chan asyncResult[Obs], 1),
+		}
+		if v.shared != nil {
+			region := v.shared[i*dim : (i+1)*dim : (i+1)*dim]
+			v.workers[i].shared = region
+			v.sharedObs[i] = any(region).(Obs)
+		}
+		v.wg.Add(1)
+		go v.workers[i].run(&v.wg)
+	}
+	return v, nil
+}
+
+// sharedDim returns the number of elements of an observation in the shared buffer, which requires a
+// []float64 observation space.
+func sharedDim[Obs any](obsSpace gym.Space[Obs]) (int, error) {
+	if _, ok := any(obsSpace).(gym.Space[[]float64]); !ok {
+		return 0, fmt.Errorf("observations must be []float64, got %s", reflect.TypeFor[Obs]())
+	}
+
+	dim := 1
+	for _, d := range obsSpace.Shape() {
+		dim *= d
+	}
+	if dim <= 0 {
+		return 0, fmt.Errorf("observation space must have a positive size, got shape %v", obsSpace.Shape())
+	}
+	return dim, nil
+}
+
+// call sends a command to every worker and gathers the results, or returns early if ctx is done.
+func (v *AsyncVectorEnv[Obs, Act]) call(ctx context.Context, commands []asyncCommand[Act]) ([]asyncResult[Obs], error) {
+	if v.closed {
+		return nil, fmt.Errorf("vector environment is closed")
+	}
+	if v.broken != nil {
+		return nil, fmt.Errorf("vector environment is unusable after an interrupted call: %w", v.broken)
+	}
+
+	for i, w := range v.workers {
+		w.commands <- commands[i]
+	}
+
+	results := make([]asyncResult[Obs], len(v.workers))
+	for i, w := range v.workers {
+		select {
+		case results[i] = <-w.results:
+		case <-ctx.Done():
+			v.broken = ctx.Err()
+			return nil, ctx.Err()
+		}
+	}
+	return results, nil
+}
+
+// Reset resets every sub-environment in parallel.
+//
+// Parameters:
+//   - ctx: The context of the call
+//   - seeds: The seed of each sub-environment, or nil to reset without reseeding
+//
+// Returns:
+//   - The initial observation of each sub-environment
+//   - The info of each sub-environment
+//   - An error if seeds does not match the number of sub-environments, a reset fails or ctx is done
+func (v *AsyncVectorEnv[Obs, Act]) Reset(ctx context.Context, seeds []int64) ([]Obs, []gym.Info, error) {
+	if seeds != nil && len(seeds) != len(v.workers) {
+		return nil, nil, fmt.Errorf("expected %d seeds, got %d", len(v.workers), len(seeds))
+	}
+
+	commands := make([]asyncCommand[Act], len(v.workers))
+	for i := range commands {
+		commands[i] = asyncCommand[Act]{reset: true, ctx: ctx}
+		if seeds != nil {
+			commands[i].seed = &seeds[i]
+		}
+	}
+
+	results, err := v.call(ctx, commands)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	observations := v.observations()
+	infos := make([]gym.Info, len(results))
+	for i, res := range results {
+		if res.err != nil {
+			return nil, nil, fmt.Errorf("failed to reset sub-environment %d: %w", i, res.err)
+		}
+		if v.shared == nil {
+			observations[i] = res.obs
+		}
+		infos[i] = res.info
+	}
+	return observations, infos, nil
+}
+
+// Step steps every sub-environment with its action in parallel, resetting the sub-environments whose episode ended.
+//
+// Parameters:
+//   - ctx: The context of the call
+//   - actions: The action of each sub-environment
+//
+// Returns:
+//   - The observation of each sub-environment, the initial observation of a new episode for reset ones
+//   - The reward of each sub-environment
+//   - Whether each sub-environment terminated
+//   - Whether each sub-environment was truncated
+//   - The info of each sub-environment
+//   - An error if actions does not match the number of sub-environments, a step fails or ctx is done
+func (v *AsyncVectorEnv[Obs, Act]) Step(ctx context.Context, actions []Act) ([]Obs, []float64, []bool, []bool, []gym.Info, error) {
+	if len(actions) != len(v.workers) {
+		return nil, nil, nil, nil, nil, fmt.Errorf("expected %d actions, got %d", len(v.workers), len(actions))
+	}
+
+	commands := make([]asyncCommand[Act], len(v.workers))
+	for i := range commands {
+		commands[i] = asyncCommand[Act]{ctx: ctx, action: actions[i]}
+	}
+
+	results, err := v.call(ctx, commands)
+	if err != nil {
+		return nil, nil, nil, nil, nil, err
+	}
+
+	observations := v.observations()
+	rewards := make([]float64, len(results))
+	terminations := make([]bool, len(results))
+	truncations := make([]bool, len(results))
+	infos := make([]gym.Info, len(results))
+	for i, res := range results {
+		if res.err != nil {
+			return nil, nil, nil, nil, nil, fmt.Errorf("failed to step sub-environment %d: %w", i, res.err)
+		}
+		if v.shared == nil {
+			observations[i] = res.obs
+		}
+		rewards[i] = res.reward
+		terminations[i] = res.terminated
+		truncations[i] = res.truncated
+		infos[i] = res.info
+	}
+	return observations, rewards, terminations, truncations, infos, nil
+}
+
+// observations returns the slice receiving the observations of a call, the views into the shared buffer in
+// shared memory mode.
+func (v *AsyncVectorEnv[Obs, Act]) observations() []Obs {
+	if v.shared != nil {
+		return v.sharedObs
+	}
+	return make([]Obs, len(v.workers))
+}
+
+// ObservationBuffer returns the contiguous buffer holding the observations of every sub-environment in shared
+// memory mode, the observation of sub-environment i being at [i*dim, (i+1)*dim) for observations of dim elements.
+//
+// The buffer is overwritten by every Reset and Step.
+//
+// Returns:
+//   - The shared observation buffer, or nil if the AsyncVectorEnv was not created with WithSharedMemory
+func (v *AsyncVectorEnv[Obs, Act]) ObservationBuffer() []float64 {
+	return v.shared
+}
+
+// Close stops the worker goroutines, closes every sub-environment and waits for the workers to exit.
+//
+// Workers finish their current call, if any, before exiting.
+func (v *AsyncVectorEnv[Obs, Act]) Close() error {
+	if v.closed {
+		return nil
+	}
+	v.closed = true
+
+	for _, w := range v.workers {
+		close(w.commands)
+	}
+	v.wg.Wait()
+
+	var errs []error
+	for i, w := range v.workers {
+		if w.closeErr != nil {
+			errs = append(errs, fmt.Errorf("failed to close sub-environment %d: %w", i, w.closeErr))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SampleActions draws n actions from the action spaces of the sub-environments, as SyncVectorEnv.SampleActions.
+//
+// Parameters:
+//   - n: The number of actions (must be positive)
+//
+// Returns:
+//   - The sampled actions
+//   - An error if n is not positive, the vector environment is closed or unusable, or sampling fails
+func (v *AsyncVectorEnv[Obs, Act]) SampleActions(n int) ([]Act, error) {
+	if v.closed {
+		return nil, fmt.Errorf("vector environment is closed")
+	}
+	if v.broken != nil {
+		return nil, fmt.Errorf("vector environment is unusable after an interrupted call: %w", v.broken)
+	}
+
+	envs := make([]gym.Env[Obs, Act], len(v.workers))
+	for i, w := range v.workers {
+		envs[i] = w.env
+	}
+	return sampleActions(envs, n)
+}
+
+// NumEnvs returns the number of sub-environments.
+func (v *AsyncVectorEnv[Obs, Act]) NumEnvs() int {
+	return len(v.workers)
+}
+
+// SingleActionSpace returns the action space of a single sub-environment.
+func (v *AsyncVectorEnv[Obs, Act]) SingleActionSpace() gym.Space[Act] {
+	return v.singleActionSpace
+}
+
+// SingleObservationSpace returns the observation space of a single sub-environment.
+func (v *AsyncVectorEnv[Obs, Act]) SingleObservationSpace() gym.Space[Obs] {
+	return v.singleObservationSpace
+}
+
+// ActionSpace returns the space of batched actions, with one action per sub-environment.
+func (v *AsyncVectorEnv[Obs, Act]) ActionSpace() gym.Space[[]Act] {
+	return v.actionSpace
+}
+
+// ObservationSpace returns the space of batched observations, with one observation per sub-environment.
+func (v *AsyncVectorEnv[Obs, Act]) ObservationSpace() gym.Space[[]Obs] {
+	return v.observationSpace
+}
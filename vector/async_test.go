@@ -0,0 +1,257 @@
+package vector_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/envs/classic"
+	"github.com/gocnn/gym/envs/toy"
+	"github.com/gocnn/gym/rand"
+	"github.com/gocnn/gym/space"
+	"github.com/gocnn/gym/vector"
+)
+
+// newCartPole creates a CartPole sub-environment for a vector environment.
+func newCartPole(int) (gym.Env[[]float64, int], error) {
+	return classic.NewCartPoleEnv(nil)
+}
+
+// blockingEnv is a stub environment whose Step blocks until release is closed, ignoring its context.
+type blockingEnv struct {
+	release     chan struct{}
+	rng         *rand.RNG
+	actionSpace *space.Discrete
+	obsSpace    *space.Box
+}
+
+func newBlockingEnv(release chan struct{}) (*blockingEnv, error) {
+	rng, _, err := rand.NewRNG(1)
+	if err != nil {
+		return nil, err
+	}
+	actionSpace, err := space.NewDiscreteWithRNG(rng.Derive(), 2)
+	if err != nil {
+		return nil, err
+	}
+	obsSpace, err := space.NewBoxWithRNG(rng.Derive(), []float64{0}, []float64{1})
+	if err != nil {
+		return nil, err
+	}
+	return &blockingEnv{release: release, rng: rng, actionSpace: actionSpace, obsSpace: obsSpace}, nil
+}
+
+func (e *blockingEnv) Step(context.Context, int) ([]float64, float64, bool, bool, gym.Info, error) {
+	<-e.release
+	return []float64{0}, 0, false, false, gym.Info{}, nil
+}
+
+func (e *blockingEnv) Reset(context.Context, *int64, gym.Info) ([]float64, gym.Info, error) {
+	return []float64{0}, gym.Info{}, nil
+}
+
+func (e *blockingEnv) Render() (gym.RenderFrame, error)       { return nil, nil }
+func (e *blockingEnv) Close() error                           { return nil }
+func (e *blockingEnv) ActionSpace() gym.Space[int]            { return e.actionSpace }
+func (e *blockingEnv) ObservationSpace() gym.Space[[]float64] { return e.obsSpace }
+func (e *blockingEnv) Metadata() gym.Metadata                 { return gym.Metadata{} }
+func (e *blockingEnv) Unwrapped() gym.Env[[]float64, int]     { return e }
+func (e *blockingEnv) GetRNG() *rand.RNG                      { return e.rng }
+
+// TestAsyncVectorEnvStepConcurrently steps many CartPole sub-environments in parallel; run with -race to
+// detect data races between the worker goroutines.
+func TestAsyncVectorEnvStepConcurrently(t *testing.T) {
+	const numEnvs, numSteps = 32, 200
+
+	v, err := vector.NewAsyncVectorEnv(numEnvs, newCartPole)
+	if err != nil {
+		t.Fatalf("NewAsyncVectorEnv failed: %v", err)
+	}
+	defer v.Close()
+
+	seeds := make([]int64, numEnvs)
+	for i := range seeds {
+		seeds[i] = int64(i + 1)
+	}
+	ctx := context.Background()
+	if _, _, err := v.Reset(ctx, seeds); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+
+	for step := range numSteps {
+		actions, err := v.SampleActions(numEnvs)
+		if err != nil {
+			t.Fatalf("SampleActions failed: %v", err)
+		}
+		obs, rewards, _, _, _, err := v.Step(ctx, actions)
+		if err != nil {
+			t.Fatalf("Step %d failed: %v", step, err)
+		}
+		if len(obs) != numEnvs || len(rewards) != numEnvs {
+			t.Fatalf("Step %d returned %d observations and %d rewards, expected %d", step, len(obs), len(rewards), numEnvs)
+		}
+		if !v.ObservationSpace().Contains(obs) {
+			t.Fatalf("Step %d returned observations outside the observation space: %v", step, obs)
+		}
+	}
+
+	if err := v.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+}
+
+// TestAsyncVectorEnvCancelMidStep checks that cancelling the context of a Step that is still running returns
+// promptly and leaves the vector environment unusable.
+func TestAsyncVectorEnvCancelMidStep(t *testing.T) {
+	release := make(chan struct{})
+	v, err := vector.NewAsyncVectorEnv(4, func(int) (gym.Env[[]float64, int], error) {
+		return newBlockingEnv(release)
+	})
+	if err != nil {
+		t.Fatalf("NewAsyncVectorEnv failed: %v", err)
+	}
+	defer func() {
+		close(release)
+		if err := v.Close(); err != nil {
+			t.Errorf("Close failed: %v", err)
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, _, _, _, _, err = v.Step(ctx, make([]int, 4))
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Step returned %v after cancellation, expected it to return promptly", elapsed)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Step returned %v, expected %v", err, context.Canceled)
+	}
+
+	if _, _, _, _, _, err := v.Step(context.Background(), make([]int, 4)); err == nil {
+		t.Error("Step after an interrupted call succeeded, expected an error")
+	}
+}
+
+// TestAsyncVectorEnvSharedMemory checks that the shared memory mode returns the same observations as the
+// channel mode, laid out contiguously in the observation buffer.
+func TestAsyncVectorEnvSharedMemory(t *testing.T) {
+	const numEnvs, numSteps = 8, 100
+
+	channel, err := vector.NewAsyncVectorEnv(numEnvs, newCartPole)
+	if err != nil {
+		t.Fatalf("NewAsyncVectorEnv failed: %v", err)
+	}
+	defer channel.Close()
+	shared, err := vector.NewAsyncVectorEnv(numEnvs, newCartPole, vector.WithSharedMemory())
+	if err != nil {
+		t.Fatalf("NewAsyncVectorEnv with shared memory failed: %v", err)
+	}
+	defer shared.Close()
+
+	if channel.ObservationBuffer() != nil {
+		t.Error("ObservationBuffer() is non-nil without shared memory")
+	}
+	if got := len(shared.ObservationBuffer()); got != numEnvs*4 {
+		t.Fatalf("ObservationBuffer() has %d elements, want %d", got, numEnvs*4)
+	}
+
+	seeds := make([]int64, numEnvs)
+	for i := range seeds {
+		seeds[i] = int64(i + 1)
+	}
+	ctx := context.Background()
+	want, _, err := channel.Reset(ctx, seeds)
+	if err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	got, _, err := shared.Reset(ctx, seeds)
+	if err != nil {
+		t.Fatalf("Reset with shared memory failed: %v", err)
+	}
+	checkSharedObservations(t, shared, got, want)
+
+	for range numSteps {
+		actions, err := channel.SampleActions(numEnvs)
+		if err != nil {
+			t.Fatalf("SampleActions failed: %v", err)
+		}
+		want, _, _, _, _, err := channel.Step(ctx, actions)
+		if err != nil {
+			t.Fatalf("Step failed: %v", err)
+		}
+		got, _, _, _, _, err := shared.Step(ctx, actions)
+		if err != nil {
+			t.Fatalf("Step with shared memory failed: %v", err)
+		}
+		checkSharedObservations(t, shared, got, want)
+	}
+}
+
+// checkSharedObservations checks that the observations of the shared memory mode equal want, both as returned
+// and in the observation buffer.
+func checkSharedObservations(t *testing.T, v *vector.AsyncVectorEnv[[]float64, int], got, want [][]float64) {
+	t.Helper()
+
+	buffer := v.ObservationBuffer()
+	for i := range want {
+		if !slices.Equal(got[i], want[i]) {
+			t.Fatalf("observation %d = %v, want %v", i, got[i], want[i])
+		}
+		if region := buffer[i*4 : (i+1)*4]; !slices.Equal(region, want[i]) {
+			t.Fatalf("buffer region %d = %v, want %v", i, region, want[i])
+		}
+	}
+}
+
+func TestAsyncVectorEnvSharedMemoryRequiresFloatObservations(t *testing.T) {
+	_, err := vector.NewAsyncVectorEnv(2, func(int) (gym.Env[int, int], error) {
+		return toy.NewTaxiEnv(nil)
+	}, vector.WithSharedMemory())
+	if err == nil {
+		t.Fatal("NewAsyncVectorEnv with shared memory succeeded for int observations, expected an error")
+	}
+}
+
+// BenchmarkAsyncVectorEnvStep compares gathering the observations of 64 CartPole sub-environments over the
+// result channels with writing them into the shared buffer.
+func BenchmarkAsyncVectorEnvStep(b *testing.B) {
+	const numEnvs = 64
+
+	modes := []struct {
+		name string
+		opts []vector.AsyncOption
+	}{
+		{"channel", nil},
+		{"shared", []vector.AsyncOption{vector.WithSharedMemory()}},
+	}
+	for _, mode := range modes {
+		b.Run(mode.name, func(b *testing.B) {
+			v, err := vector.NewAsyncVectorEnv(numEnvs, newCartPole, mode.opts...)
+			if err != nil {
+				b.Fatalf("NewAsyncVectorEnv failed: %v", err)
+			}
+			defer v.Close()
+
+			ctx := context.Background()
+			if _, _, err := v.Reset(ctx, nil); err != nil {
+				b.Fatalf("Reset failed: %v", err)
+			}
+			actions, err := v.SampleActions(numEnvs)
+			if err != nil {
+				b.Fatalf("SampleActions failed: %v", err)
+			}
+
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, _, _, _, _, err := v.Step(ctx, actions); err != nil {
+					b.Fatalf("Step failed: %v", err)
+				}
+			}
+		})
+	}
+}
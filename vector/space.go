@@ -0,0 +1,140 @@
+package vector
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/rand"
+)
+
+// batchSpace is the space of batches of n elements of a single space.
+//
+// Elements are slices with one element of the single space per sub-environment.
+type batchSpace[T any] struct {
+	single gym.Space[T]
+	n      int
+}
+
+// newBatchSpace creates the space of batches of n elements of a single space.
+func newBatchSpace[T any](single gym.Space[T], n int) *batchSpace[T] {
+	return &batchSpace[T]{single: single, n: n}
+}
+
+// Sample generates a batch of independent samples of the single space.
+//
+// The mask and probability, if provided, must be []any with one entry per element of the batch.
+func (b *batchSpace[T]) Sample(mask any, probability any) ([]T, error) {
+	masks, err := b.split("mask", mask)
+	if err != nil {
+		return nil, err
+	}
+	probabilities, err := b.split("probability", probability)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := make([]T, b.n)
+	for i := range batch {
+		sample, err := b.single.Sample(masks[i], probabilities[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to sample element %d: %w", i, err)
+		}
+		batch[i] = sample
+	}
+	return batch, nil
+}
+
+// split returns the per-element masks of a mask given for the whole batch.
+func (b *batchSpace[T]) split(name string, mask any) ([]any, error) {
+	if mask == nil {
+		return make([]any, b.n), nil
+	}
+
+	masks, ok := mask.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a []any with one entry per element, got %T", name, mask)
+	}
+	if len(masks) != b.n {
+		return nil, fmt.Errorf("%s has %d entries, expected %d", name, len(masks), b.n)
+	}
+	return masks, nil
+}
+
+// Seed seeds the single space with a seed derived deterministically from the given seed.
+func (b *batchSpace[T]) Seed(seed int64) (int64, error) {
+	rng, effectiveSeed, err := rand.NewRNG(seed)
+	if err != nil {
+		return 0, err
+	}
+
+	// Derived seeds are positive since a zero seed means a random seed
+	if _, err := b.single.Seed(rng.Int64N(math.MaxInt32) + 1); err != nil {
+		return 0, err
+	}
+	return effectiveSeed, nil
+}
+
+// Contains returns true if x has n elements that are all members of the single space.
+func (b *batchSpace[T]) Contains(x []T) bool {
+	if len(x) != b.n {
+		return false
+	}
+
+	for _, elem := range x {
+		if !b.single.Contains(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+// Shape returns the shape of the single space with a leading batch dimension.
+func (b *batchSpace[T]) Shape() []int {
+	return append([]int{b.n}, b.single.Shape()...)
+}
+
+// DType returns the data type of the single space.
+func (b *batchSpace[T]) DType() string {
+	return b.single.DType()
+}
+
+// IsFlattenable returns true if the single space is flattenable.
+func (b *batchSpace[T]) IsFlattenable() bool {
+	return b.single.IsFlattenable()
+}
+
+// ToJSONable converts batches to slices of the JSONable elements of the single space.
+func (b *batchSpace[T]) ToJSONable(samples [][]T) ([]any, error) {
+	result := make([]any, len(samples))
+	for i, sample := range samples {
+		converted, err := b.single.ToJSONable(sample)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert batch %d: %w", i, err)
+		}
+		result[i] = converted
+	}
+	return result, nil
+}
+
+// FromJSONable converts slices of JSONable elements of the single space to batches.
+func (b *batchSpace[T]) FromJSONable(json []any) ([][]T, error) {
+	result := make([][]T, len(json))
+	for i, val := range json {
+		elems, ok := val.([]any)
+		if !ok {
+			return nil, fmt.Errorf("expected []any, got %T", val)
+		}
+		batch, err := b.single.FromJSONable(elems)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert batch %d: %w", i, err)
+		}
+		result[i] = batch
+	}
+	return result, nil
+}
+
+// String returns a string representation of this space.
+func (b *batchSpace[T]) String() string {
+	return fmt.Sprintf("Batch(%v, n=%d)", b.single, b.n)
+}
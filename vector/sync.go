@@ -0,0 +1,266 @@
+package vector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gocnn/gym"
+)
+
+// SyncVectorEnv runs multiple sub-environments sequentially in the calling goroutine.
+//
+// Observations, rewards, terminations, truncations and infos are returned as batches with one entry per
+// sub-environment. Sub-environments that terminate or truncate are reset automatically within the same Step:
+// the returned observation and info are those of the new episode, and the info contains the last observation
+// and info of the finished episode under the keys "final_observation" and "final_info".
+type SyncVectorEnv[Obs any, Act any] struct {
+	envs        []gym.Env[Obs, Act]
+	stepTimeout time.Duration // Deadline of each sub-step, or 0 for none
+
+	singleActionSpace      gym.Space[Act]
+	singleObservationSpace gym.Space[Obs]
+	actionSpace            gym.Space[[]Act]
+	observationSpace       gym.Space[[]Obs]
+}
+
+// SyncOption configures a SyncVectorEnv.
+type SyncOption func(*syncConfig)
+
+// syncConfig holds the settings of SyncOption.
+type syncConfig struct {
+	stepTimeout time.Duration // Deadline of each sub-step, or 0 for none
+}
+
+// WithStepTimeout bounds the time each sub-environment may take to step.
+//
+// Every sub-step, including the automatic reset at the end of an episode, runs with a context derived by
+// context.WithTimeout. A sub-environment whose step fails with the deadline does not fail the batch: its
+// observation, reward and flags are zero values and its info holds the error under the key "error". The
+// sub-environment is not reset, so its state is whatever the interrupted step left behind. The deadline is
+// only effective for environments that honor context cancellation, as the sub-steps run sequentially.
+func WithStepTimeout(timeout time.Duration) SyncOption {
+	return func(config *syncConfig) {
+		config.stepTimeout = timeout
+	}
+}
+
+// NewSyncVectorEnv creates a new SyncVectorEnv.
+//
+// Parameters:
+//   - n: The number of sub-environments (must be positive)
+//   - factory: Creates the sub-environment with the given index
+//   - opts: Options such as WithStepTimeout
+//
+// Returns:
+//   - A new SyncVectorEnv
+//   - An error if n is not positive, an option is invalid or a sub-environment could not be created
+func NewSyncVectorEnv[Obs any, Act any](n int, factory func(index int) (gym.Env[Obs, Act], error), opts ...SyncOption) (*SyncVectorEnv[Obs, Act], error) {
+	var config syncConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+	if config.stepTimeout < 0 {
+		return nil, fmt.Errorf("step timeout must be non-negative, got %v", config.stepTimeout)
+	}
+
+	envs, err := makeEnvs(n, factory)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SyncVectorEnv[Obs, Act]{
+		envs:                   envs,
+		stepTimeout:            config.stepTimeout,
+		singleActionSpace:      envs[0].ActionSpace(),
+		singleObservationSpace: envs[0].ObservationSpace(),
+		actionSpace:            newBatchSpace(envs[0].ActionSpace(), n),
+		observationSpace:       newBatchSpace(envs[0].ObservationSpace(), n),
+	}, nil
+}
+
+// makeEnvs creates n sub-environments, closing the created ones if any fails.
+func makeEnvs[Obs any, Act any](n int, factory func(index int) (gym.Env[Obs, Act], error)) ([]gym.Env[Obs, Act], error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("number of environments must be positive, got %d", n)
+	}
+	if factory == nil {
+		return nil, fmt.Errorf("factory must not be nil")
+	}
+
+	envs := make([]gym.Env[Obs, Act], 0, n)
+	for i := range n {
+		env, err := factory(i)
+		if err == nil && env == nil {
+			err = fmt.Errorf("factory returned a nil environment")
+		}
+		if err != nil {
+			for _, created := range envs {
+				created.Close()
+			}
+			return nil, fmt.Errorf("failed to create sub-environment %d: %w", i, err)
+		}
+		envs = append(envs, env)
+	}
+	return envs, nil
+}
+
+// Reset resets every sub-environment.
+//
+// Parameters:
+//   - ctx: The context of the call
+//   - seeds: The seed of each sub-environment, or nil to reset without reseeding
+//
+// Returns:
+//   - The initial observation of each sub-environment
+//   - The info of each sub-environment
+//   - An error if seeds does not match the number of sub-environments or a reset fails
+func (v *SyncVectorEnv[Obs, Act]) Reset(ctx context.Context, seeds []int64) ([]Obs, []gym.Info, error) {
+	if seeds != nil && len(seeds) != len(v.envs) {
+		return nil, nil, fmt.Errorf("expected %d seeds, got %d", len(v.envs), len(seeds))
+	}
+
+	observations := make([]Obs, len(v.envs))
+	infos := make([]gym.Info, len(v.envs))
+	for i, env := range v.envs {
+		var seed *int64
+		if seeds != nil {
+			seed = &seeds[i]
+		}
+
+		obs, info, err := resetEnv(ctx, env, seed)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to reset sub-environment %d: %w", i, err)
+		}
+		observations[i] = obs
+		infos[i] = info
+	}
+	return observations, infos, nil
+}
+
+// Step steps every sub-environment with its action, resetting the sub-environments whose episode ended.
+//
+// Parameters:
+//   - ctx: The context of the call
+//   - actions: The action of each sub-environment
+//
+// Returns:
+//   - The observation of each sub-environment, the initial observation of a new episode for reset ones
+//   - The reward of each sub-environment
+//   - Whether each sub-environment terminated
+//   - Whether each sub-environment was truncated
+//   - The info of each sub-environment, holding the error of a sub-step that exceeded the step timeout
+//   - An error if actions does not match the number of sub-environments or a step fails
+func (v *SyncVectorEnv[Obs, Act]) Step(ctx context.Context, actions []Act) ([]Obs, []float64, []bool, []bool, []gym.Info, error) {
+	if len(actions) != len(v.envs) {
+		return nil, nil, nil, nil, nil, fmt.Errorf("expected %d actions, got %d", len(v.envs), len(actions))
+	}
+
+	observations := make([]Obs, len(v.envs))
+	rewards := make([]float64, len(v.envs))
+	terminations := make([]bool, len(v.envs))
+	truncations := make([]bool, len(v.envs))
+	infos := make([]gym.Info, len(v.envs))
+	for i, env := range v.envs {
+		obs, reward, terminated, truncated, info, err := v.step(ctx, env, actions[i])
+		if err != nil && errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			infos[i] = gym.Info{"error": fmt.Errorf("sub-environment %d exceeded the step timeout: %w", i, err)}
+			continue
+		}
+		if err != nil {
+			return nil, nil, nil, nil, nil, fmt.Errorf("failed to step sub-environment %d: %w", i, err)
+		}
+		observations[i] = obs
+		rewards[i] = reward
+		terminations[i] = terminated
+		truncations[i] = truncated
+		infos[i] = info
+	}
+	return observations, rewards, terminations, truncations, infos, nil
+}
+
+// step steps env with stepAutoReset, under the step timeout if one is set.
+func (v *SyncVectorEnv[Obs, Act]) step(ctx context.Context, env gym.Env[Obs, Act], action Act) (Obs, float64, bool, bool, gym.Info, error) {
+	if v.stepTimeout == 0 {
+		return stepAutoReset(ctx, env, action)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, v.stepTimeout)
+	defer cancel()
+	return stepAutoReset(ctx, env, action)
+}
+
+// stepAutoReset steps env and resets it if the episode ended, recording the final observation and info.
+func stepAutoReset[Obs any, Act any](ctx context.Context, env gym.Env[Obs, Act], action Act) (Obs, float64, bool, bool, gym.Info, error) {
+	obs, reward, terminated, truncated, info, err := env.Step(ctx, action)
+	if err != nil || !(terminated || truncated) {
+		return obs, reward, terminated, truncated, info, err
+	}
+
+	resetObs, resetInfo, err := env.Reset(ctx, nil, nil)
+	if err != nil {
+		return obs, reward, terminated, truncated, info, fmt.Errorf("failed to reset after episode end: %w", err)
+	}
+
+	finalInfo := gym.Info{}
+	for key, val := range resetInfo {
+		finalInfo[key] = val
+	}
+	finalInfo["final_observation"] = obs
+	finalInfo["final_info"] = info
+	return resetObs, reward, terminated, truncated, finalInfo, nil
+}
+
+// Close closes every sub-environment.
+func (v *SyncVectorEnv[Obs, Act]) Close() error {
+	var errs []error
+	for i, env := range v.envs {
+		if err := env.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close sub-environment %d: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SampleActions draws n actions from the action spaces of the sub-environments.
+//
+// Action i is sampled from the action space of sub-environment i % NumEnvs(), so n = NumEnvs() gives one
+// action per sub-environment and larger multiples give actions for several steps. Each sub-environment samples
+// from its own stream, which Reset seeds from the seed of the sub-environment, so the actions are reproducible
+// and independent of the other sub-environments.
+//
+// Parameters:
+//   - n: The number of actions (must be positive)
+//
+// Returns:
+//   - The sampled actions
+//   - An error if n is not positive or sampling fails
+func (v *SyncVectorEnv[Obs, Act]) SampleActions(n int) ([]Act, error) {
+	return sampleActions(v.envs, n)
+}
+
+// NumEnvs returns the number of sub-environments.
+func (v *SyncVectorEnv[Obs, Act]) NumEnvs() int {
+	return len(v.envs)
+}
+
+// SingleActionSpace returns the action space of a single sub-environment.
+func (v *SyncVectorEnv[Obs, Act]) SingleActionSpace() gym.Space[Act] {
+	return v.singleActionSpace
+}
+
+// SingleObservationSpace returns the observation space of a single sub-environment.
+func (v *SyncVectorEnv[Obs, Act]) SingleObservationSpace() gym.Space[Obs] {
+	return v.singleObservationSpace
+}
+
+// ActionSpace returns the space of batched actions, with one action per sub-environment.
+func (v *SyncVectorEnv[Obs, Act]) ActionSpace() gym.Space[[]Act] {
+	return v.actionSpace
+}
+
+// ObservationSpace returns the space of batched observations, with one observation per sub-environment.
+func (v *SyncVectorEnv[Obs, Act]) ObservationSpace() gym.Space[[]Obs] {
+	return v.observationSpace
+}
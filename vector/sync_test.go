@@ -0,0 +1,188 @@
+package vector_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/vector"
+)
+
+func TestSyncVectorEnvStepsIndependently(t *testing.T) {
+	const numEnvs = 3
+	v, err := vector.NewSyncVectorEnv(numEnvs, newCartPole)
+	if err != nil {
+		t.Fatalf("NewSyncVectorEnv failed: %v", err)
+	}
+	defer v.Close()
+
+	// Each sub-environment must behave as a standalone CartPole reset with its seed and given its actions
+	ctx := context.Background()
+	seeds := []int64{1, 2, 3}
+	observations, _, err := v.Reset(ctx, seeds)
+	if err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	refs := make([]gym.Env[[]float64, int], numEnvs)
+	for i := range refs {
+		ref, err := newCartPole(i)
+		if err != nil {
+			t.Fatalf("NewCartPoleEnv failed: %v", err)
+		}
+		defer ref.Close()
+		obs, _, err := ref.Reset(ctx, &seeds[i], nil)
+		if err != nil {
+			t.Fatalf("Reset failed: %v", err)
+		}
+		if !slices.Equal(observations[i], obs) {
+			t.Errorf("initial observation %d = %v, want %v", i, observations[i], obs)
+		}
+		refs[i] = ref
+	}
+	if slices.Equal(observations[0], observations[1]) || slices.Equal(observations[1], observations[2]) {
+		t.Errorf("sub-environments reset with distinct seeds observed %v", observations)
+	}
+
+	resets := 0
+	for step := range 50 {
+		actions := []int{step % 2, 0, 1}
+		observations, rewards, terminations, truncations, infos, err := v.Step(ctx, actions)
+		if err != nil {
+			t.Fatalf("Step failed: %v", err)
+		}
+
+		for i, ref := range refs {
+			obs, reward, terminated, truncated, _, err := ref.Step(ctx, actions[i])
+			if err != nil {
+				t.Fatalf("Step failed: %v", err)
+			}
+			if rewards[i] != reward || terminations[i] != terminated || truncations[i] != truncated {
+				t.Errorf("step %d of sub-environment %d returned %v, %t, %t, want %v, %t, %t",
+					step, i, rewards[i], terminations[i], truncations[i], reward, terminated, truncated)
+			}
+
+			if terminated || truncated {
+				resets++
+				if final, _ := infos[i]["final_observation"].([]float64); !slices.Equal(final, obs) {
+					t.Errorf("final observation of sub-environment %d = %v, want %v", i, final, obs)
+				}
+				if obs, _, err = ref.Reset(ctx, nil, nil); err != nil {
+					t.Fatalf("Reset failed: %v", err)
+				}
+			}
+			if !slices.Equal(observations[i], obs) {
+				t.Errorf("observation %d of sub-environment %d = %v, want %v", step, i, observations[i], obs)
+			}
+		}
+	}
+	if resets == 0 {
+		t.Error("no sub-environment ended its episode, expected the constant actions to end some")
+	}
+}
+
+func TestSyncVectorEnvSeededResetIsReproducible(t *testing.T) {
+	reset := func(seeds []int64) [][]float64 {
+		t.Helper()
+
+		v, err := vector.NewSyncVectorEnv(3, newCartPole)
+		if err != nil {
+			t.Fatalf("NewSyncVectorEnv failed: %v", err)
+		}
+		defer v.Close()
+		observations, _, err := v.Reset(context.Background(), seeds)
+		if err != nil {
+			t.Fatalf("Reset failed: %v", err)
+		}
+		return observations
+	}
+
+	first := reset([]int64{1, 2, 3})
+	second := reset([]int64{1, 2, 3})
+	other := reset([]int64{1, 5, 3})
+	for i := range first {
+		if !slices.Equal(first[i], second[i]) {
+			t.Errorf("sub-environment %d observed %v and %v after resets with the same seed", i, first[i], second[i])
+		}
+		if changed := !slices.Equal(first[i], other[i]); changed != (i == 1) {
+			t.Errorf("reseeding sub-environment 1 changed the observation of sub-environment %d: %t", i, changed)
+		}
+	}
+
+	v, err := vector.NewSyncVectorEnv(3, newCartPole)
+	if err != nil {
+		t.Fatalf("NewSyncVectorEnv failed: %v", err)
+	}
+	defer v.Close()
+	if _, _, err := v.Reset(context.Background(), []int64{1, 2}); err == nil {
+		t.Error("Reset with 2 seeds for 3 sub-environments succeeded, expected an error")
+	}
+}
+
+// sleepingEnv is a blockingEnv whose Step takes delay to return the observation [1] with a reward of 1, or
+// fails with the error of its context if that is done first.
+type sleepingEnv struct {
+	*blockingEnv
+	delay time.Duration
+}
+
+func (e *sleepingEnv) Step(ctx context.Context, _ int) ([]float64, float64, bool, bool, gym.Info, error) {
+	select {
+	case <-time.After(e.delay):
+		return []float64{1}, 1, false, false, gym.Info{}, nil
+	case <-ctx.Done():
+		return nil, 0, false, false, nil, ctx.Err()
+	}
+}
+
+func TestSyncVectorEnvStepTimeout(t *testing.T) {
+	const slow = 1
+	v, err := vector.NewSyncVectorEnv(3, func(index int) (gym.Env[[]float64, int], error) {
+		env, err := newBlockingEnv(nil)
+		if err != nil {
+			return nil, err
+		}
+		if index == slow {
+			return &sleepingEnv{blockingEnv: env, delay: time.Minute}, nil
+		}
+		return &sleepingEnv{blockingEnv: env}, nil
+	}, vector.WithStepTimeout(20*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewSyncVectorEnv failed: %v", err)
+	}
+	defer v.Close()
+
+	start := time.Now()
+	observations, rewards, _, _, infos, err := v.Step(context.Background(), []int{0, 0, 0})
+	if err != nil {
+		t.Fatalf("Step failed: %v, want the timeout reported in the info", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Step took %v despite the step timeout", elapsed)
+	}
+
+	for i := range observations {
+		if i == slow {
+			continue
+		}
+		if !slices.Equal(observations[i], []float64{1}) || rewards[i] != 1 || infos[i]["error"] != nil {
+			t.Errorf("sub-environment %d returned %v, %f, %v, want [1], 1 and no error", i, observations[i], rewards[i], infos[i])
+		}
+	}
+
+	stepErr, ok := infos[slow]["error"].(error)
+	if !ok || !errors.Is(stepErr, context.DeadlineExceeded) {
+		t.Fatalf("info of the slow sub-environment = %v, want a deadline error under \"error\"", infos[slow])
+	}
+	if observations[slow] != nil || rewards[slow] != 0 {
+		t.Errorf("slow sub-environment returned %v, %f, want zero values", observations[slow], rewards[slow])
+	}
+}
+
+func TestSyncVectorEnvRejectsNegativeStepTimeout(t *testing.T) {
+	if _, err := vector.NewSyncVectorEnv(1, newCartPole, vector.WithStepTimeout(-time.Second)); err == nil {
+		t.Error("NewSyncVectorEnv with a negative step timeout succeeded, expected an error")
+	}
+}
@@ -0,0 +1,84 @@
+// Package vector provides vectorized environments that run multiple sub-environments in lockstep.
+package vector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/rand"
+)
+
+// VectorEnv is the interface of environments that run multiple independent copies of an environment.
+//
+// Observations, rewards, terminations, truncations and infos are batched with one entry per
+// sub-environment, and sub-environments whose episode ended are reset automatically during Step.
+// SyncVectorEnv and AsyncVectorEnv implement this interface, so agents can target either.
+type VectorEnv[Obs any, Act any] interface {
+	// Reset resets every sub-environment, reseeding each with its seed unless seeds is nil.
+	Reset(ctx context.Context, seeds []int64) ([]Obs, []gym.Info, error)
+
+	// Step steps every sub-environment with its action.
+	Step(ctx context.Context, actions []Act) ([]Obs, []float64, []bool, []bool, []gym.Info, error)
+
+	// Close closes every sub-environment.
+	Close() error
+
+	// NumEnvs returns the number of sub-environments.
+	NumEnvs() int
+
+	// SingleActionSpace returns the action space of a single sub-environment.
+	SingleActionSpace() gym.Space[Act]
+
+	// SingleObservationSpace returns the observation space of a single sub-environment.
+	SingleObservationSpace() gym.Space[Obs]
+
+	// ActionSpace returns the space of batched actions, with one action per sub-environment.
+	ActionSpace() gym.Space[[]Act]
+
+	// ObservationSpace returns the space of batched observations, with one observation per sub-environment.
+	ObservationSpace() gym.Space[[]Obs]
+
+	// SampleActions draws n actions, action i from the action space of sub-environment i % NumEnvs().
+	SampleActions(n int) ([]Act, error)
+}
+
+var (
+	_ VectorEnv[any, any] = (*SyncVectorEnv[any, any])(nil)
+	_ VectorEnv[any, any] = (*AsyncVectorEnv[any, any])(nil)
+)
+
+// resetEnv resets a sub-environment and, if a seed is given, seeds its action space with the first seed spawned
+// from it, so that SampleActions draws reproducible actions from independent streams per sub-environment.
+func resetEnv[Obs any, Act any](ctx context.Context, env gym.Env[Obs, Act], seed *int64) (Obs, gym.Info, error) {
+	obs, info, err := env.Reset(ctx, seed, nil)
+	if err != nil || seed == nil {
+		return obs, info, err
+	}
+
+	seq, err := rand.NewSeedSequenceExact(*seed)
+	if err != nil {
+		return obs, info, fmt.Errorf("failed to derive action space seed: %w", err)
+	}
+	if _, err := env.ActionSpace().Seed(seq.Spawn(1)[0]); err != nil {
+		return obs, info, fmt.Errorf("failed to seed action space: %w", err)
+	}
+	return obs, info, nil
+}
+
+// sampleActions draws n actions, action i from the action space of envs[i % len(envs)].
+func sampleActions[Obs any, Act any](envs []gym.Env[Obs, Act], n int) ([]Act, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("number of actions must be positive, got %d", n)
+	}
+
+	actions := make([]Act, n)
+	for i := range actions {
+		action, err := envs[i%len(envs)].ActionSpace().Sample(nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sample action of sub-environment %d: %w", i%len(envs), err)
+		}
+		actions[i] = action
+	}
+	return actions, nil
+}
@@ -0,0 +1,229 @@
+package gym
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// TimeLimit truncates episodes after a maximum number of steps.
+//
+// Make applies it to environments whose spec sets MaxEpisodeSteps.
+type TimeLimit[Obs any, Act any] struct {
+	Env[Obs, Act]
+
+	maxEpisodeSteps int // Number of steps after which the episode is truncated
+	elapsedSteps    int // Number of steps of the current episode
+}
+
+// NewTimeLimit creates a new TimeLimit wrapper.
+//
+// Parameters:
+//   - env: The environment to wrap
+//   - maxEpisodeSteps: The number of steps after which the episode is truncated (must be positive)
+//
+// Returns:
+//   - A new TimeLimit wrapper
+//   - An error if maxEpisodeSteps is not positive
+func NewTimeLimit[Obs any, Act any](env Env[Obs, Act], maxEpisodeSteps int) (*TimeLimit[Obs, Act], error) {
+	if maxEpisodeSteps <= 0 {
+		return nil, fmt.Errorf("max episode steps must be positive, got %d", maxEpisodeSteps)
+	}
+
+	return &TimeLimit[Obs, Act]{
+		Env:             env,
+		maxEpisodeSteps: maxEpisodeSteps,
+	}, nil
+}
+
+// Step steps the environment and truncates the episode once the maximum number of steps is reached.
+func (t *TimeLimit[Obs, Act]) Step(ctx context.Context, action Act) (Obs, float64, bool, bool, Info, error) {
+	obs, reward, terminated, truncated, info, err := t.Env.Step(ctx, action)
+	if err != nil {
+		return obs, reward, terminated, truncated, info, err
+	}
+
+	t.elapsedSteps++
+	if t.elapsedSteps >= t.maxEpisodeSteps {
+		truncated = true
+	}
+	return obs, reward, terminated, truncated, info, nil
+}
+
+// Reset resets the environment and the step count.
+func (t *TimeLimit[Obs, Act]) Reset(ctx context.Context, seed *int64, options Info) (Obs, Info, error) {
+	t.elapsedSteps = 0
+	return t.Env.Reset(ctx, seed, options)
+}
+
+// MaxEpisodeSteps returns the number of steps after which the episode is truncated.
+func (t *TimeLimit[Obs, Act]) MaxEpisodeSteps() int {
+	return t.maxEpisodeSteps
+}
+
+// ElapsedSteps returns the number of steps of the current episode.
+func (t *TimeLimit[Obs, Act]) ElapsedSteps() int {
+	return t.elapsedSteps
+}
+
+// Inner returns the wrapped environment.
+func (t *TimeLimit[Obs, Act]) Inner() Env[Obs, Act] {
+	return t.Env
+}
+
+// OrderEnforcing returns an error if Step is called before Reset.
+//
+// Make applies it to environments whose spec sets OrderEnforce.
+type OrderEnforcing[Obs any, Act any] struct {
+	Env[Obs, Act]
+
+	hasReset bool // Whether Reset has been called
+}
+
+// NewOrderEnforcing creates a new OrderEnforcing wrapper.
+//
+// Parameters:
+//   - env: The environment to wrap
+//
+// Returns:
+//   - A new OrderEnforcing wrapper
+//   - An error if env is nil
+func NewOrderEnforcing[Obs any, Act any](env Env[Obs, Act]) (*OrderEnforcing[Obs, Act], error) {
+	if env == nil {
+		return nil, errors.New("env must not be nil")
+	}
+	return &OrderEnforcing[Obs, Act]{Env: env}, nil
+}
+
+// Step steps the environment, failing if it has not been reset.
+func (o *OrderEnforcing[Obs, Act]) Step(ctx context.Context, action Act) (Obs, float64, bool, bool, Info, error) {
+	if !o.hasReset {
+		var zero Obs
+		return zero, 0, false, false, nil, errors.New("cannot call Step before Reset")
+	}
+	return o.Env.Step(ctx, action)
+}
+
+// Reset resets the environment and allows Step to be called.
+func (o *OrderEnforcing[Obs, Act]) Reset(ctx context.Context, seed *int64, options Info) (Obs, Info, error) {
+	obs, info, err := o.Env.Reset(ctx, seed, options)
+	if err != nil {
+		return obs, info, err
+	}
+	o.hasReset = true
+	return obs, info, nil
+}
+
+// HasReset returns whether Reset has been called.
+func (o *OrderEnforcing[Obs, Act]) HasReset() bool {
+	return o.hasReset
+}
+
+// Inner returns the wrapped environment.
+func (o *OrderEnforcing[Obs, Act]) Inner() Env[Obs, Act] {
+	return o.Env
+}
+
+// PassiveEnvChecker checks the first Reset and Step of an environment without changing their results.
+//
+// Observations outside the observation space and non-finite rewards are reported to the logger installed
+// with SetLogger, as CheckEnv would report them. They are logged at debug level, and the default logger
+// discards every log, so they are only visible after SetLogger, e.g. with a *slog.Logger enabled at debug
+// level. Make applies it unless the spec sets DisableEnvChecker.
+type PassiveEnvChecker[Obs any, Act any] struct {
+	Env[Obs, Act]
+
+	checkedReset bool // Whether the first Reset has been checked
+	checkedStep  bool // Whether the first Step has been checked
+}
+
+// NewPassiveEnvChecker creates a new PassiveEnvChecker wrapper.
+//
+// Parameters:
+//   - env: The environment to wrap
+//
+// Returns:
+//   - A new PassiveEnvChecker wrapper
+//   - An error if the observation or action space of env is nil
+func NewPassiveEnvChecker[Obs any, Act any](env Env[Obs, Act]) (*PassiveEnvChecker[Obs, Act], error) {
+	if env.ObservationSpace() == nil {
+		return nil, errors.New("observation space is nil")
+	}
+	if env.ActionSpace() == nil {
+		return nil, errors.New("action space is nil")
+	}
+	return &PassiveEnvChecker[Obs, Act]{Env: env}, nil
+}
+
+// Step steps the environment, checking the result of the first step.
+func (p *PassiveEnvChecker[Obs, Act]) Step(ctx context.Context, action Act) (Obs, float64, bool, bool, Info, error) {
+	obs, reward, terminated, truncated, info, err := p.Env.Step(ctx, action)
+	if err != nil || p.checkedStep {
+		return obs, reward, terminated, truncated, info, err
+	}
+
+	p.checkedStep = true
+	if !p.ObservationSpace().Contains(obs) {
+		getLogger().Debug("step observation is not contained in the observation space", "observation", obs)
+	}
+	if math.IsNaN(reward) || math.IsInf(reward, 0) {
+		getLogger().Debug("step reward is not finite", "reward", reward)
+	}
+	return obs, reward, terminated, truncated, info, nil
+}
+
+// Reset resets the environment, checking the result of the first reset.
+func (p *PassiveEnvChecker[Obs, Act]) Reset(ctx context.Context, seed *int64, options Info) (Obs, Info, error) {
+	obs, info, err := p.Env.Reset(ctx, seed, options)
+	if err != nil || p.checkedReset {
+		return obs, info, err
+	}
+
+	p.checkedReset = true
+	if !p.ObservationSpace().Contains(obs) {
+		getLogger().Debug("reset observation is not contained in the observation space", "observation", obs)
+	}
+	return obs, info, nil
+}
+
+// Inner returns the wrapped environment.
+func (p *PassiveEnvChecker[Obs, Act]) Inner() Env[Obs, Act] {
+	return p.Env
+}
+
+// Wrappers returns the type names of the wrappers applied to env, from the outermost to the innermost.
+//
+// The chain is followed through the Inner method of each wrapper, which every wrapper of this module
+// provides, so it also crosses wrappers that change the observation or action types. Type parameters are
+// omitted from the names, e.g. a CartPole made with Make gives ["TimeLimit", "OrderEnforcing", "PassiveEnvChecker"].
+//
+// Parameters:
+//   - env: The environment, of any Env type
+//
+// Returns:
+//   - The type names of the wrappers, or nil if env is not wrapped
+func Wrappers(env any) []string {
+	var names []string
+	for env != nil {
+		inner := reflect.ValueOf(env).MethodByName("Inner")
+		if !inner.IsValid() || inner.Type().NumIn() != 0 || inner.Type().NumOut() != 1 {
+			break
+		}
+
+		names = append(names, wrapperName(reflect.TypeOf(env)))
+		env = inner.Call(nil)[0].Interface()
+	}
+	return names
+}
+
+// wrapperName returns the name of a wrapper type without its pointer and type parameters.
+func wrapperName(t reflect.Type) string {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	name, _, _ := strings.Cut(t.Name(), "[")
+	return name
+}
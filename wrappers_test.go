@@ -0,0 +1,52 @@
+package gym_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gocnn/gym"
+	"github.com/gocnn/gym/envs/classic"
+	"github.com/gocnn/gym/envs/wrappers"
+)
+
+func TestWrappers(t *testing.T) {
+	env, err := classic.NewCartPoleEnv(nil)
+	if err != nil {
+		t.Fatalf("NewCartPoleEnv failed: %v", err)
+	}
+	defer env.Close()
+
+	if names := gym.Wrappers(env); names != nil {
+		t.Errorf("Wrappers of an unwrapped environment = %v, want nil", names)
+	}
+
+	timeLimit, err := gym.NewTimeLimit[[]float64, int](env, 10)
+	if err != nil {
+		t.Fatalf("NewTimeLimit failed: %v", err)
+	}
+	orderEnforcing, err := gym.NewOrderEnforcing[[]float64, int](timeLimit)
+	if err != nil {
+		t.Fatalf("NewOrderEnforcing failed: %v", err)
+	}
+	if names, want := gym.Wrappers(orderEnforcing), []string{"OrderEnforcing", "TimeLimit"}; !slices.Equal(names, want) {
+		t.Errorf("Wrappers = %v, want %v", names, want)
+	}
+
+	// The chain continues through wrappers changing the observation type
+	discretized, err := wrappers.NewDiscretizeObservation[int](orderEnforcing, []int{2, 2, 2, 2}, []float64{-1, -1, -1, -1}, []float64{1, 1, 1, 1})
+	if err != nil {
+		t.Fatalf("NewDiscretizeObservation failed: %v", err)
+	}
+	if names, want := gym.Wrappers(discretized), []string{"DiscretizeObservation", "OrderEnforcing", "TimeLimit"}; !slices.Equal(names, want) {
+		t.Errorf("Wrappers = %v, want %v", names, want)
+	}
+
+	made, err := gym.Make[[]float64, int]("CartPole-v1", nil)
+	if err != nil {
+		t.Fatalf("Make failed: %v", err)
+	}
+	defer made.Close()
+	if names, want := gym.Wrappers(made), []string{"TimeLimit", "OrderEnforcing", "PassiveEnvChecker"}; !slices.Equal(names, want) {
+		t.Errorf("Wrappers of a made CartPole = %v, want %v", names, want)
+	}
+}